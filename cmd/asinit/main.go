@@ -21,28 +21,47 @@ import (
 	"fmt"
 	"io/ioutil"
 	"strings"
+	"time"
 
 	"github.com/travelaudience/aerospike-operator/pkg/reconciler"
 
 	log "github.com/sirupsen/logrus"
 )
 
+const (
+	// altAddrFilePollPeriod is how often the file containing the alternate
+	// access address is read while it is empty
+	altAddrFilePollPeriod = 2 * time.Second
+	// altAddrFileTimeout is how long we wait for the file containing the
+	// alternate access address to be populated
+	altAddrFileTimeout = 5 * time.Minute
+)
+
 var (
-	nodeId    string
-	peerList  string
-	sourceCfg string
-	targetCfg string
+	nodeId      string
+	rackId      string
+	altAddr     string
+	altAddrFile string
+	altPort     string
+	peerList    string
+	sourceCfg   string
+	targetCfg   string
 )
 
 func init() {
 	flag.StringVar(&nodeId, "node-id", "", "the node id for the current aerospike node")
+	flag.StringVar(&rackId, "rack-id", "", "the rack id for the current aerospike node")
+	flag.StringVar(&altAddr, "alternate-access-address", "", "the address at which the current aerospike node can be reached from outside the kubernetes cluster")
+	flag.StringVar(&altAddrFile, "alternate-access-address-file", "", "path to the file containing the address at which the current aerospike node can be reached from outside the kubernetes cluster")
+	flag.StringVar(&altPort, "alternate-access-port", "", "the port at which the current aerospike node can be reached from outside the kubernetes cluster")
 	flag.StringVar(&peerList, "peer-list", "", "comma-separated list of peers for the current aerospike node")
 	flag.StringVar(&sourceCfg, "source-config", "", "path to the source configuration file")
 	flag.StringVar(&targetCfg, "target-config", "", "path to the target configuration file")
 }
 
-// asinit takes a node id and a list of peers for a given aerospike
-// node and updates the source configuration file with these values.
+// asinit takes a node id, a rack id, an alternate access address and port
+// and a list of peers for a given aerospike node and updates the source
+// configuration file with these values.
 // this allows for setting node-specific configuration parameter
 // which can't be set using the common configmap.
 func main() {
@@ -55,6 +74,14 @@ func main() {
 		log.Fatalf("failed to read source configuration file: %v", err)
 	}
 
+	// wait for the alternate access address to be made available if it
+	// is read from a file
+	if altAddrFile != "" {
+		if altAddr, err = readAltAddrFile(altAddrFile); err != nil {
+			log.Fatalf("failed to read alternate access address: %v", err)
+		}
+	}
+
 	// create a split function that returns an empty slice
 	// when the peerList is empty
 	splitFn := func(c rune) bool {
@@ -72,9 +99,33 @@ func main() {
 	cfg := string(input)
 	cfg = strings.Replace(cfg, reconciler.ServiceNodeIdValue, nodeId, -1)
 	cfg = strings.Replace(cfg, reconciler.HeartbeatAddressesValue, peers.String(), -1)
+	cfg = strings.Replace(cfg, reconciler.NamespaceRackIdValue, rackId, -1)
+	cfg = strings.Replace(cfg, reconciler.ServiceAlternateAccessAddressValue, altAddr, -1)
+	cfg = strings.Replace(cfg, reconciler.ServiceAlternateAccessPortValue, altPort, -1)
 
 	// create the target configuration file
 	if err := ioutil.WriteFile(targetCfg, []byte(cfg), 0777); err != nil {
 		log.Fatalf("failed to create target configuration file: %v", err)
 	}
 }
+
+// readAltAddrFile waits for the specified file to be populated (which happens
+// once aerospike-operator sets the corresponding pod annotation) and returns
+// its contents.
+func readAltAddrFile(path string) (string, error) {
+	deadline := time.Now().Add(altAddrFileTimeout)
+	for {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		if v := strings.TrimSpace(string(b)); v != "" {
+			return v, nil
+		}
+		if time.Now().After(deadline) {
+			return "", fmt.Errorf("timed out waiting for %s to be populated", path)
+		}
+		log.Infof("waiting for %s to be populated", path)
+		time.Sleep(altAddrFilePollPeriod)
+	}
+}
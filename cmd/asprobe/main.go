@@ -0,0 +1,151 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/travelaudience/aerospike-operator/pkg/asutils"
+)
+
+const (
+	modeLiveness  = "liveness"
+	modeReadiness = "readiness"
+)
+
+var (
+	mode                    string
+	host                    string
+	port                    int
+	expectedClusterSizeFile string
+	stateFile               string
+)
+
+func init() {
+	flag.StringVar(&mode, "mode", modeReadiness, "the type of probe to perform (liveness or readiness)")
+	flag.StringVar(&host, "host", "127.0.0.1", "the host at which the aerospike node can be reached")
+	flag.IntVar(&port, "port", asutils.ServicePort, "the port at which the aerospike node can be reached")
+	flag.StringVar(&expectedClusterSizeFile, "expected-cluster-size-file", "", "path to the file containing the expected size of the cluster")
+	flag.StringVar(&stateFile, "state-file", "", "path to the file where the cluster key observed by the previous readiness probe is stored")
+}
+
+// asprobe checks whether the aerospike node running in the current pod is
+// alive or ready to serve requests, and exits with a non-zero code if it is
+// not. an aerospike node is alive if it responds to the "status" info
+// command. an aerospike node is ready if it responds to the "status" info
+// command, observes the expected cluster size, observes the same cluster key
+// as in the previous readiness probe and reports no unavailable partitions.
+// aerospike-operator lowers the expected cluster size before deliberately
+// deleting a pod, and a single change of the cluster key only fails a single
+// readiness probe, so that the restart of a single aerospike node does not
+// cause every aerospike node to become unready at the same time.
+func main() {
+	// parse the configuration flags
+	flag.Parse()
+
+	switch mode {
+	case modeLiveness:
+		if err := asutils.CheckNodeStatus(host, port); err != nil {
+			log.Fatalf("aerospike node is not alive: %v", err)
+		}
+	case modeReadiness:
+		health, err := asutils.GetNodeHealth(host, port)
+		if err != nil {
+			log.Fatalf("aerospike node is not ready: %v", err)
+		}
+		expectedClusterSize, err := readExpectedClusterSize()
+		if err != nil {
+			log.Fatalf("aerospike node is not ready: %v", err)
+		}
+		previousClusterKey, err := swapClusterKey(health.ClusterKey)
+		if err != nil {
+			log.Fatalf("aerospike node is not ready: %v", err)
+		}
+		if err := checkReadiness(health, expectedClusterSize, previousClusterKey); err != nil {
+			log.Fatalf("aerospike node is not ready: %v", err)
+		}
+	default:
+		log.Fatalf("unknown mode %q", mode)
+	}
+}
+
+// readExpectedClusterSize reads the expected cluster size from the specified
+// file. the expected cluster size is kept up-to-date by aerospike-operator, so
+// it is read every time.
+func readExpectedClusterSize() (int, error) {
+	if expectedClusterSizeFile == "" {
+		return 1, nil
+	}
+	b, err := ioutil.ReadFile(expectedClusterSizeFile)
+	if err != nil {
+		return 0, err
+	}
+	v := strings.TrimSpace(string(b))
+	if v == "" {
+		return 1, nil
+	}
+	return strconv.Atoi(v)
+}
+
+// swapClusterKey stores the specified cluster key in the state file and
+// returns the cluster key stored by the previous readiness probe (if any). if
+// no state file has been specified, the specified cluster key is returned.
+func swapClusterKey(clusterKey string) (string, error) {
+	if stateFile == "" {
+		return clusterKey, nil
+	}
+	previousClusterKey := ""
+	if b, err := ioutil.ReadFile(stateFile); err == nil {
+		previousClusterKey = strings.TrimSpace(string(b))
+	} else if !os.IsNotExist(err) {
+		return "", err
+	}
+	if err := ioutil.WriteFile(stateFile, []byte(clusterKey), 0644); err != nil {
+		return "", err
+	}
+	return previousClusterKey, nil
+}
+
+// checkReadiness returns an error if the aerospike node with the specified
+// health is not ready to serve requests.
+func checkReadiness(health *asutils.NodeHealth, expectedClusterSize int, previousClusterKey string) error {
+	if health.Status != "ok" {
+		return fmt.Errorf("unexpected status: %s", health.Status)
+	}
+	// the node must observe every node that aerospike-operator expects to be
+	// part of the cluster, or it has not (fully) joined the cluster yet
+	if health.ClusterSize < expectedClusterSize {
+		return fmt.Errorf("cluster size is %d, expected at least %d", health.ClusterSize, expectedClusterSize)
+	}
+	// the cluster is still being formed while its key keeps on changing
+	if health.ClusterKey != previousClusterKey {
+		return fmt.Errorf("cluster key changed from %q to %q", previousClusterKey, health.ClusterKey)
+	}
+	// unavailable partitions are only reported for namespaces with strong
+	// consistency enabled, in which case they cannot be served by any node
+	if health.UnavailablePartitions > 0 {
+		return fmt.Errorf("%d partitions are unavailable", health.UnavailablePartitions)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/travelaudience/aerospike-operator/pkg/asutils"
+)
+
+func TestCheckReadiness(t *testing.T) {
+	tests := []struct {
+		health              *asutils.NodeHealth
+		expectedClusterSize int
+		previousClusterKey  string
+		ready               bool
+	}{
+		{&asutils.NodeHealth{Status: "ok", ClusterSize: 1, ClusterKey: "A"}, 1, "A", true},
+		{&asutils.NodeHealth{Status: "ok", ClusterSize: 3, ClusterKey: "A"}, 3, "A", true},
+		// nodes that joined before the expected cluster size was raised must
+		// not make the node unready
+		{&asutils.NodeHealth{Status: "ok", ClusterSize: 4, ClusterKey: "A"}, 3, "A", true},
+		{&asutils.NodeHealth{Status: "ok", ClusterSize: 2, ClusterKey: "A"}, 3, "A", false},
+		{&asutils.NodeHealth{Status: "ok", ClusterSize: 1, ClusterKey: "A"}, 3, "A", false},
+		{&asutils.NodeHealth{Status: "ok", ClusterSize: 3, ClusterKey: "B"}, 3, "A", false},
+		{&asutils.NodeHealth{Status: "ok", ClusterSize: 3, ClusterKey: "A"}, 3, "", false},
+		{&asutils.NodeHealth{Status: "ok", ClusterSize: 3, ClusterKey: "A", UnavailablePartitions: 12}, 3, "A", false},
+		{&asutils.NodeHealth{Status: "error", ClusterSize: 3, ClusterKey: "A"}, 3, "A", false},
+	}
+	for _, test := range tests {
+		err := checkReadiness(test.health, test.expectedClusterSize, test.previousClusterKey)
+		if test.ready {
+			assert.NoError(t, err)
+		} else {
+			assert.Error(t, err)
+		}
+	}
+}
+
+func TestSwapClusterKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "asprobe")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	stateFile = path.Join(dir, "cluster-key")
+	defer func() { stateFile = "" }()
+
+	// no cluster key has been observed by a previous readiness probe
+	previous, err := swapClusterKey("A")
+	assert.NoError(t, err)
+	assert.Equal(t, "", previous)
+	previous, err = swapClusterKey("A")
+	assert.NoError(t, err)
+	assert.Equal(t, "A", previous)
+	previous, err = swapClusterKey("B")
+	assert.NoError(t, err)
+	assert.Equal(t, "A", previous)
+}
@@ -44,15 +44,21 @@ import (
 	"github.com/travelaudience/aerospike-operator/pkg/crd"
 	v1alpha2converters "github.com/travelaudience/aerospike-operator/pkg/crd/converters/v1alpha2"
 	"github.com/travelaudience/aerospike-operator/pkg/debug"
+	"github.com/travelaudience/aerospike-operator/pkg/images"
 	"github.com/travelaudience/aerospike-operator/pkg/signals"
 	flagutils "github.com/travelaudience/aerospike-operator/pkg/utils/flags"
 	"github.com/travelaudience/aerospike-operator/pkg/versioning"
 )
 
 const (
-	admissionEnabledFlag = "admission-enabled"
-	debugEnabledFlag     = "debug"
-	kubeconfigFlag       = "kubeconfig"
+	admissionEnabledFlag                    = "admission-enabled"
+	aerospikeServerRepositoryFlag           = "aerospike-server-repository"
+	aerospikeServerEnterpriseRepositoryFlag = "aerospike-server-enterprise-repository"
+	debugEnabledFlag                        = "debug"
+	imagePullPolicyFlag                     = "image-pull-policy"
+	imagePullSecretsFlag                    = "image-pull-secrets"
+	kubeconfigFlag                          = "kubeconfig"
+	toolsRepositoryFlag                     = "tools-repository"
 )
 
 var (
@@ -66,6 +72,11 @@ func init() {
 	fs.BoolVar(&debug.DebugEnabled, debugEnabledFlag, false, "[DEPRECATED] Whether to enable debug mode.")
 	fs.StringVar(&kubeconfig, kubeconfigFlag, "", "Path to a kubeconfig. Only required if out-of-cluster.")
 	fs.BoolVar(&admission.Enabled, admissionEnabledFlag, true, "[DEPRECATED] Whether to enable the validating admission webhook.")
+	fs.StringVar(&images.AerospikeServerRepository, aerospikeServerRepositoryFlag, images.AerospikeServerRepository, "The repository from which to pull the aerospike-server image.")
+	fs.StringVar(&images.AerospikeServerEnterpriseRepository, aerospikeServerEnterpriseRepositoryFlag, images.AerospikeServerEnterpriseRepository, "The repository from which to pull the aerospike-server-enterprise image.")
+	fs.StringVar(&images.ToolsRepository, toolsRepositoryFlag, images.ToolsRepository, "The repository from which to pull the aerospike-operator-tools image.")
+	fs.StringVar(&images.PullPolicy, imagePullPolicyFlag, "", "The pull policy to use for every image (Always, IfNotPresent or Never).")
+	fs.StringVar(&images.PullSecrets, imagePullSecretsFlag, "", "Comma-separated list of names of the secrets to use when pulling images.")
 }
 
 func main() {
@@ -74,6 +85,11 @@ func main() {
 	// warn about deprecated flags
 	flagutils.DeprecateFlags(fs, admissionEnabledFlag, debugEnabledFlag)
 
+	// make sure that the specified image pull policy is valid
+	if err := images.ValidatePullPolicy(); err != nil {
+		log.Fatalf("invalid value for --%s: %v", imagePullPolicyFlag, err)
+	}
+
 	// workaround for https://github.com/kubernetes/kubernetes/issues/17162
 	flag.CommandLine.Parse([]string{})
 
@@ -204,6 +220,8 @@ func run(stopCh chan struct{}, cfg *restclient.Config, kubeClient *kubernetes.Cl
 	clusterController := controller.NewAerospikeClusterController(kubeClient, aerospikeClient, kubeInformerFactory, aerospikeInformerFactory)
 	backupController := controller.NewAerospikeNamespaceBackupController(kubeClient, aerospikeClient, kubeInformerFactory, aerospikeInformerFactory)
 	restoreController := controller.NewAerospikeNamespaceRestoreController(kubeClient, aerospikeClient, kubeInformerFactory, aerospikeInformerFactory)
+	secondaryIndexController := controller.NewAerospikeSecondaryIndexController(kubeClient, aerospikeClient, kubeInformerFactory, aerospikeInformerFactory)
+	udfController := controller.NewAerospikeUDFController(kubeClient, aerospikeClient, kubeInformerFactory, aerospikeInformerFactory)
 	gcController := controller.NewGarbageCollectorController(kubeClient, aerospikeClient, kubeInformerFactory, aerospikeInformerFactory)
 
 	// start the shared informer factories
@@ -212,7 +230,7 @@ func run(stopCh chan struct{}, cfg *restclient.Config, kubeClient *kubernetes.Cl
 
 	// start the controllers
 	var wg sync.WaitGroup
-	controllers := []controller.Controller{clusterController, backupController, restoreController, gcController}
+	controllers := []controller.Controller{clusterController, backupController, restoreController, secondaryIndexController, udfController, gcController}
 	for _, c := range controllers {
 		wg.Add(1)
 		go func(c controller.Controller) {
@@ -19,13 +19,22 @@ package admission
 import (
 	"fmt"
 	"reflect"
+	"strings"
 
 	av1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"k8s.io/apimachinery/pkg/api/errors"
 
+	"github.com/travelaudience/aerospike-operator/pkg/apis/aerospike"
+	"github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/common"
 	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
+	"github.com/travelaudience/aerospike-operator/pkg/asutils"
+	"github.com/travelaudience/aerospike-operator/pkg/utils/annotations"
+	"github.com/travelaudience/aerospike-operator/pkg/utils/selectors"
 	"github.com/travelaudience/aerospike-operator/pkg/versioning"
 )
 
@@ -47,6 +56,11 @@ const (
 	// the length corresponds to the maximum length of a pod name (63 characters) minus the dash and
 	// the index (a single digit).
 	AerospikeClusterNameMaxLength = 61
+	// aerospikeClusterNameMaxLengthWithExternalAccess represents the maximum length of an AerospikeCluster's
+	// metadata.name when external access is enabled. The length corresponds to AerospikeClusterNameMaxLength minus
+	// the length of the suffix appended to the name of each pod in order to obtain the name of its service
+	// ("-external").
+	aerospikeClusterNameMaxLengthWithExternalAccess = AerospikeClusterNameMaxLength - 9
 	// aerospikeNamespaceMaxNameLen represents the maximum length of an AerospikeCluster's namespace name.
 	// The length corresponds to the maximum length of a pod name (63 characters) minus 40 chars
 	// corresponding to the following:
@@ -58,6 +72,32 @@ const (
 	// the default replication factor for an aerospike namespace
 	// https://www.aerospike.com/docs/reference/configuration#replication-factor
 	defaultNamespaceReplicationFactor int32 = 2
+	// aerospikeMaxNamespaces represents the maximum number of namespaces that
+	// can be configured in a single Aerospike Community Edition cluster.
+	// https://www.aerospike.com/products/product-matrix/
+	aerospikeMaxNamespaces = 2
+	// aerospikeMaxNamespacesEnterprise represents the maximum number of
+	// namespaces that can be configured in a single Aerospike Enterprise
+	// Edition cluster.
+	// https://www.aerospike.com/products/product-matrix/
+	aerospikeMaxNamespacesEnterprise = 32
+	// minReplicationFactorForStorageChange represents the minimum replication
+	// factor a namespace must have so that its storage spec can be changed
+	// without losing data.
+	minReplicationFactorForStorageChange int32 = 2
+	// minReplicationFactorForMemoryScaleDown represents the minimum
+	// replication factor a namespace that stores data in memory must have so
+	// that the cluster can be scaled down without losing data.
+	minReplicationFactorForMemoryScaleDown int32 = 2
+	// aerospikeMaxRackId represents the maximum value of namespace.rack-id.
+	// https://www.aerospike.com/docs/reference/configuration#rack-id
+	aerospikeMaxRackId = 1000000
+	// defaultStorageClassAnnotation is the annotation used to mark a storage
+	// class as the default one.
+	defaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+	// betaDefaultStorageClassAnnotation is the (deprecated) beta version of
+	// defaultStorageClassAnnotation.
+	betaDefaultStorageClassAnnotation = "storageclass.beta.kubernetes.io/is-default-class"
 )
 
 func (s *ValidatingAdmissionWebhook) admitAerospikeCluster(ar av1beta1.AdmissionReview) *av1beta1.AdmissionResponse {
@@ -75,6 +115,10 @@ func (s *ValidatingAdmissionWebhook) admitAerospikeCluster(ar av1beta1.Admission
 	if err = s.validateAerospikeCluster(new); err != nil {
 		return admissionResponseFromError(err)
 	}
+	// validate the requested recovery action (if any)
+	if err = validateRecovery(old, new); err != nil {
+		return admissionResponseFromError(err)
+	}
 	// if this is an update, validate that the transition from old to new
 	if ar.Request.Operation == av1beta1.Update {
 		if err = s.validateAerospikeClusterUpdate(old, new); err != nil {
@@ -96,16 +140,36 @@ func (s *ValidatingAdmissionWebhook) validateAerospikeCluster(aerospikeCluster *
 		return fmt.Errorf("the current combination of cluster and kubernetes namespace names cannot be used")
 	}
 
-	// validate the Aerospike version
+	// validate that the per-pod services created when external access is
+	// enabled have valid names
+	if aerospikeCluster.Spec.ExternalAccess != nil && len(aerospikeCluster.Name) > aerospikeClusterNameMaxLengthWithExternalAccess {
+		return fmt.Errorf("the name of a cluster with external access enabled cannot exceed %d characters", aerospikeClusterNameMaxLengthWithExternalAccess)
+	}
+
+	// validate the Aerospike version against the requested edition
+	edition := aerospikeCluster.Spec.GetEdition()
 	if version, err := versioning.NewVersionFromString(aerospikeCluster.Spec.Version); err != nil {
 		return err
 	} else if !version.IsSupported() {
 		return fmt.Errorf("aerospike version %q is not supported", aerospikeCluster.Spec.Version)
+	} else if !versioning.IsSupportedForEdition(aerospikeCluster.Spec.Version, edition) {
+		return fmt.Errorf("aerospike version %q is not supported for the %s edition", aerospikeCluster.Spec.Version, edition)
 	}
 
-	// enforce the existence of a single namespace per cluster
-	if len(aerospikeCluster.Spec.Namespaces) != 1 {
-		return fmt.Errorf("the number of namespaces in the cluster must be exactly one")
+	// enforce the existence of at least one and at most
+	// aerospikeMaxNamespaces (or aerospikeMaxNamespacesEnterprise)
+	// namespaces per cluster
+	maxNamespaces := aerospikeMaxNamespaces
+	if edition == common.EditionEnterprise {
+		maxNamespaces = aerospikeMaxNamespacesEnterprise
+	}
+	if len(aerospikeCluster.Spec.Namespaces) < 1 || len(aerospikeCluster.Spec.Namespaces) > maxNamespaces {
+		return fmt.Errorf("the number of namespaces in the cluster must be between 1 and %d", maxNamespaces)
+	}
+
+	// prevent two namespaces with the same name from appearing in the spec
+	if len(namespaceMap(aerospikeCluster)) < len(aerospikeCluster.Spec.Namespaces) {
+		return fmt.Errorf("namespace names must be unique")
 	}
 
 	// validate every namespace's name and that its replication factor
@@ -116,13 +180,43 @@ func (s *ValidatingAdmissionWebhook) validateAerospikeCluster(aerospikeCluster *
 		}
 		// the current replication factor equals aerospike's default, unless it
 		// has been set by the user
-		currentReplicationFactor := defaultNamespaceReplicationFactor
-		if ns.ReplicationFactor != nil {
-			currentReplicationFactor = *ns.ReplicationFactor
-		}
+		currentReplicationFactor := replicationFactor(ns)
 		if currentReplicationFactor > aerospikeCluster.Spec.NodeCount {
 			return fmt.Errorf("replication factor of %d requested for namespace %s but the cluster has only %d nodes", currentReplicationFactor, ns.Name, aerospikeCluster.Spec.NodeCount)
 		}
+		if err := validateNamespaceStorage(ns); err != nil {
+			return err
+		}
+	}
+
+	// validate the overrides to the aerospike configuration
+	if err := validateAerospikeConfig(aerospikeCluster); err != nil {
+		return err
+	}
+
+	// validate the compute resources for each container
+	if err := validateResources(aerospikeCluster); err != nil {
+		return err
+	}
+
+	// validate the racks across which nodes are distributed
+	if err := validateRacks(aerospikeCluster); err != nil {
+		return err
+	}
+
+	// validate the nodes under maintenance
+	if err := validateNodesUnderMaintenance(aerospikeCluster); err != nil {
+		return err
+	}
+
+	// validate the overrides to the pod spec
+	if err := validatePodSpec(aerospikeCluster); err != nil {
+		return err
+	}
+
+	// validate the feature key secret required by the enterprise edition
+	if err := s.validateFeatureKeySecret(aerospikeCluster); err != nil {
+		return err
 	}
 
 	// if backupSpec is specified, make sure that the secret containing
@@ -145,6 +239,207 @@ func (s *ValidatingAdmissionWebhook) validateAerospikeCluster(aerospikeCluster *
 	return nil
 }
 
+// validateNodesUnderMaintenance makes sure that the indexes of the nodes
+// under maintenance are unique and refer to existing nodes, that at least one
+// node remains available, and that fewer nodes than the lowest replication
+// factor across all namespaces are under maintenance, so that every partition
+// remains available and no data stored in memory is lost as a result of
+// deleting the corresponding pods.
+func validateNodesUnderMaintenance(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) error {
+	nodesUnderMaintenance := aerospikeCluster.Spec.NodesUnderMaintenance
+	if len(nodesUnderMaintenance) == 0 {
+		return nil
+	}
+	seen := make(map[int32]bool, len(nodesUnderMaintenance))
+	for _, index := range nodesUnderMaintenance {
+		if index < 0 || index >= aerospikeCluster.Spec.NodeCount {
+			return fmt.Errorf("node index %d under maintenance must be between 0 and %d", index, aerospikeCluster.Spec.NodeCount-1)
+		}
+		if seen[index] {
+			return fmt.Errorf("node indexes under maintenance must be unique")
+		}
+		seen[index] = true
+	}
+	if int32(len(nodesUnderMaintenance)) >= aerospikeCluster.Spec.NodeCount {
+		return fmt.Errorf("at least one node must not be under maintenance")
+	}
+	for _, ns := range aerospikeCluster.Spec.Namespaces {
+		if int32(len(nodesUnderMaintenance)) >= replicationFactor(ns) {
+			return fmt.Errorf("the number of nodes under maintenance must be lower than the replication factor of namespace %s (%d)", ns.Name, replicationFactor(ns))
+		}
+	}
+	return nil
+}
+
+// validateFeatureKeySecret makes sure that a feature key secret is specified
+// if and only if the enterprise edition is requested, and that it exists and
+// contains the expected field.
+func (s *ValidatingAdmissionWebhook) validateFeatureKeySecret(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) error {
+	featureKeySecret := aerospikeCluster.Spec.FeatureKeySecret
+	if aerospikeCluster.Spec.GetEdition() != common.EditionEnterprise {
+		if featureKeySecret != nil {
+			return fmt.Errorf("a feature key secret can only be specified for the %s edition", common.EditionEnterprise)
+		}
+		return nil
+	}
+	if featureKeySecret == nil {
+		return fmt.Errorf("a feature key secret must be specified for the %s edition", common.EditionEnterprise)
+	}
+	secret, err := s.kubeClient.CoreV1().Secrets(aerospikeCluster.Namespace).Get(featureKeySecret.Name, v1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("secret %q not found in namespace %q", featureKeySecret.Name, aerospikeCluster.Namespace)
+		}
+		return err
+	}
+	if _, ok := secret.Data[featureKeySecret.GetKey()]; !ok {
+		return fmt.Errorf("secret %q does not contain expected field %q", featureKeySecret.Name, featureKeySecret.GetKey())
+	}
+	return nil
+}
+
+// validateNamespaceStorage makes sure that a storage size is specified for
+// namespaces backed by persistent volumes, and that no properties specific to
+// persistent storage are specified for namespaces that store data in memory.
+func validateNamespaceStorage(ns aerospikev1alpha2.AerospikeNamespaceSpec) error {
+	if ns.Storage.Type != common.StorageTypeMemory {
+		if ns.Storage.Size == "" {
+			return fmt.Errorf("the storage size for namespace %s must be specified", ns.Name)
+		}
+		return nil
+	}
+	if ns.Storage.DataInMemory != nil {
+		return fmt.Errorf("dataInMemory cannot be specified for namespace %s, which stores data in memory", ns.Name)
+	}
+	if ns.Storage.VolumeCount != nil {
+		return fmt.Errorf("volumeCount cannot be specified for namespace %s, which stores data in memory", ns.Name)
+	}
+	for key := range ns.AerospikeConfig {
+		if asutils.StorageEngineConfigKeys.Has(key) {
+			return fmt.Errorf("property %q cannot be specified for namespace %s, which stores data in memory", key, ns.Name)
+		}
+	}
+	return nil
+}
+
+// validateAerospikeConfig validates the overrides to the aerospike
+// configuration against the set of properties that can be set by the user.
+func validateAerospikeConfig(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) error {
+	if config := aerospikeCluster.Spec.AerospikeConfig; config != nil {
+		if err := validateConfigKeys("service", config.Service, asutils.ServiceConfigKeys); err != nil {
+			return err
+		}
+		if config.Network != nil {
+			if err := validateConfigKeys("network.heartbeat", config.Network.Heartbeat, asutils.HeartbeatConfigKeys); err != nil {
+				return err
+			}
+			if err := validateConfigKeys("network.fabric", config.Network.Fabric, asutils.FabricConfigKeys); err != nil {
+				return err
+			}
+		}
+		if err := validateConfigKeys("logging", config.Logging, asutils.LoggingContexts); err != nil {
+			return err
+		}
+		for context, level := range config.Logging {
+			if !asutils.LoggingLevels.Has(level) {
+				return fmt.Errorf("invalid logging level %q for context %q", level, context)
+			}
+		}
+	}
+	for _, ns := range aerospikeCluster.Spec.Namespaces {
+		for key := range ns.AerospikeConfig {
+			if !asutils.NamespaceConfigKeys.Has(key) && !asutils.StorageEngineConfigKeys.Has(key) {
+				return fmt.Errorf("unsupported property %q in the configuration of namespace %s", key, ns.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// validateResources makes sure that only cpu and memory requirements are
+// specified for each container, and that no request exceeds its limit.
+func validateResources(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) error {
+	if aerospikeCluster.Spec.Resources == nil {
+		return nil
+	}
+	containers := []struct {
+		name      string
+		resources *corev1.ResourceRequirements
+	}{
+		{"aerospike-server", aerospikeCluster.Spec.Resources.AerospikeServer},
+		{"asprom", aerospikeCluster.Spec.Resources.Asprom},
+		{"init", aerospikeCluster.Spec.Resources.Init},
+	}
+	for _, c := range containers {
+		container, resources := c.name, c.resources
+		if resources == nil {
+			continue
+		}
+		for _, list := range []corev1.ResourceList{resources.Requests, resources.Limits} {
+			for name := range list {
+				if name != corev1.ResourceCPU && name != corev1.ResourceMemory {
+					return fmt.Errorf("unsupported resource %q for the %s container", name, container)
+				}
+			}
+		}
+		for name, request := range resources.Requests {
+			if limit, ok := resources.Limits[name]; ok && request.Cmp(limit) > 0 {
+				return fmt.Errorf("the %s request for the %s container cannot exceed its limit", name, container)
+			}
+		}
+	}
+	return nil
+}
+
+// validateRacks makes sure that rack ids are valid and unique, and that
+// every rack specifies where its pods must be scheduled.
+func validateRacks(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) error {
+	ids := make(map[int32]bool, len(aerospikeCluster.Spec.Racks))
+	for _, rack := range aerospikeCluster.Spec.Racks {
+		if rack.ID < 1 || rack.ID > aerospikeMaxRackId {
+			return fmt.Errorf("rack ids must be between 1 and %d", aerospikeMaxRackId)
+		}
+		if ids[rack.ID] {
+			return fmt.Errorf("rack ids must be unique")
+		}
+		ids[rack.ID] = true
+		if rack.Zone == "" && len(rack.NodeSelector) == 0 {
+			return fmt.Errorf("rack %d must specify a zone or a node selector", rack.ID)
+		}
+	}
+	return nil
+}
+
+// validatePodSpec makes sure that the overrides to the pod spec do not
+// target labels and annotations managed by aerospike-operator.
+func validatePodSpec(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) error {
+	if aerospikeCluster.Spec.PodSpec == nil {
+		return nil
+	}
+	for key := range aerospikeCluster.Spec.PodSpec.Labels {
+		if key == selectors.LabelAppKey || key == selectors.LabelClusterKey || key == selectors.LabelRackKey || key == selectors.LabelPodKey {
+			return fmt.Errorf("label %q is reserved and cannot be specified", key)
+		}
+	}
+	for key := range aerospikeCluster.Spec.PodSpec.Annotations {
+		if strings.HasPrefix(key, aerospike.GroupName+"/") {
+			return fmt.Errorf("annotation %q is reserved and cannot be specified", key)
+		}
+	}
+	return nil
+}
+
+// validateConfigKeys makes sure that every key in config belongs to the
+// specified set of supported keys.
+func validateConfigKeys(stanza string, config map[string]string, supported asutils.KeySet) error {
+	for key := range config {
+		if !supported.Has(key) {
+			return fmt.Errorf("unsupported property %q in the %s stanza of the aerospike configuration", key, stanza)
+		}
+	}
+	return nil
+}
+
 func (s *ValidatingAdmissionWebhook) validateAerospikeClusterUpdate(old, new *aerospikev1alpha2.AerospikeCluster) error {
 	// check whether a version upgrade has been requested, in which case we
 	// prevent configuration/topology changes from occurring simultaneously
@@ -153,6 +448,9 @@ func (s *ValidatingAdmissionWebhook) validateAerospikeClusterUpdate(old, new *ae
 		tmp := new.DeepCopy()
 		// set tmp.Spec.Version to old.Spec.Version
 		tmp.Spec.Version = old.Spec.Version
+		// aerospike-operator removes .spec.recovery when changing
+		// .spec.version as part of a recovery action
+		tmp.Spec.Recovery = old.Spec.Recovery
 		// check if old.Spec and tmp.Spec differ
 		// if they do, more than just .spec.Version has been been changed
 		// between old and new, and new must be rejected
@@ -165,14 +463,34 @@ func (s *ValidatingAdmissionWebhook) validateAerospikeClusterUpdate(old, new *ae
 		}
 	}
 
+	// prevent the edition from being changed, as the data files of each
+	// edition are not guaranteed to be compatible with the other one
+	if old.Spec.GetEdition() != new.Spec.GetEdition() {
+		return fmt.Errorf("the edition of an existing cluster cannot be changed")
+	}
 	// validate the transition between old.spec.version and new.spec.version
 	if err := validateVersion(old, new); err != nil {
 		return err
 	}
 	// validate the namespace configuration
-	if err := validateNamespaces(old, new); err != nil {
+	if err := s.validateNamespaces(old, new); err != nil {
 		return err
 	}
+	// scaling down the cluster causes the data held by the removed nodes to be
+	// lost in the case of namespaces that store data in memory, and so we
+	// must make sure there is at least one other replica of every record
+	if new.Spec.NodeCount < old.Spec.NodeCount {
+		for _, ns := range new.Spec.Namespaces {
+			if ns.Storage.Type == common.StorageTypeMemory && replicationFactor(ns) < minReplicationFactorForMemoryScaleDown {
+				return fmt.Errorf("cannot scale down a cluster with namespace %s, which stores data in memory, with a replication factor lower than %d", ns.Name, minReplicationFactorForMemoryScaleDown)
+			}
+		}
+	}
+	// prevent the racks from being changed, as that would cause existing pods
+	// (and their persistent volumes) to move between racks
+	if !reflect.DeepEqual(old.Spec.Racks, new.Spec.Racks) {
+		return fmt.Errorf("the racks of an existing cluster cannot be changed")
+	}
 
 	return nil
 }
@@ -191,7 +509,16 @@ func validateVersion(old, new *aerospikev1alpha2.AerospikeCluster) error {
 	if err != nil {
 		return err
 	}
-	upgrade := versioning.VersionUpgrade{sourceVersion, targetVersion}
+	// if only the edition suffix was changed, we're good
+	if sourceVersion.Equals(targetVersion) {
+		return nil
+	}
+	// if a failed upgrade is being rolled back to the version the cluster was
+	// running before, we're good
+	if isRollback(old, new) {
+		return nil
+	}
+	upgrade := versioning.VersionUpgrade{Source: sourceVersion, Target: targetVersion}
 	// return an error if the transition is not supported
 	if !upgrade.IsValid() {
 		return fmt.Errorf("cannot upgrade from version %v to %v", sourceVersion, targetVersion)
@@ -199,7 +526,29 @@ func validateVersion(old, new *aerospikev1alpha2.AerospikeCluster) error {
 	return nil
 }
 
-func validateNamespaces(old, new *aerospikev1alpha2.AerospikeCluster) error {
+// validateRecovery makes sure that a recovery action is only requested for an
+// Aerospike cluster that is not being managed due to a failed upgrade.
+func validateRecovery(old, new *aerospikev1alpha2.AerospikeCluster) error {
+	if new.Spec.Recovery == nil || reflect.DeepEqual(old.Spec.Recovery, new.Spec.Recovery) {
+		return nil
+	}
+	switch old.Annotations[annotations.UpgradeStatusAnnotationKey] {
+	case annotations.UpgradeStatusFailedAnnotationValue, annotations.UpgradeStatusHaltedAnnotationValue:
+		return nil
+	default:
+		return fmt.Errorf(".spec.recovery can only be specified for a cluster whose version upgrade has failed")
+	}
+}
+
+// isRollback indicates whether the transition between old and new corresponds
+// to the start of the rollback of a failed upgrade.
+func isRollback(old, new *aerospikev1alpha2.AerospikeCluster) bool {
+	return old.Annotations[annotations.UpgradeStatusAnnotationKey] == annotations.UpgradeStatusFailedAnnotationValue &&
+		new.Annotations[annotations.UpgradeStatusAnnotationKey] == annotations.UpgradeStatusRollbackAnnotationValue &&
+		new.Spec.Version == old.Status.Version
+}
+
+func (s *ValidatingAdmissionWebhook) validateNamespaces(old, new *aerospikev1alpha2.AerospikeCluster) error {
 	// grab a name => spec map for the namespaces in the old object
 	oldnss := namespaceMap(old)
 	// grab a name => spec map for the namespaces in the new object
@@ -214,24 +563,104 @@ func validateNamespaces(old, new *aerospikev1alpha2.AerospikeCluster) error {
 			return fmt.Errorf("cannot remove namespace %s", name)
 		}
 	}
-	// validate that there were no changes to existing namespaces
+	// validate changes to existing namespaces
 	for name := range newnss {
 		// if the namespace didn't exist before, there's nothing to validate
 		if _, ok := oldnss[name]; !ok {
 			continue
 		}
-		// make sure that the replication factor hasn't been changed
-		if oldnss[name].ReplicationFactor != nil && newnss[name].ReplicationFactor != nil && *oldnss[name].ReplicationFactor != *newnss[name].ReplicationFactor {
-			return fmt.Errorf("cannot change the replication factor for namespace %s", name)
+		oldStorage, newStorage := oldnss[name].Storage, newnss[name].Storage
+		// namespaces that store data in memory have no persistent volumes, and
+		// their memory size can be freely changed
+		if oldStorage.Type == common.StorageTypeMemory || newStorage.Type == common.StorageTypeMemory {
+			if oldStorage.Type != newStorage.Type {
+				return fmt.Errorf("cannot change the storage type for namespace %s to or from %s", name, common.StorageTypeMemory)
+			}
+			continue
+		}
+		// the operator cannot add or remove volumes from existing pods, so
+		// the number of volumes is fixed when the namespace is created
+		if oldStorage.GetVolumeCount() != newStorage.GetVolumeCount() {
+			return fmt.Errorf("cannot change the number of volumes for namespace %s", name)
+		}
+		// if the storage type, size and class haven't been changed, there's
+		// no need to touch existing persistent volumes
+		sameTypeAndClass := oldStorage.Type == newStorage.Type && reflect.DeepEqual(oldStorage.StorageClassName, newStorage.StorageClassName)
+		if sameTypeAndClass && oldStorage.Size == newStorage.Size {
+			continue
+		}
+		// make sure that the storage size hasn't been decreased
+		oldSize, err := resource.ParseQuantity(oldStorage.Size)
+		if err != nil {
+			return err
+		}
+		newSize, err := resource.ParseQuantity(newStorage.Size)
+		if err != nil {
+			return err
+		}
+		if newSize.Cmp(oldSize) < 0 {
+			return fmt.Errorf("cannot decrease the storage size for namespace %s", name)
+		}
+		// if the storage size is the only change and the storage class allows
+		// for volume expansion, existing persistent volumes will be expanded
+		// in place and no data will be lost
+		if sameTypeAndClass {
+			expandable, err := s.storageClassAllowsVolumeExpansion(newStorage.StorageClassName)
+			if err != nil {
+				return err
+			}
+			if expandable {
+				continue
+			}
 		}
-		// make sure that the storage spec hasn't been changed
-		if !reflect.DeepEqual(oldnss[name].Storage, newnss[name].Storage) {
-			return fmt.Errorf("cannot change the storage spec for namespace %s", name)
+		// replacing the persistent volumes of a node causes it to lose its
+		// data, and so we must make sure there is at least one other replica
+		// of every record both before and after the change
+		if replicationFactor(oldnss[name]) < minReplicationFactorForStorageChange || replicationFactor(newnss[name]) < minReplicationFactorForStorageChange {
+			return fmt.Errorf("cannot change the storage spec for namespace %s with a replication factor lower than %d", name, minReplicationFactorForStorageChange)
 		}
 	}
 	return nil
 }
 
+// storageClassAllowsVolumeExpansion indicates whether the storage class with
+// the specified name (or the default storage class if no name is specified)
+// allows for persistent volumes to be expanded.
+func (s *ValidatingAdmissionWebhook) storageClassAllowsVolumeExpansion(name *string) (bool, error) {
+	var sc *storagev1.StorageClass
+	if name != nil && *name != "" {
+		res, err := s.kubeClient.StorageV1().StorageClasses().Get(*name, v1.GetOptions{})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		sc = res
+	} else {
+		scs, err := s.kubeClient.StorageV1().StorageClasses().List(v1.ListOptions{})
+		if err != nil {
+			return false, err
+		}
+		for _, item := range scs.Items {
+			if item.Annotations[defaultStorageClassAnnotation] == "true" || item.Annotations[betaDefaultStorageClassAnnotation] == "true" {
+				sc = item.DeepCopy()
+				break
+			}
+		}
+	}
+	return sc != nil && sc.AllowVolumeExpansion != nil && *sc.AllowVolumeExpansion, nil
+}
+
+// replicationFactor returns the effective replication factor of the specified
+// namespace (i.e. the one requested by the user or aerospike's default).
+func replicationFactor(ns aerospikev1alpha2.AerospikeNamespaceSpec) int32 {
+	if ns.ReplicationFactor != nil {
+		return *ns.ReplicationFactor
+	}
+	return defaultNamespaceReplicationFactor
+}
+
 func namespaceMap(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) map[string]aerospikev1alpha2.AerospikeNamespaceSpec {
 	res := make(map[string]aerospikev1alpha2.AerospikeNamespaceSpec, len(aerospikeCluster.Spec.Namespaces))
 	for _, ns := range aerospikeCluster.Spec.Namespaces {
@@ -0,0 +1,95 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"fmt"
+	"reflect"
+
+	av1beta1 "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
+)
+
+func (s *ValidatingAdmissionWebhook) admitAerospikeSecondaryIndex(ar av1beta1.AdmissionReview) *av1beta1.AdmissionResponse {
+	// decode the new AerospikeSecondaryIndex object
+	obj, err := decodeAerospikeSecondaryIndex(ar.Request.Object.Raw)
+	if err != nil {
+		return admissionResponseFromError(err)
+	}
+
+	// if this is an update to .spec, return an error. the target cluster is
+	// not validated on updates, as the finalizer of an aerospikesecondaryindex
+	// resource must be removable after the target cluster has been deleted.
+	if ar.Request.Operation == av1beta1.Update {
+		old, err := decodeAerospikeSecondaryIndex(ar.Request.OldObject.Raw)
+		if err != nil {
+			return admissionResponseFromError(err)
+		}
+		// reject updates to the .spec field
+		if !reflect.DeepEqual(obj.Spec, old.Spec) {
+			return admissionResponseFromError(fmt.Errorf("the spec of an aerospikesecondaryindex resource cannot be changed after creation"))
+		}
+		return &av1beta1.AdmissionResponse{Allowed: true}
+	}
+
+	// validate the new AerospikeSecondaryIndex
+	if err = s.validateAerospikeSecondaryIndex(obj); err != nil {
+		return admissionResponseFromError(err)
+	}
+
+	// admit the AerospikeSecondaryIndex object
+	return &av1beta1.AdmissionResponse{Allowed: true}
+}
+
+func (s *ValidatingAdmissionWebhook) validateAerospikeSecondaryIndex(obj *aerospikev1alpha2.AerospikeSecondaryIndex) error {
+	// make sure that the target cluster exists
+	aerospikeCluster, err := s.aerospikeClient.AerospikeV1alpha2().AerospikeClusters(obj.Namespace).Get(obj.Spec.Target.Cluster, v1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	// make sure that the target namespace exists
+	if _, ok := namespaceMap(aerospikeCluster)[obj.Spec.Target.Namespace]; !ok {
+		return fmt.Errorf("cluster %s does not contain a namespace named %s", aerospikeCluster.Name, obj.Spec.Target.Namespace)
+	}
+
+	// make sure that no other secondary index exists on the same bin
+	indexes, err := s.aerospikeClient.AerospikeV1alpha2().AerospikeSecondaryIndexes(obj.Namespace).List(v1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, index := range indexes.Items {
+		if index.Name != obj.Name && index.Spec.Target == obj.Spec.Target && index.Spec.Set == obj.Spec.Set && index.Spec.Bin == obj.Spec.Bin {
+			return fmt.Errorf("bin %s is already indexed by aerospikesecondaryindex %s", obj.Spec.Bin, index.Name)
+		}
+	}
+	return nil
+}
+
+func decodeAerospikeSecondaryIndex(raw []byte) (*aerospikev1alpha2.AerospikeSecondaryIndex, error) {
+	obj := &aerospikev1alpha2.AerospikeSecondaryIndex{}
+	if len(raw) == 0 {
+		return obj, nil
+	}
+	_, _, err := codecs.UniversalDeserializer().Decode(raw, nil, obj)
+	if err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
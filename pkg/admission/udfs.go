@@ -0,0 +1,84 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"fmt"
+
+	av1beta1 "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
+)
+
+func (s *ValidatingAdmissionWebhook) admitAerospikeUDF(ar av1beta1.AdmissionReview) *av1beta1.AdmissionResponse {
+	// decode the new AerospikeUDF object
+	obj, err := decodeAerospikeUDF(ar.Request.Object.Raw)
+	if err != nil {
+		return admissionResponseFromError(err)
+	}
+
+	// make sure that exactly one source is specified
+	if err = validateUDFSource(obj); err != nil {
+		return admissionResponseFromError(err)
+	}
+
+	// if this is an update to .spec.target, return an error. the target
+	// cluster is not validated on updates, as the finalizer of an
+	// aerospikeudf resource must be removable after the target cluster has
+	// been deleted.
+	if ar.Request.Operation == av1beta1.Update {
+		old, err := decodeAerospikeUDF(ar.Request.OldObject.Raw)
+		if err != nil {
+			return admissionResponseFromError(err)
+		}
+		// reject updates to the .spec.target field
+		if obj.Spec.Target != old.Spec.Target {
+			return admissionResponseFromError(fmt.Errorf("the target of an aerospikeudf resource cannot be changed after creation"))
+		}
+		return &av1beta1.AdmissionResponse{Allowed: true}
+	}
+
+	// make sure that the target cluster exists
+	if _, err = s.aerospikeClient.AerospikeV1alpha2().AerospikeClusters(obj.Namespace).Get(obj.Spec.Target.Cluster, v1.GetOptions{}); err != nil {
+		return admissionResponseFromError(err)
+	}
+
+	// admit the AerospikeUDF object
+	return &av1beta1.AdmissionResponse{Allowed: true}
+}
+
+func validateUDFSource(obj *aerospikev1alpha2.AerospikeUDF) error {
+	hasInline := obj.Spec.Source.Inline != ""
+	hasConfigMapKeyRef := obj.Spec.Source.ConfigMapKeyRef != nil
+	if hasInline == hasConfigMapKeyRef {
+		return fmt.Errorf("exactly one of inline or configMapKeyRef must be specified as the source of an aerospikeudf resource")
+	}
+	return nil
+}
+
+func decodeAerospikeUDF(raw []byte) (*aerospikev1alpha2.AerospikeUDF, error) {
+	obj := &aerospikev1alpha2.AerospikeUDF{}
+	if len(raw) == 0 {
+		return obj, nil
+	}
+	_, _, err := codecs.UniversalDeserializer().Decode(raw, nil, obj)
+	if err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
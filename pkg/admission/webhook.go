@@ -64,6 +64,8 @@ var (
 	aerospikeClusterWebhookPath          = "/admission/reviews/aerospikeclusters"
 	aerospikeNamespaceBackupWebhookPath  = "/admission/reviews/aerospikenamespacebackups"
 	aerospikeNamespaceRestoreWebhookPath = "/admission/reviews/aerospikenamespacerestores"
+	aerospikeSecondaryIndexWebhookPath   = "/admission/reviews/aerospikesecondaryindexes"
+	aerospikeUDFWebhookPath              = "/admission/reviews/aerospikeudfs"
 	healthzPath                          = "/healthz"
 
 	failurePolicy = admissionregistrationv1beta1.Fail
@@ -136,6 +138,8 @@ func (s *ValidatingAdmissionWebhook) Run(stopCh chan struct{}) {
 	mux.HandleFunc(aerospikeClusterWebhookPath, s.handleAerospikeCluster)
 	mux.HandleFunc(aerospikeNamespaceBackupWebhookPath, s.handleAerospikeNamespaceBackup)
 	mux.HandleFunc(aerospikeNamespaceRestoreWebhookPath, s.handleAerospikeNamespaceRestore)
+	mux.HandleFunc(aerospikeSecondaryIndexWebhookPath, s.handleAerospikeSecondaryIndex)
+	mux.HandleFunc(aerospikeUDFWebhookPath, s.handleAerospikeUDF)
 	mux.HandleFunc(healthzPath, handleHealthz)
 	srv := http.Server{
 		Addr:    fmt.Sprintf(":%d", 8443),
@@ -148,7 +152,8 @@ func (s *ValidatingAdmissionWebhook) Run(stopCh chan struct{}) {
 	// shutdown the server when stopCh is closed
 	go func() {
 		<-stopCh
-		ctx, _ := context.WithTimeout(context.Background(), 5*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
 		srv.Shutdown(ctx)
 		log.Debugf("admission webhook has been shutdown")
 	}()
@@ -173,6 +178,14 @@ func (s *ValidatingAdmissionWebhook) handleAerospikeNamespaceRestore(res http.Re
 	handle(res, req, s.admitAerospikeNamespaceRestore)
 }
 
+func (s *ValidatingAdmissionWebhook) handleAerospikeSecondaryIndex(res http.ResponseWriter, req *http.Request) {
+	handle(res, req, s.admitAerospikeSecondaryIndex)
+}
+
+func (s *ValidatingAdmissionWebhook) handleAerospikeUDF(res http.ResponseWriter, req *http.Request) {
+	handle(res, req, s.admitAerospikeUDF)
+}
+
 // ensureTLSSecret generates a certificate and private key to be used for registering and serving the webhook, and
 // creates a kubernetes secret containing them so they can be used by all running instances of aerospike-operator.
 // in case such secret already exists, it is read and returned.
@@ -338,6 +351,64 @@ func (s *ValidatingAdmissionWebhook) ensureWebhookConfig(caBundle []byte) error
 				},
 				FailurePolicy: &failurePolicy,
 			},
+			{
+				Name: crd.AerospikeSecondaryIndexCRDName,
+				Rules: []admissionregistrationv1beta1.RuleWithOperations{
+					{
+						Operations: []admissionregistrationv1beta1.OperationType{
+							admissionregistrationv1beta1.Create,
+							admissionregistrationv1beta1.Update,
+						},
+						Rule: admissionregistrationv1beta1.Rule{
+							APIGroups: []string{
+								aerospikev1alpha2.SchemeGroupVersion.Group,
+							},
+							APIVersions: []string{
+								aerospikev1alpha2.SchemeGroupVersion.Version,
+							},
+							Resources: []string{crd.AerospikeSecondaryIndexPlural},
+						},
+					},
+				},
+				ClientConfig: admissionregistrationv1beta1.WebhookClientConfig{
+					Service: &admissionregistrationv1beta1.ServiceReference{
+						Name:      serviceName,
+						Namespace: s.namespace,
+						Path:      &aerospikeSecondaryIndexWebhookPath,
+					},
+					CABundle: caBundle,
+				},
+				FailurePolicy: &failurePolicy,
+			},
+			{
+				Name: crd.AerospikeUDFCRDName,
+				Rules: []admissionregistrationv1beta1.RuleWithOperations{
+					{
+						Operations: []admissionregistrationv1beta1.OperationType{
+							admissionregistrationv1beta1.Create,
+							admissionregistrationv1beta1.Update,
+						},
+						Rule: admissionregistrationv1beta1.Rule{
+							APIGroups: []string{
+								aerospikev1alpha2.SchemeGroupVersion.Group,
+							},
+							APIVersions: []string{
+								aerospikev1alpha2.SchemeGroupVersion.Version,
+							},
+							Resources: []string{crd.AerospikeUDFPlural},
+						},
+					},
+				},
+				ClientConfig: admissionregistrationv1beta1.WebhookClientConfig{
+					Service: &admissionregistrationv1beta1.ServiceReference{
+						Name:      serviceName,
+						Namespace: s.namespace,
+						Path:      &aerospikeUDFWebhookPath,
+					},
+					CABundle: caBundle,
+				},
+				FailurePolicy: &failurePolicy,
+			},
 		},
 	}
 
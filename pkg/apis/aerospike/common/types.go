@@ -27,9 +27,81 @@ const (
 	// StorageTypeDevice defines the device storage type for a given Aerospike namespace.
 	StorageTypeDevice = "device"
 
+	// StorageTypeMemory defines the memory storage type for a given Aerospike namespace, in which data is not
+	// persisted.
+	StorageTypeMemory = "memory"
+
+	// ExternalAccessTypeNodePort defines the external access type that exposes each Aerospike node using a
+	// NodePort service.
+	ExternalAccessTypeNodePort = "NodePort"
+
+	// ExternalAccessTypeLoadBalancer defines the external access type that exposes each Aerospike node using a
+	// LoadBalancer service.
+	ExternalAccessTypeLoadBalancer = "LoadBalancer"
+
+	// ExternalAccessTypeHostNetwork defines the external access type that exposes each Aerospike node using the
+	// network of the Kubernetes node in which it is running.
+	ExternalAccessTypeHostNetwork = "HostNetwork"
+
+	// UpgradeFailurePolicyHalt defines the policy that stops the management of an Aerospike cluster whose
+	// version upgrade has failed.
+	UpgradeFailurePolicyHalt = "halt"
+
+	// UpgradeFailurePolicyRollback defines the policy that restarts every Aerospike node of an Aerospike cluster
+	// whose version upgrade has failed on the previous version.
+	UpgradeFailurePolicyRollback = "rollback"
+
+	// UpgradeFailurePolicyRestore defines the policy that, in addition to rolling back an Aerospike cluster whose
+	// version upgrade has failed, restores every Aerospike namespace from the backup made before the upgrade.
+	UpgradeFailurePolicyRestore = "restore"
+
+	// RecoveryActionResume defines the recovery action that retries the failed version upgrade of an Aerospike
+	// cluster.
+	RecoveryActionResume = "resume"
+
+	// RecoveryActionAbort defines the recovery action that rolls an Aerospike cluster whose version upgrade has
+	// failed back to the previous version.
+	RecoveryActionAbort = "abort"
+
+	// EditionCommunity defines the Community Edition of Aerospike.
+	EditionCommunity = "community"
+
+	// EditionEnterprise defines the Enterprise Edition of Aerospike.
+	EditionEnterprise = "enterprise"
+
+	// SecondaryIndexTypeNumeric defines the type of secondary indexes on bins containing integer values.
+	SecondaryIndexTypeNumeric = "numeric"
+
+	// SecondaryIndexTypeString defines the type of secondary indexes on bins containing string values.
+	SecondaryIndexTypeString = "string"
+
+	// SecondaryIndexTypeGeo2DSphere defines the type of secondary indexes on bins containing GeoJSON values.
+	SecondaryIndexTypeGeo2DSphere = "geo2dsphere"
+
 	// StorageTypeGCS defines the Google Cloud Storage type for a given Aerospike backup.
 	StorageTypeGCS = "gcs"
 
+	// ClusterPhaseCreating defines the phase of an Aerospike cluster whose pods are being created for the first time.
+	ClusterPhaseCreating = "Creating"
+
+	// ClusterPhaseRunning defines the phase of an Aerospike cluster whose pods are all running and ready.
+	ClusterPhaseRunning = "Running"
+
+	// ClusterPhaseScaling defines the phase of an Aerospike cluster whose number of nodes is being changed.
+	ClusterPhaseScaling = "Scaling"
+
+	// ClusterPhaseUpgrading defines the phase of an Aerospike cluster whose version is being changed.
+	ClusterPhaseUpgrading = "Upgrading"
+
+	// ClusterPhaseDegraded defines the phase of an Aerospike cluster some of whose pods are missing or not ready.
+	ClusterPhaseDegraded = "Degraded"
+
+	// ClusterPhaseInvalid defines the phase of an Aerospike cluster whose spec has been rejected by aerospike-operator.
+	ClusterPhaseInvalid = "Invalid"
+
+	// ClusterPhaseFailed defines the phase of an Aerospike cluster whose upgrade has failed.
+	ClusterPhaseFailed = "Failed"
+
 	// ConditionBackupFailed defines a status condition that indicates that a backup job has failed
 	ConditionBackupFailed apiextensions.CustomResourceDefinitionConditionType = "BackupFailed"
 
@@ -72,9 +144,97 @@ const (
 	// backup for an Aerospike cluster has failed
 	ConditionAutoBackupFailed apiextensions.CustomResourceDefinitionConditionType = "AutoBackupFailed"
 
+	// ConditionUpgradeHalted defines a status condition that indicates that aerospike-operator has
+	// stopped managing an Aerospike cluster after a failed upgrade
+	ConditionUpgradeHalted apiextensions.CustomResourceDefinitionConditionType = "UpgradeHalted"
+
+	// ConditionUpgradeRollbackStarted defines a status condition that indicates that the rollback
+	// of a failed upgrade to an Aerospike cluster has started
+	ConditionUpgradeRollbackStarted apiextensions.CustomResourceDefinitionConditionType = "UpgradeRollbackStarted"
+
+	// ConditionUpgradeRollbackFinished defines a status condition that indicates that the rollback
+	// of a failed upgrade to an Aerospike cluster has finished
+	ConditionUpgradeRollbackFinished apiextensions.CustomResourceDefinitionConditionType = "UpgradeRollbackFinished"
+
+	// ConditionUpgradeRollbackFailed defines a status condition that indicates that the rollback
+	// of a failed upgrade to an Aerospike cluster has failed
+	ConditionUpgradeRollbackFailed apiextensions.CustomResourceDefinitionConditionType = "UpgradeRollbackFailed"
+
+	// ConditionAutoRestoreStarted defines a status condition that indicates that the restore of
+	// the pre-upgrade backup of an Aerospike cluster has started
+	ConditionAutoRestoreStarted apiextensions.CustomResourceDefinitionConditionType = "AutoRestoreStarted"
+
+	// ConditionAutoRestoreFinished defines a status condition that indicates that the restore of
+	// the pre-upgrade backup of an Aerospike cluster has finished
+	ConditionAutoRestoreFinished apiextensions.CustomResourceDefinitionConditionType = "AutoRestoreFinished"
+
+	// ConditionAutoRestoreFailed defines a status condition that indicates that the restore of
+	// the pre-upgrade backup of an Aerospike cluster has failed
+	ConditionAutoRestoreFailed apiextensions.CustomResourceDefinitionConditionType = "AutoRestoreFailed"
+
+	// ConditionRecoveryFinished defines a status condition that indicates that the recovery action
+	// requested for an Aerospike cluster whose upgrade has failed has been performed
+	ConditionRecoveryFinished apiextensions.CustomResourceDefinitionConditionType = "RecoveryFinished"
+
+	// ConditionRecoveryFailed defines a status condition that indicates that the recovery action
+	// requested for an Aerospike cluster whose upgrade has failed could not be performed
+	ConditionRecoveryFailed apiextensions.CustomResourceDefinitionConditionType = "RecoveryFailed"
+
+	// ConditionNamespaceUpdateStarted defines a status condition that indicates that an update
+	// to the replication factor or storage spec of existing Aerospike namespaces has started
+	ConditionNamespaceUpdateStarted apiextensions.CustomResourceDefinitionConditionType = "NamespaceUpdateStarted"
+
+	// ConditionNamespaceUpdateFinished defines a status condition that indicates that an update
+	// to the replication factor or storage spec of existing Aerospike namespaces has finished
+	ConditionNamespaceUpdateFinished apiextensions.CustomResourceDefinitionConditionType = "NamespaceUpdateFinished"
+
+	// ConditionNodeStorageUpdated defines a status condition that indicates that the persistent
+	// volumes of an Aerospike node have been replaced in order to match the storage spec
+	ConditionNodeStorageUpdated apiextensions.CustomResourceDefinitionConditionType = "NodeStorageUpdated"
+
+	// ConditionClusterPaused defines a status condition that indicates that the reconciliation
+	// of an Aerospike cluster has been paused
+	ConditionClusterPaused apiextensions.CustomResourceDefinitionConditionType = "ClusterPaused"
+
+	// ConditionClusterResumed defines a status condition that indicates that the reconciliation
+	// of an Aerospike cluster has been resumed
+	ConditionClusterResumed apiextensions.CustomResourceDefinitionConditionType = "ClusterResumed"
+
+	// ConditionClusterRestartStarted defines a status condition that indicates that a rolling
+	// restart of an Aerospike cluster has started
+	ConditionClusterRestartStarted apiextensions.CustomResourceDefinitionConditionType = "ClusterRestartStarted"
+
+	// ConditionClusterRestartFinished defines a status condition that indicates that a rolling
+	// restart of an Aerospike cluster has finished
+	ConditionClusterRestartFinished apiextensions.CustomResourceDefinitionConditionType = "ClusterRestartFinished"
+
+	// ConditionClusterValid defines a status condition that indicates whether the spec of an Aerospike
+	// cluster has been accepted by aerospike-operator
+	ConditionClusterValid apiextensions.CustomResourceDefinitionConditionType = "ClusterValid"
+
+	// ConditionClusterReady defines a status condition that indicates whether every expected node of an
+	// Aerospike cluster is running and ready
+	ConditionClusterReady apiextensions.CustomResourceDefinitionConditionType = "ClusterReady"
+
+	// ConditionSecondaryIndexCreated defines a status condition that indicates that a secondary
+	// index has been created in the target Aerospike cluster
+	ConditionSecondaryIndexCreated apiextensions.CustomResourceDefinitionConditionType = "SecondaryIndexCreated"
+
+	// ConditionSecondaryIndexBuilt defines a status condition that indicates that a secondary
+	// index has been built in every node of the target Aerospike cluster
+	ConditionSecondaryIndexBuilt apiextensions.CustomResourceDefinitionConditionType = "SecondaryIndexBuilt"
+
+	// ConditionUDFRegistered defines a status condition that indicates that a UDF module
+	// has been registered in every node of the target Aerospike cluster
+	ConditionUDFRegistered apiextensions.CustomResourceDefinitionConditionType = "UDFRegistered"
+
 	// DefaultSecretFilename represents the name of the file that is required to exist
 	// in the secret referenced in BackupStorageSpec objects.
 	DefaultSecretFilename = "key.json"
+
+	// DefaultFeatureKeyFilename represents the name of the file that is required to exist
+	// in the secret referenced in AerospikeFeatureKeySecretSpec objects, unless otherwise specified.
+	DefaultFeatureKeyFilename = "features.conf"
 )
 
 // OperationType represents the type used to indicate whether a
@@ -88,4 +248,6 @@ const (
 	AerospikeClusterKind          = "AerospikeCluster"
 	AerospikeNamespaceBackupKind  = "AerospikeNamespaceBackup"
 	AerospikeNamespaceRestoreKind = "AerospikeNamespaceRestore"
+	AerospikeSecondaryIndexKind   = "AerospikeSecondaryIndex"
+	AerospikeUDFKind              = "AerospikeUDF"
 )
@@ -106,7 +106,7 @@ type AerospikeNamespaceBackupStatus struct {
 	AerospikeNamespaceBackupSpec
 	// Details about the current condition of the AerospikeNamespaceBackup resource.
 	// +k8s:openapi-gen=false
-	Conditions []apiextensions.CustomResourceDefinitionCondition `json="conditions"`
+	Conditions []apiextensions.CustomResourceDefinitionCondition `json:"conditions"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
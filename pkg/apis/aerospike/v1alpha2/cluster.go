@@ -17,8 +17,11 @@ limitations under the License.
 package v1alpha2
 
 import (
+	"k8s.io/api/core/v1"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/common"
 )
 
 // +genclient
@@ -42,25 +45,170 @@ type AerospikeClusterSpec struct {
 	// The number of nodes in the Aerospike cluster.
 	NodeCount int32 `json:"nodeCount"`
 	// The version of Aerospike to be deployed.
+	// May be suffixed with -ce or -ee in order to identify the edition of Aerospike, in which case it must match edition.
 	Version string `json:"version"`
+	// The edition of Aerospike to be deployed ("community" or "enterprise").
+	// If absent, Aerospike Community Edition is deployed.
+	// +optional
+	Edition string `json:"edition,omitempty"`
+	// A reference to the secret containing the feature key file for Aerospike Enterprise Edition.
+	// Must be present if and only if edition is "enterprise".
+	// +optional
+	FeatureKeySecret *AerospikeFeatureKeySecretSpec `json:"featureKeySecret,omitempty"`
 	// The specification of the Aerospike namespaces in the cluster.
-	// Must have exactly one element.
+	// Must have at least one and at most two elements (or 32 elements, if edition is "enterprise").
 	Namespaces []AerospikeNamespaceSpec `json:"namespaces"`
 	// The specification of how Aerospike namespace backups made by aerospike-operator should be performed and stored.
 	// It is only required to be present if one wants to perform version upgrades on the Aerospike cluster.
 	// +optional
 	BackupSpec *AerospikeClusterBackupSpec `json:"backupSpec,omitempty"`
+	// Overrides to the service, network and logging stanzas of the Aerospike configuration generated by aerospike-operator.
+	// +optional
+	AerospikeConfig *AerospikeConfigSpec `json:"aerospikeConfig,omitempty"`
+	// The compute resources to be requested and the limits to be enforced for each container of an Aerospike node.
+	// If absent, requests are derived from the memory size of the Aerospike namespaces.
+	// +optional
+	Resources *AerospikeClusterResourcesSpec `json:"resources,omitempty"`
+	// Overrides to be merged into the pods that compose the Aerospike cluster.
+	// +optional
+	PodSpec *AerospikePodSpec `json:"podSpec,omitempty"`
+	// The racks across which the nodes of the Aerospike cluster are to be distributed.
+	// If absent, Aerospike rack awareness is disabled.
+	// +optional
+	Racks []AerospikeRackSpec `json:"racks,omitempty"`
+	// The images to be used for the pods and jobs created for the Aerospike cluster, and how to pull them.
+	// If absent, the values specified when starting aerospike-operator are used.
+	// +optional
+	Images *AerospikeImagesSpec `json:"images,omitempty"`
+	// Specifies how Aerospike nodes can be accessed by clients outside the Kubernetes cluster.
+	// If absent, Aerospike nodes can only be accessed from inside the Kubernetes cluster.
+	// +optional
+	ExternalAccess *AerospikeExternalAccessSpec `json:"externalAccess,omitempty"`
+	// Whether the reconciliation of the Aerospike cluster is paused.
+	// While paused, aerospike-operator does not create, delete or restart any pods, and only updates the status of the resource.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+	// The indexes of the Aerospike nodes that are under maintenance.
+	// Aerospike nodes under maintenance are safely deleted and are not re-created until they are removed from this list.
+	// +optional
+	NodesUnderMaintenance []int32 `json:"nodesUnderMaintenance,omitempty"`
+	// A timestamp that, whenever changed, causes every Aerospike node to be safely restarted, one at a time.
+	// +optional
+	RestartedAt *metav1.Time `json:"restartedAt,omitempty"`
+	// The maximum number of new Aerospike nodes that may be started at the same time when the Aerospike cluster is created or scaled up.
+	// Existing Aerospike nodes are always restarted one at a time.
+	// If absent, new Aerospike nodes are started one at a time.
+	// +optional
+	MaxSurge *int32 `json:"maxSurge,omitempty"`
+	// What aerospike-operator should do when a version upgrade fails ("halt", "rollback" or "restore").
+	// "halt" stops managing the Aerospike cluster, "rollback" restarts every Aerospike node on the previous version and
+	// "restore" additionally restores every Aerospike namespace from the backup made before the upgrade.
+	// If absent, aerospike-operator halts.
+	// +optional
+	OnUpgradeFailure *string `json:"onUpgradeFailure,omitempty"`
+	// The action to perform in order to recover the Aerospike cluster after a failed version upgrade.
+	// It can only be specified while the Aerospike cluster is not managed due to a failed version upgrade, and is
+	// removed by aerospike-operator once the action has been performed.
+	// +optional
+	Recovery *AerospikeClusterRecoverySpec `json:"recovery,omitempty"`
+}
+
+// GetEdition returns the edition of Aerospike to be deployed.
+func (s *AerospikeClusterSpec) GetEdition() string {
+	if s.Edition != "" {
+		return s.Edition
+	}
+	return common.EditionCommunity
+}
+
+// GetMaxSurge returns the maximum number of new Aerospike nodes that may be started at the same time.
+func (s *AerospikeClusterSpec) GetMaxSurge() int {
+	if s.MaxSurge != nil {
+		return int(*s.MaxSurge)
+	}
+	return 1
+}
+
+// GetOnUpgradeFailure returns what aerospike-operator should do when a version upgrade fails.
+func (s *AerospikeClusterSpec) GetOnUpgradeFailure() string {
+	if s.OnUpgradeFailure != nil {
+		return *s.OnUpgradeFailure
+	}
+	return common.UpgradeFailurePolicyHalt
+}
+
+// IsNodeUnderMaintenance indicates whether the Aerospike node with the specified index is under maintenance.
+func (s *AerospikeClusterSpec) IsNodeUnderMaintenance(index int) bool {
+	for _, i := range s.NodesUnderMaintenance {
+		if int(i) == index {
+			return true
+		}
+	}
+	return false
 }
 
 // AerospikeClusterStatus represents the current state of an Aerospike cluster.
 type AerospikeClusterStatus struct {
 	// The desired state of the Aerospike cluster.
 	AerospikeClusterSpec
+	// The current phase of the Aerospike cluster.
+	// +optional
+	Phase string `json:"phase,omitempty"`
+	// The most recent generation of the AerospikeCluster resource observed by aerospike-operator.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// The label selector that matches the pods of the Aerospike cluster, in string form.
+	// +optional
+	Selector string `json:"selector,omitempty"`
+	// The number of rolling restarts requested through restartedAt that have been started.
+	// +optional
+	RestartGeneration int64 `json:"restartGeneration,omitempty"`
+	// The number of Aerospike nodes that have already been restarted as part of the most recent rolling restart.
+	// +optional
+	RestartedNodes int32 `json:"restartedNodes,omitempty"`
+	// The number of pods that currently exist for the Aerospike cluster.
+	// +optional
+	TotalNodes int32 `json:"totalNodes,omitempty"`
+	// The number of pods for the Aerospike cluster that are currently running and ready.
+	// +optional
+	ReadyNodes int32 `json:"readyNodes,omitempty"`
+	// The observed state of each Aerospike node in the cluster.
+	// +optional
+	Nodes []AerospikeNodeStatus `json:"nodes,omitempty"`
 	// Details about the current condition of the AerospikeCluster resource.
 	// +k8s:openapi-gen=false
 	Conditions []apiextensions.CustomResourceDefinitionCondition `json:"conditions"`
 }
 
+// AerospikeNodeStatus reports the observed state of an Aerospike node.
+type AerospikeNodeStatus struct {
+	// The name of the pod running the Aerospike node.
+	PodName string `json:"podName"`
+	// The ID of the Aerospike node.
+	// +optional
+	NodeID string `json:"nodeId,omitempty"`
+	// The IP address of the pod running the Aerospike node.
+	// +optional
+	PodIP string `json:"podIP,omitempty"`
+	// Whether the pod running the Aerospike node is running and ready.
+	Ready bool `json:"ready"`
+	// The version of Aerospike reported by the Aerospike node.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// The size of the Aerospike cluster as observed by the Aerospike node.
+	// +optional
+	ClusterSize int32 `json:"clusterSize,omitempty"`
+	// The key of the Aerospike cluster as observed by the Aerospike node.
+	// +optional
+	ClusterKey string `json:"clusterKey,omitempty"`
+	// The number of partitions that remain to be migrated by the Aerospike node.
+	// +optional
+	MigratePartitionsRemaining int64 `json:"migratePartitionsRemaining,omitempty"`
+	// The names of the persistent volume claims used by the Aerospike node.
+	// +optional
+	PersistentVolumeClaims []string `json:"persistentVolumeClaims,omitempty"`
+}
+
 // AerospikeNamespaceSpec specifies the configuration for an Aerospike namespace.
 type AerospikeNamespaceSpec struct {
 	// The name of the Aerospike namespace.
@@ -81,6 +229,135 @@ type AerospikeNamespaceSpec struct {
 	DefaultTTL *string `json:"defaultTTL,omitempty"`
 	// Specifies how data for the Aerospike namespace will be stored.
 	Storage StorageSpec `json:"storage"`
+	// Overrides to the namespace stanza of the Aerospike configuration generated by aerospike-operator
+	// (e.g. "high-water-memory-pct" or "write-block-size").
+	// +optional
+	AerospikeConfig map[string]string `json:"aerospikeConfig,omitempty"`
+}
+
+// AerospikeConfigSpec specifies overrides to the Aerospike configuration generated by aerospike-operator.
+type AerospikeConfigSpec struct {
+	// Properties to be set in the service stanza (e.g. "migrate-threads").
+	// +optional
+	Service map[string]string `json:"service,omitempty"`
+	// Properties to be set in the network stanza.
+	// +optional
+	Network *AerospikeNetworkConfigSpec `json:"network,omitempty"`
+	// The logging level to be used for each logging context (e.g. "migrate": "debug").
+	// +optional
+	Logging map[string]string `json:"logging,omitempty"`
+}
+
+// AerospikeNetworkConfigSpec specifies overrides to the network stanza of the Aerospike configuration.
+type AerospikeNetworkConfigSpec struct {
+	// Properties to be set in the network.heartbeat stanza (e.g. "interval").
+	// +optional
+	Heartbeat map[string]string `json:"heartbeat,omitempty"`
+	// Properties to be set in the network.fabric stanza (e.g. "send-threads").
+	// +optional
+	Fabric map[string]string `json:"fabric,omitempty"`
+}
+
+// AerospikeClusterResourcesSpec specifies the compute resources for each container of an Aerospike node.
+type AerospikeClusterResourcesSpec struct {
+	// The compute resources for the aerospike-server container.
+	// +optional
+	AerospikeServer *v1.ResourceRequirements `json:"aerospikeServer,omitempty"`
+	// The compute resources for the asprom container.
+	// +optional
+	Asprom *v1.ResourceRequirements `json:"asprom,omitempty"`
+	// The compute resources for the init container.
+	// +optional
+	Init *v1.ResourceRequirements `json:"init,omitempty"`
+}
+
+// AerospikePodSpec specifies overrides to be merged into the pods that compose an Aerospike cluster.
+type AerospikePodSpec struct {
+	// Additional labels to be added to each pod.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Additional annotations to be added to each pod.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// The node selector to be used when scheduling each pod.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// The tolerations to be added to each pod.
+	// +optional
+	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+	// The scheduling constraints to be used for each pod.
+	// If specified, it replaces the pod anti-affinity enforced by default by aerospike-operator.
+	// +optional
+	Affinity *v1.Affinity `json:"affinity,omitempty"`
+	// The name of the priority class to be used for each pod.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
+// AerospikeRackSpec specifies a rack across which the nodes of an Aerospike cluster are distributed.
+type AerospikeRackSpec struct {
+	// The ID of the rack (used as the value of rack-id in each namespace).
+	ID int32 `json:"id"`
+	// The zone in which the pods belonging to this rack must be scheduled.
+	// +optional
+	Zone string `json:"zone,omitempty"`
+	// The node selector to be used when scheduling the pods belonging to this rack.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+}
+
+// AerospikeImagesSpec specifies the images to be used for the pods and jobs created for an Aerospike cluster.
+type AerospikeImagesSpec struct {
+	// The repository from which to pull the aerospike-server image (or the aerospike-server-enterprise image, if
+	// edition is "enterprise").
+	// +optional
+	AerospikeServerRepository string `json:"aerospikeServerRepository,omitempty"`
+	// The repository from which to pull the aerospike-operator-tools image.
+	// +optional
+	ToolsRepository string `json:"toolsRepository,omitempty"`
+	// The pull policy to use for every image.
+	// +optional
+	PullPolicy v1.PullPolicy `json:"pullPolicy,omitempty"`
+	// The secrets to use when pulling images.
+	// +optional
+	PullSecrets []v1.LocalObjectReference `json:"pullSecrets,omitempty"`
+}
+
+// AerospikeExternalAccessSpec specifies how Aerospike nodes can be accessed by clients outside the Kubernetes cluster.
+type AerospikeExternalAccessSpec struct {
+	// The type of external access (NodePort, LoadBalancer or HostNetwork).
+	Type string `json:"type"`
+	// The annotations to be added to the service created for each Aerospike node.
+	// Not applicable when type is HostNetwork.
+	// +optional
+	ServiceAnnotations map[string]string `json:"serviceAnnotations,omitempty"`
+}
+
+// AerospikeClusterRecoverySpec specifies how to recover an Aerospike cluster whose version upgrade has failed.
+type AerospikeClusterRecoverySpec struct {
+	// The recovery action to perform ("resume" or "abort").
+	// "resume" retries the upgrade to the version the Aerospike cluster failed to be upgraded to, while "abort" rolls
+	// the Aerospike cluster back to the version it was running before the upgrade.
+	Action string `json:"action"`
+}
+
+// AerospikeFeatureKeySecretSpec specifies the secret containing the feature key file for Aerospike Enterprise Edition.
+type AerospikeFeatureKeySecretSpec struct {
+	// The name of the secret. Must exist in the Kubernetes namespace of the Aerospike cluster.
+	Name string `json:"name"`
+	// The name of the field of the secret that holds the feature key file.
+	// If absent, "features.conf" is used.
+	// +optional
+	Key *string `json:"key,omitempty"`
+}
+
+// GetKey returns the name of the field of the secret that holds the feature
+// key file.
+func (s *AerospikeFeatureKeySecretSpec) GetKey() string {
+	if s.Key != nil {
+		return *s.Key
+	}
+	return common.DefaultFeatureKeyFilename
 }
 
 // AerospikeClusterBackupSpec specifies how Aerospike namespace backups made by aerospike-operator before a version upgrade should be stored.
@@ -95,10 +372,16 @@ type AerospikeClusterBackupSpec struct {
 
 // StorageSpec specifies how data in a given Aerospike namespace will be stored.
 type StorageSpec struct {
-	// The storage engine to be used for the namespace (file or device).
+	// The storage engine to be used for the namespace (file, device or memory).
 	Type string `json:"type"`
 	// The size (gibibytes) of the persistent volume to use for storing data in this namespace, suffixed with G.
-	Size string `json:"size"`
+	// Required unless type is memory.
+	// +optional
+	Size string `json:"size,omitempty"`
+	// The number of persistent volumes of the specified size to use for storing data in this namespace on each node.
+	// Aerospike stripes writes across these persistent volumes. If absent, a single persistent volume is used.
+	// +optional
+	VolumeCount *int32 `json:"volumeCount,omitempty"`
 	// The name of the storage class to use to create persistent volumes.
 	// +optional
 	StorageClassName *string `json:"storageClassName,omitempty"`
@@ -113,6 +396,15 @@ type StorageSpec struct {
 	DataInMemory *bool `json:"dataInMemory,omitempty"`
 }
 
+// GetVolumeCount returns the number of persistent volumes to use for storing
+// data in the namespace on each node.
+func (s *StorageSpec) GetVolumeCount() int {
+	if s.VolumeCount != nil {
+		return int(*s.VolumeCount)
+	}
+	return 1
+}
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // AerospikeClusterList represents a list of Aerospike clusters.
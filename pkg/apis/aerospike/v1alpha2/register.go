@@ -51,6 +51,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&AerospikeNamespaceBackupList{},
 		&AerospikeNamespaceRestore{},
 		&AerospikeNamespaceRestoreList{},
+		&AerospikeSecondaryIndex{},
+		&AerospikeSecondaryIndexList{},
+		&AerospikeUDF{},
+		&AerospikeUDFList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
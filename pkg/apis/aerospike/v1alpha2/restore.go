@@ -56,7 +56,7 @@ type AerospikeNamespaceRestoreStatus struct {
 	AerospikeNamespaceRestoreSpec
 	// Details about the current condition of the AerospikeNamespaceRestore resource.
 	// +k8s:openapi-gen=false
-	Conditions []apiextensions.CustomResourceDefinitionCondition `json="conditions"`
+	Conditions []apiextensions.CustomResourceDefinitionCondition `json:"conditions"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
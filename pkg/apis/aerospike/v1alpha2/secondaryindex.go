@@ -0,0 +1,75 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+
+// AerospikeSecondaryIndex represents a secondary index on a bin of the records of a single Aerospike namespace.
+type AerospikeSecondaryIndex struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object metadata.
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// The specification of the secondary index.
+	Spec AerospikeSecondaryIndexSpec `json:"spec"`
+	// The status of the secondary index.
+	Status AerospikeSecondaryIndexStatus `json:"status"`
+}
+
+// AerospikeSecondaryIndexSpec specifies the configuration for a secondary index.
+type AerospikeSecondaryIndexSpec struct {
+	// The specification of the Aerospike cluster and Aerospike namespace in which to create the secondary index.
+	Target TargetNamespace `json:"target"`
+	// The name of the set whose records are indexed. If absent, the secondary index is created at the namespace level.
+	// +optional
+	Set string `json:"set,omitempty"`
+	// The name of the bin to index.
+	Bin string `json:"bin"`
+	// The type of the values to index (numeric, string or geo2dsphere).
+	Type string `json:"type"`
+}
+
+// AerospikeSecondaryIndexStatus is the status for an AerospikeSecondaryIndex resource.
+type AerospikeSecondaryIndexStatus struct {
+	// The configuration for the secondary index.
+	AerospikeSecondaryIndexSpec
+	// The percentage of records that have been indexed in the Aerospike node where the build of the secondary index
+	// is the least advanced.
+	BuildProgress int32 `json:"buildProgress"`
+	// Details about the current condition of the AerospikeSecondaryIndex resource.
+	// +k8s:openapi-gen=false
+	Conditions []apiextensions.CustomResourceDefinitionCondition `json:"conditions"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AerospikeSecondaryIndexList is a list of AerospikeSecondaryIndex resources
+type AerospikeSecondaryIndexList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata.
+	metav1.ListMeta `json:"metadata"`
+
+	// The list of AerospikeSecondaryIndex resources.
+	Items []AerospikeSecondaryIndex `json:"items"`
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"k8s.io/api/core/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+
+// AerospikeUDF represents a Lua UDF module registered in an Aerospike cluster.
+type AerospikeUDF struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object metadata.
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// The specification of the UDF module.
+	Spec AerospikeUDFSpec `json:"spec"`
+	// The status of the UDF module.
+	Status AerospikeUDFStatus `json:"status"`
+}
+
+// AerospikeUDFSpec specifies the configuration for a UDF module.
+type AerospikeUDFSpec struct {
+	// The specification of the Aerospike cluster in which to register the UDF module.
+	Target TargetCluster `json:"target"`
+	// The source of the Lua code of the UDF module.
+	Source UDFSource `json:"source"`
+}
+
+// TargetCluster specifies the Aerospike cluster targeted by a given operation.
+type TargetCluster struct {
+	// The name of the Aerospike cluster.
+	Cluster string `json:"cluster"`
+}
+
+// UDFSource specifies where to read the Lua code of a UDF module from. Exactly one of its fields must be specified.
+type UDFSource struct {
+	// The Lua code of the UDF module.
+	// +optional
+	Inline string `json:"inline,omitempty"`
+	// A reference to a key of a configmap containing the Lua code of the UDF module.
+	// +optional
+	ConfigMapKeyRef *v1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+}
+
+// AerospikeUDFStatus is the status for an AerospikeUDF resource.
+type AerospikeUDFStatus struct {
+	// The hash of the Lua code of the UDF module registered in every node of the target Aerospike cluster.
+	// +optional
+	Hash string `json:"hash,omitempty"`
+	// Details about the current condition of the AerospikeUDF resource.
+	// +k8s:openapi-gen=false
+	Conditions []apiextensions.CustomResourceDefinitionCondition `json:"conditions"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AerospikeUDFList is a list of AerospikeUDF resources
+type AerospikeUDFList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata.
+	metav1.ListMeta `json:"metadata"`
+
+	// The list of AerospikeUDF resources.
+	Items []AerospikeUDF `json:"items"`
+}
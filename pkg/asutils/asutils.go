@@ -25,7 +25,13 @@ import (
 	as "github.com/aerospike/aerospike-client-go"
 )
 
-const timeout = 10 * time.Second
+const (
+	// ServicePort is the port on which Aerospike nodes serve client and info
+	// requests.
+	ServicePort = 3000
+
+	timeout = 10 * time.Second
+)
 
 func GetClusterSize(host string, port int) (int, error) {
 	c, err := as.NewConnection(fmt.Sprintf("%s:%d", host, port), timeout)
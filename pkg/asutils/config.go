@@ -0,0 +1,257 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asutils
+
+var (
+	// ServiceConfigKeys is the set of properties that can be set by the user
+	// in the service stanza of the Aerospike configuration.
+	// https://www.aerospike.com/docs/reference/configuration#service
+	ServiceConfigKeys = newKeySet(
+		"batch-index-threads",
+		"batch-max-buffers-per-queue",
+		"batch-max-requests",
+		"batch-max-unused-buffers",
+		"info-threads",
+		"migrate-max-num-incoming",
+		"migrate-threads",
+		"nsup-delete-sleep",
+		"nsup-period",
+		"paxos-single-replica-limit",
+		"proto-fd-idle-ms",
+		"proto-fd-max",
+		"query-batch-size",
+		"query-in-transaction-thread",
+		"query-long-q-max-size",
+		"query-priority",
+		"query-short-q-max-size",
+		"query-threads",
+		"query-worker-threads",
+		"service-threads",
+		"ticker-interval",
+		"transaction-max-ms",
+		"transaction-pending-limit",
+		"transaction-queues",
+		"transaction-retry-ms",
+		"transaction-threads-per-queue",
+	)
+
+	// HeartbeatConfigKeys is the set of properties that can be set by the user
+	// in the network.heartbeat stanza of the Aerospike configuration.
+	// https://www.aerospike.com/docs/reference/configuration#heartbeat
+	HeartbeatConfigKeys = newKeySet(
+		"connect-timeout-ms",
+		"interval",
+		"mtu",
+		"timeout",
+	)
+
+	// FabricConfigKeys is the set of properties that can be set by the user
+	// in the network.fabric stanza of the Aerospike configuration.
+	// https://www.aerospike.com/docs/reference/configuration#fabric
+	FabricConfigKeys = newKeySet(
+		"channel-bulk-fds",
+		"channel-bulk-recv-threads",
+		"channel-ctrl-fds",
+		"channel-ctrl-recv-threads",
+		"channel-meta-fds",
+		"channel-meta-recv-threads",
+		"channel-rw-fds",
+		"channel-rw-recv-threads",
+		"keepalive-enabled",
+		"keepalive-intvl",
+		"keepalive-probes",
+		"keepalive-time",
+		"latency-max-ms",
+		"send-threads",
+	)
+
+	// LoggingContexts is the set of logging contexts for which the user can
+	// set the logging level.
+	// https://www.aerospike.com/docs/operations/configure/log
+	LoggingContexts = newKeySet(
+		"any",
+		"aggr",
+		"alloc",
+		"appeal",
+		"arenax",
+		"as",
+		"batch",
+		"bin",
+		"clustering",
+		"config",
+		"drv_ssd",
+		"exchange",
+		"fabric",
+		"geo",
+		"hardware",
+		"hb",
+		"hlc",
+		"index",
+		"info",
+		"info-port",
+		"job",
+		"migrate",
+		"misc",
+		"msg",
+		"namespace",
+		"nsup",
+		"particle",
+		"partition",
+		"paxos",
+		"predexp",
+		"proto",
+		"proxy",
+		"query",
+		"rbuffer",
+		"record",
+		"roster",
+		"rw",
+		"rw-client",
+		"scan",
+		"security",
+		"service",
+		"sindex",
+		"skew",
+		"smd",
+		"socket",
+		"storage",
+		"truncate",
+		"tsvc",
+		"udf",
+		"xdr",
+		"xmem",
+	)
+
+	// LoggingLevels is the set of valid logging levels.
+	LoggingLevels = newKeySet(
+		"critical",
+		"warning",
+		"info",
+		"detail",
+		"debug",
+	)
+
+	// NamespaceConfigKeys is the set of properties that can be set by the user
+	// in a namespace stanza of the Aerospike configuration.
+	// https://www.aerospike.com/docs/reference/configuration#namespace
+	NamespaceConfigKeys = newKeySet(
+		"conflict-resolution-policy",
+		"disallow-null-setname",
+		"evict-hist-buckets",
+		"evict-tenths-pct",
+		"high-water-disk-pct",
+		"high-water-memory-pct",
+		"max-ttl",
+		"migrate-order",
+		"migrate-retransmit-ms",
+		"migrate-sleep",
+		"partition-tree-sprigs",
+		"read-consistency-level-override",
+		"single-bin",
+		"stop-writes-pct",
+		"write-commit-level-override",
+	)
+
+	// StorageEngineConfigKeys is the set of properties that can be set by the
+	// user in the storage-engine sub-stanza of a namespace stanza of the
+	// Aerospike configuration.
+	// https://www.aerospike.com/docs/reference/configuration#storage-engine
+	StorageEngineConfigKeys = newKeySet(
+		"cold-start-empty",
+		"commit-to-device",
+		"defrag-lwm-pct",
+		"defrag-queue-min",
+		"defrag-sleep",
+		"defrag-startup-minimum",
+		"max-write-cache",
+		"min-avail-pct",
+		"post-write-queue",
+		"read-page-cache",
+		"write-block-size",
+	)
+)
+
+var (
+	// DynamicServiceConfigKeys is the subset of ServiceConfigKeys that can be
+	// changed on a running node through the set-config info command.
+	// https://www.aerospike.com/docs/reference/configuration#service
+	DynamicServiceConfigKeys = newKeySet(
+		"batch-max-buffers-per-queue",
+		"batch-max-requests",
+		"batch-max-unused-buffers",
+		"migrate-max-num-incoming",
+		"migrate-threads",
+		"nsup-delete-sleep",
+		"nsup-period",
+		"proto-fd-idle-ms",
+		"proto-fd-max",
+		"query-batch-size",
+		"query-in-transaction-thread",
+		"query-long-q-max-size",
+		"query-priority",
+		"query-short-q-max-size",
+		"query-threads",
+		"query-worker-threads",
+		"ticker-interval",
+		"transaction-max-ms",
+		"transaction-pending-limit",
+		"transaction-retry-ms",
+	)
+
+	// DynamicHeartbeatConfigKeys is the subset of HeartbeatConfigKeys that can
+	// be changed on a running node through the set-config info command.
+	// https://www.aerospike.com/docs/reference/configuration#heartbeat
+	DynamicHeartbeatConfigKeys = newKeySet(
+		"interval",
+		"timeout",
+	)
+
+	// DynamicNamespaceConfigKeys is the set of namespace properties that can
+	// be changed on a running node through the set-config info command. Note
+	// that memory-size can only be increased.
+	// https://www.aerospike.com/docs/reference/configuration#namespace
+	DynamicNamespaceConfigKeys = newKeySet(
+		"default-ttl",
+		"disallow-null-setname",
+		"evict-tenths-pct",
+		"high-water-disk-pct",
+		"high-water-memory-pct",
+		"max-ttl",
+		"memory-size",
+		"migrate-order",
+		"migrate-retransmit-ms",
+		"migrate-sleep",
+		"stop-writes-pct",
+	)
+)
+
+// KeySet represents a set of Aerospike configuration properties.
+type KeySet map[string]struct{}
+
+// Has indicates whether the specified key belongs to the set.
+func (s KeySet) Has(key string) bool {
+	_, ok := s[key]
+	return ok
+}
+
+func newKeySet(keys ...string) KeySet {
+	res := make(KeySet, len(keys))
+	for _, key := range keys {
+		res[key] = struct{}{}
+	}
+	return res
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asutils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	as "github.com/aerospike/aerospike-client-go"
+)
+
+// NodeHealth holds the information used to decide whether an Aerospike node
+// is ready to serve requests.
+type NodeHealth struct {
+	// Status is the response of the node to the status info command.
+	Status string
+	// ClusterSize is the size of the cluster as observed by the node.
+	ClusterSize int
+	// ClusterKey is the key of the cluster as observed by the node.
+	ClusterKey string
+	// UnavailablePartitions is the number of partitions that are unavailable
+	// across all the namespaces of the node.
+	UnavailablePartitions int
+}
+
+// CheckNodeStatus issues a status info command against the Aerospike node at
+// the specified host and port, and returns an error if the node does not
+// respond with "ok".
+func CheckNodeStatus(host string, port int) error {
+	res, err := requestInfo(host, port, "status")
+	if err != nil {
+		return err
+	}
+	if res != "ok" {
+		return fmt.Errorf("unexpected status: %s", res)
+	}
+	return nil
+}
+
+// GetNodeHealth returns the status of the Aerospike node at the specified host
+// and port, the cluster size and cluster key it observes and the number of
+// partitions it reports as unavailable.
+func GetNodeHealth(host string, port int) (*NodeHealth, error) {
+	c, err := as.NewConnection(fmt.Sprintf("%s:%d", host, port), timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+	r, err := as.RequestInfo(c, "status", "statistics", "namespaces")
+	if err != nil {
+		return nil, err
+	}
+	for _, ns := range parseNamespaces(r["namespaces"]) {
+		cmd := namespaceInfoCommand(ns)
+		nr, err := as.RequestInfo(c, cmd)
+		if err != nil {
+			return nil, err
+		}
+		r[cmd] = nr[cmd]
+	}
+	return parseNodeHealth(r)
+}
+
+// parseNodeHealth builds a NodeHealth struct from the responses to the status,
+// statistics and namespaces info commands, and to the namespace/<name> info
+// command for each namespace.
+func parseNodeHealth(r map[string]string) (*NodeHealth, error) {
+	stats := ParseStatistics(r["statistics"])
+	str, ok := stats["cluster_size"]
+	if !ok {
+		return nil, fmt.Errorf("cluster_size is not present")
+	}
+	clusterSize, err := strconv.Atoi(str)
+	if err != nil {
+		return nil, err
+	}
+	clusterKey, ok := stats["cluster_key"]
+	if !ok {
+		return nil, fmt.Errorf("cluster_key is not present")
+	}
+	res := &NodeHealth{
+		Status:      strings.TrimSpace(r["status"]),
+		ClusterSize: clusterSize,
+		ClusterKey:  clusterKey,
+	}
+	for _, ns := range parseNamespaces(r["namespaces"]) {
+		// unavailable_partitions is not reported by versions of aerospike
+		// older than 4.0
+		str, ok := ParseStatistics(r[namespaceInfoCommand(ns)])["unavailable_partitions"]
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(str)
+		if err != nil {
+			return nil, err
+		}
+		res.UnavailablePartitions += n
+	}
+	return res, nil
+}
+
+// parseNamespaces returns the names of the namespaces in the response to the
+// namespaces info command.
+func parseNamespaces(r string) []string {
+	res := make([]string, 0)
+	for _, ns := range strings.Split(strings.TrimSpace(r), ";") {
+		if ns != "" {
+			res = append(res, ns)
+		}
+	}
+	return res
+}
+
+// namespaceInfoCommand returns the info command used to get the statistics of
+// the specified namespace.
+func namespaceInfoCommand(namespace string) string {
+	return fmt.Sprintf("namespace/%s", namespace)
+}
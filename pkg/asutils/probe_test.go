@@ -0,0 +1,83 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNodeHealth(t *testing.T) {
+	tests := []struct {
+		res    map[string]string
+		health *NodeHealth
+		err    bool
+	}{
+		{
+			map[string]string{"status": "ok", "statistics": "cluster_size=3;cluster_key=A7C2D01E8D5B;migrate_partitions_remaining=12"},
+			&NodeHealth{Status: "ok", ClusterSize: 3, ClusterKey: "A7C2D01E8D5B"},
+			false,
+		},
+		{
+			map[string]string{"status": "ok\n", "statistics": "cluster_size=1;cluster_key=A7C2D01E8D5B", "namespaces": "\n"},
+			&NodeHealth{Status: "ok", ClusterSize: 1, ClusterKey: "A7C2D01E8D5B"},
+			false,
+		},
+		{
+			map[string]string{
+				"status":             "ok",
+				"statistics":         "cluster_size=2;cluster_key=A7C2D01E8D5B",
+				"namespaces":         "sessions;profiles;legacy",
+				"namespace/sessions": "objects=10;unavailable_partitions=0",
+				"namespace/profiles": "objects=10;unavailable_partitions=7",
+				"namespace/legacy":   "objects=10",
+			},
+			&NodeHealth{Status: "ok", ClusterSize: 2, ClusterKey: "A7C2D01E8D5B", UnavailablePartitions: 7},
+			false,
+		},
+		{
+			map[string]string{"status": "ok", "statistics": "cluster_key=A7C2D01E8D5B"},
+			nil,
+			true,
+		},
+		{
+			map[string]string{"status": "ok", "statistics": "cluster_size=3"},
+			nil,
+			true,
+		},
+		{
+			map[string]string{"status": "ok", "statistics": "cluster_size=foo;cluster_key=A7C2D01E8D5B"},
+			nil,
+			true,
+		},
+		{
+			map[string]string{"status": "ok", "statistics": "cluster_size=1;cluster_key=A7C2D01E8D5B", "namespaces": "sessions", "namespace/sessions": "unavailable_partitions=foo"},
+			nil,
+			true,
+		},
+	}
+	for _, test := range tests {
+		health, err := parseNodeHealth(test.res)
+		if test.err {
+			assert.Error(t, err)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, test.health, health)
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asutils
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	as "github.com/aerospike/aerospike-client-go"
+)
+
+// ErrSecondaryIndexNotFound is returned when the requested secondary index
+// does not exist in an Aerospike node.
+var ErrSecondaryIndexNotFound = errors.New("secondary index not found")
+
+// CreateSecondaryIndex issues a sindex-create info command against the
+// Aerospike node at the specified host and port. Aerospike propagates the
+// secondary index to every node in the cluster.
+func CreateSecondaryIndex(host string, port int, namespace, set, name, bin, indexType string) error {
+	cmd := fmt.Sprintf("sindex-create:ns=%s;", namespace)
+	if set != "" {
+		cmd += fmt.Sprintf("set=%s;", set)
+	}
+	cmd += fmt.Sprintf("indexname=%s;numbins=1;indexdata=%s,%s;priority=normal", name, bin, strings.ToUpper(indexType))
+	res, err := requestInfo(host, port, cmd)
+	if err != nil {
+		return err
+	}
+	if res != "OK" {
+		return fmt.Errorf("failed to create secondary index %s: %s", name, res)
+	}
+	return nil
+}
+
+// DeleteSecondaryIndex issues a sindex-delete info command against the
+// Aerospike node at the specified host and port. Deleting a secondary index
+// that does not exist is not considered an error.
+func DeleteSecondaryIndex(host string, port int, namespace, name string) error {
+	res, err := requestInfo(host, port, fmt.Sprintf("sindex-delete:ns=%s;indexname=%s", namespace, name))
+	if err != nil {
+		return err
+	}
+	if res != "OK" && !isSecondaryIndexNotFound(res) {
+		return fmt.Errorf("failed to delete secondary index %s: %s", name, res)
+	}
+	return nil
+}
+
+// GetSecondaryIndexLoadPercentage returns the percentage of records that have
+// been indexed by the Aerospike node at the specified host and port, as
+// reported by the sindex/<ns>/<name> statistics. ErrSecondaryIndexNotFound is
+// returned if the node does not know about the secondary index.
+func GetSecondaryIndexLoadPercentage(host string, port int, namespace, name string) (int, error) {
+	res, err := requestInfo(host, port, fmt.Sprintf("sindex/%s/%s", namespace, name))
+	if err != nil {
+		return 0, err
+	}
+	if isSecondaryIndexNotFound(res) {
+		return 0, ErrSecondaryIndexNotFound
+	}
+	if str, ok := ParseStatistics(res)["load_pct"]; !ok {
+		return 0, fmt.Errorf("load_pct is not present")
+	} else {
+		return strconv.Atoi(str)
+	}
+}
+
+// isSecondaryIndexNotFound indicates whether the response to an info command
+// corresponds to Aerospike's "no index" error.
+func isSecondaryIndexNotFound(res string) bool {
+	return strings.HasPrefix(res, "FAIL:201") || strings.Contains(strings.ToUpper(res), "NO INDEX")
+}
+
+// requestInfo issues the specified info command against the Aerospike node at
+// the specified host and port, and returns the (trimmed) response.
+func requestInfo(host string, port int, command string) (string, error) {
+	c, err := as.NewConnection(fmt.Sprintf("%s:%d", host, port), timeout)
+	if err != nil {
+		return "", err
+	}
+	defer c.Close()
+	r, err := as.RequestInfo(c, command)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(r[command]), nil
+}
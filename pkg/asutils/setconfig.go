@@ -0,0 +1,50 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asutils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SetConfig issues a set-config info command against the Aerospike node at
+// the specified host and port, setting the value of key in the specified
+// context (e.g. "service" or "namespace;id=test").
+func SetConfig(host string, port int, context, key, value string) error {
+	res, err := requestInfo(host, port, fmt.Sprintf("set-config:context=%s;%s=%s", context, key, value))
+	if err != nil {
+		return err
+	}
+	if strings.ToLower(res) != "ok" {
+		return fmt.Errorf("failed to set %s to %s in context %s: %s", key, value, context, res)
+	}
+	return nil
+}
+
+// GetConfig issues a get-config info command against the Aerospike node at
+// the specified host and port, and returns the properties in the specified
+// context (e.g. "service" or "namespace;id=test").
+func GetConfig(host string, port int, context string) (map[string]string, error) {
+	res, err := requestInfo(host, port, fmt.Sprintf("get-config:context=%s", context))
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(strings.ToLower(res), "error") {
+		return nil, fmt.Errorf("failed to get config for context %s: %s", context, res)
+	}
+	return ParseStatistics(res), nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asutils
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const (
+	// udfTypeLua is the only type of UDF module supported by Aerospike.
+	udfTypeLua = "LUA"
+)
+
+// UDFHash returns the hash Aerospike computes for a UDF module with the
+// specified content, as reported by the udf-list info command.
+func UDFHash(content string) string {
+	h := sha1.Sum([]byte(content))
+	return hex.EncodeToString(h[:])
+}
+
+// RegisterUDF issues a udf-put info command against the Aerospike node at the
+// specified host and port. Aerospike propagates the UDF module to every node
+// in the cluster.
+func RegisterUDF(host string, port int, filename, content string) error {
+	encoded := base64.StdEncoding.EncodeToString([]byte(content))
+	res, err := requestInfo(host, port, fmt.Sprintf("udf-put:filename=%s;content=%s;content-len=%d;udf-type=%s;", filename, encoded, len(encoded), udfTypeLua))
+	if err != nil {
+		return err
+	}
+	if strings.Contains(res, "error=") {
+		return fmt.Errorf("failed to register udf module %s: %s", filename, res)
+	}
+	return nil
+}
+
+// RemoveUDF issues a udf-remove info command against the Aerospike node at the
+// specified host and port. Removing a UDF module that does not exist is not
+// considered an error.
+func RemoveUDF(host string, port int, filename string) error {
+	res, err := requestInfo(host, port, fmt.Sprintf("udf-remove:filename=%s;", filename))
+	if err != nil {
+		return err
+	}
+	if strings.Contains(res, "error=") && !strings.Contains(res, "file_not_found") {
+		return fmt.Errorf("failed to remove udf module %s: %s", filename, res)
+	}
+	return nil
+}
+
+// ListUDFs issues a udf-list info command against the Aerospike node at the
+// specified host and port, and returns a map from the filename of each
+// registered UDF module to its hash.
+func ListUDFs(host string, port int) (map[string]string, error) {
+	res, err := requestInfo(host, port, "udf-list")
+	if err != nil {
+		return nil, err
+	}
+	return parseUDFList(res), nil
+}
+
+// parseUDFList parses the response to a udf-list info command into a map from
+// the filename of each registered UDF module to its hash.
+func parseUDFList(res string) map[string]string {
+	udfs := make(map[string]string)
+	for _, entry := range strings.Split(res, ";") {
+		if entry == "" {
+			continue
+		}
+		var filename, hash string
+		for _, field := range strings.Split(entry, ",") {
+			kv := strings.SplitN(field, "=", 2)
+			if len(kv) != 2 {
+				continue
+			}
+			switch kv[0] {
+			case "filename":
+				filename = kv[1]
+			case "hash":
+				hash = kv[1]
+			}
+		}
+		if filename != "" {
+			udfs[filename] = hash
+		}
+	}
+	return udfs
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package asutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUDFHash(t *testing.T) {
+	tests := []struct {
+		content string
+		hash    string
+	}{
+		{"", "da39a3ee5e6b4b0d3255bfef95601890afd80709"},
+		{"abc", "a9993e364706816aba3e25717850c26c9cd0d89d"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.hash, UDFHash(test.content))
+	}
+}
+
+func TestParseUDFList(t *testing.T) {
+	tests := []struct {
+		res  string
+		udfs map[string]string
+	}{
+		{"", map[string]string{}},
+		{
+			"filename=foo.lua,hash=a9993e364706816aba3e25717850c26c9cd0d89d,type=LUA;",
+			map[string]string{"foo.lua": "a9993e364706816aba3e25717850c26c9cd0d89d"},
+		},
+		{
+			"filename=foo.lua,hash=abc,type=LUA;filename=bar.lua,hash=def,type=LUA;",
+			map[string]string{"foo.lua": "abc", "bar.lua": "def"},
+		},
+		{
+			"hash=abc,type=LUA;filename=bar.lua,type=LUA;malformed;",
+			map[string]string{"bar.lua": ""},
+		},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.udfs, parseUDFList(test.res))
+	}
+}
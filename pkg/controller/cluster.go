@@ -35,9 +35,11 @@ import (
 
 const (
 	// clusterControllerDefaultThreadiness is the number of workers the cluster
-	// controller will use to process items from the queue. since every worker
-	// will usually block for a long time when reconciling an aerospikecluster
-	// resource we must use a value that is higher than the usual.
+	// controller will use to process items from the queue. long-running
+	// operations do not block workers (clusters are requeued while waiting
+	// for them to finish), but reconciling an aerospikecluster resource still
+	// involves several round-trips to the aerospike nodes, so we use a value
+	// that is higher than the usual.
 	clusterControllerDefaultThreadiness = 6
 )
 
@@ -61,8 +63,10 @@ func NewAerospikeClusterController(
 	serviceInformer := kubeInformerFactory.Core().V1().Services()
 	pvcInformer := kubeInformerFactory.Core().V1().PersistentVolumeClaims()
 	scInformer := kubeInformerFactory.Storage().V1().StorageClasses()
+	nodeInformer := kubeInformerFactory.Core().V1().Nodes()
 	aerospikeClusterInformer := aerospikeInformerFactory.Aerospike().V1alpha2().AerospikeClusters()
 	aerospikeNamespaceBackupInformer := aerospikeInformerFactory.Aerospike().V1alpha2().AerospikeNamespaceBackups()
+	aerospikeNamespaceRestoreInformer := aerospikeInformerFactory.Aerospike().V1alpha2().AerospikeNamespaceRestores()
 
 	// obtain references to listers for the required types
 	podsLister := podInformer.Lister()
@@ -70,8 +74,10 @@ func NewAerospikeClusterController(
 	servicesLister := serviceInformer.Lister()
 	pvcsLister := pvcInformer.Lister()
 	scsLister := scInformer.Lister()
+	nodesLister := nodeInformer.Lister()
 	aerospikeClustersLister := aerospikeClusterInformer.Lister()
 	aerospikeNamespaceBackupsLister := aerospikeNamespaceBackupInformer.Lister()
+	aerospikeNamespaceRestoresLister := aerospikeNamespaceRestoreInformer.Lister()
 
 	c := &AerospikeClusterController{
 		genericController:       newGenericController("aerospikecluster", clusterControllerDefaultThreadiness, kubeClient),
@@ -83,10 +89,11 @@ func NewAerospikeClusterController(
 		serviceInformer.Informer().HasSynced,
 		pvcInformer.Informer().HasSynced,
 		scInformer.Informer().HasSynced,
+		nodeInformer.Informer().HasSynced,
 		aerospikeClusterInformer.Informer().HasSynced,
 	}
 	c.syncHandler = c.processQueueItem
-	c.reconciler = reconciler.New(kubeClient, aerospikeClient, podsLister, configMapsLister, servicesLister, pvcsLister, scsLister, aerospikeNamespaceBackupsLister, c.recorder)
+	c.reconciler = reconciler.New(kubeClient, aerospikeClient, podsLister, configMapsLister, servicesLister, pvcsLister, scsLister, nodesLister, aerospikeNamespaceBackupsLister, aerospikeNamespaceRestoresLister, c.recorder)
 
 	c.logger.Debug("setting up event handlers")
 
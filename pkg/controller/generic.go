@@ -30,6 +30,8 @@ import (
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
+
+	"github.com/travelaudience/aerospike-operator/pkg/errors"
 )
 
 // Controller encapsulates a controller for Kubernetes resources.
@@ -152,6 +154,15 @@ func (c *genericController) processNextWorkItem() bool {
 		// Run the syncHandler, passing it the namespace/name string of the
 		// AerospikeCluster resource to be synced.
 		if err := c.syncHandler(key); err != nil {
+			// If the resource is waiting for an operation to finish, we
+			// requeue it after the requested delay instead of blocking the
+			// current worker while the operation is in progress.
+			if after, ok := errors.IsRequeue(err); ok {
+				c.workqueue.Forget(obj)
+				c.workqueue.AddAfter(key, after)
+				c.logger.Debugf("requeued '%s': %v", key, err)
+				return nil
+			}
 			return fmt.Errorf("error syncing '%s': %s", key, err.Error())
 		}
 		// Finally, if no error occurs we Forget this item so it does not
@@ -0,0 +1,173 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
+	aerospikeclientset "github.com/travelaudience/aerospike-operator/pkg/client/clientset/versioned"
+	aerospikeinformers "github.com/travelaudience/aerospike-operator/pkg/client/informers/externalversions"
+	aerospikelisters "github.com/travelaudience/aerospike-operator/pkg/client/listers/aerospike/v1alpha2"
+	"github.com/travelaudience/aerospike-operator/pkg/secondaryindex"
+)
+
+const (
+	// secondaryIndexControllerDefaultThreadiness is the number of workers the
+	// secondary index controller will use to process items from the queue.
+	secondaryIndexControllerDefaultThreadiness = 2
+)
+
+// AerospikeSecondaryIndexController is the controller for AerospikeSecondaryIndex resources
+type AerospikeSecondaryIndexController struct {
+	*genericController
+	aerospikeSecondaryIndexLister aerospikelisters.AerospikeSecondaryIndexLister
+	handler                       *secondaryindex.AerospikeSecondaryIndexHandler
+}
+
+// NewAerospikeSecondaryIndexController returns a new controller for AerospikeSecondaryIndex resources
+func NewAerospikeSecondaryIndexController(
+	kubeClient kubernetes.Interface,
+	aerospikeClient aerospikeclientset.Interface,
+	kubeInformerFactory kubeinformers.SharedInformerFactory,
+	aerospikeInformerFactory aerospikeinformers.SharedInformerFactory) *AerospikeSecondaryIndexController {
+
+	// obtain references to shared informers for the required types
+	podInformer := kubeInformerFactory.Core().V1().Pods()
+	aerospikeClusterInformer := aerospikeInformerFactory.Aerospike().V1alpha2().AerospikeClusters()
+	aerospikeNamespaceRestoreInformer := aerospikeInformerFactory.Aerospike().V1alpha2().AerospikeNamespaceRestores()
+	aerospikeSecondaryIndexInformer := aerospikeInformerFactory.Aerospike().V1alpha2().AerospikeSecondaryIndexes()
+
+	// obtain references to listers for the required types
+	podsLister := podInformer.Lister()
+	aerospikeClustersLister := aerospikeClusterInformer.Lister()
+	aerospikeSecondaryIndexLister := aerospikeSecondaryIndexInformer.Lister()
+
+	c := &AerospikeSecondaryIndexController{
+		genericController:             newGenericController("aerospikesecondaryindex", secondaryIndexControllerDefaultThreadiness, kubeClient),
+		aerospikeSecondaryIndexLister: aerospikeSecondaryIndexLister,
+	}
+	c.hasSyncedFuncs = []cache.InformerSynced{
+		podInformer.Informer().HasSynced,
+		aerospikeClusterInformer.Informer().HasSynced,
+		aerospikeNamespaceRestoreInformer.Informer().HasSynced,
+		aerospikeSecondaryIndexInformer.Informer().HasSynced,
+	}
+	c.syncHandler = c.processQueueItem
+
+	c.handler = secondaryindex.New(aerospikeClient, aerospikeClustersLister, podsLister, c.recorder)
+	c.logger.Debug("setting up event handlers")
+
+	// setup an event handler for when AerospikeSecondaryIndex resources change.
+	// since periodic resyncs also trigger update events, secondary indexes are
+	// periodically checked for existence and build progress.
+	aerospikeSecondaryIndexInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueue,
+		UpdateFunc: func(_, obj interface{}) {
+			c.enqueue(obj)
+		},
+	})
+	// setup an event handler for when AerospikeCluster and
+	// AerospikeNamespaceRestore resources change. These handlers will enqueue
+	// every AerospikeSecondaryIndex resource targeting the affected cluster so
+	// that secondary indexes are re-created as soon as possible after a
+	// cluster is re-created or a namespace is restored.
+	aerospikeClusterInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.handleAerospikeCluster,
+		UpdateFunc: func(_, obj interface{}) {
+			c.handleAerospikeCluster(obj)
+		},
+	})
+	aerospikeNamespaceRestoreInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.handleAerospikeNamespaceRestore,
+		UpdateFunc: func(_, obj interface{}) {
+			c.handleAerospikeNamespaceRestore(obj)
+		},
+	})
+
+	return c
+}
+
+// processQueueItem compares the actual state with the desired, and attempts to converge the two
+func (c *AerospikeSecondaryIndexController) processQueueItem(key string) error {
+	// Convert the namespace/name string into a distinct namespace and name
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("invalid resource key: %s", key))
+		return nil
+	}
+
+	// Get the AerospikeSecondaryIndex resource with this namespace/name
+	aerospikeSecondaryIndex, err := c.aerospikeSecondaryIndexLister.AerospikeSecondaryIndexes(namespace).Get(name)
+	if err != nil {
+		// The AerospikeSecondaryIndex resource may no longer exist, in which case we stop
+		// processing.
+		if errors.IsNotFound(err) {
+			runtime.HandleError(fmt.Errorf("aerospikesecondaryindex '%s' in work queue no longer exists", key))
+			return nil
+		}
+		return err
+	}
+
+	// deepcopy aerospikeSecondaryIndex before handling it so we don't possibly mutate the cache
+	return c.handler.Handle(aerospikeSecondaryIndex.DeepCopy())
+}
+
+// handleAerospikeCluster enqueues every AerospikeSecondaryIndex resource
+// targeting the specified AerospikeCluster resource.
+func (c *AerospikeSecondaryIndexController) handleAerospikeCluster(obj interface{}) {
+	aerospikeCluster, ok := obj.(*aerospikev1alpha2.AerospikeCluster)
+	if !ok {
+		runtime.HandleError(fmt.Errorf("error decoding object, invalid type"))
+		return
+	}
+	c.enqueueByTarget(aerospikeCluster.Namespace, aerospikeCluster.Name)
+}
+
+// handleAerospikeNamespaceRestore enqueues every AerospikeSecondaryIndex
+// resource targeting the cluster targeted by the specified
+// AerospikeNamespaceRestore resource.
+func (c *AerospikeSecondaryIndexController) handleAerospikeNamespaceRestore(obj interface{}) {
+	aerospikeNamespaceRestore, ok := obj.(*aerospikev1alpha2.AerospikeNamespaceRestore)
+	if !ok {
+		runtime.HandleError(fmt.Errorf("error decoding object, invalid type"))
+		return
+	}
+	c.enqueueByTarget(aerospikeNamespaceRestore.Namespace, aerospikeNamespaceRestore.Spec.Target.Cluster)
+}
+
+// enqueueByTarget enqueues every AerospikeSecondaryIndex resource in the
+// specified namespace targeting the specified cluster.
+func (c *AerospikeSecondaryIndexController) enqueueByTarget(namespace, cluster string) {
+	indexes, err := c.aerospikeSecondaryIndexLister.AerospikeSecondaryIndexes(namespace).List(labels.Everything())
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	for _, index := range indexes {
+		if index.Spec.Target.Cluster == cluster {
+			c.enqueue(index)
+		}
+	}
+}
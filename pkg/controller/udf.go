@@ -0,0 +1,206 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
+	aerospikeclientset "github.com/travelaudience/aerospike-operator/pkg/client/clientset/versioned"
+	aerospikeinformers "github.com/travelaudience/aerospike-operator/pkg/client/informers/externalversions"
+	aerospikelisters "github.com/travelaudience/aerospike-operator/pkg/client/listers/aerospike/v1alpha2"
+	"github.com/travelaudience/aerospike-operator/pkg/udf"
+)
+
+const (
+	// udfControllerDefaultThreadiness is the number of workers the udf
+	// controller will use to process items from the queue.
+	udfControllerDefaultThreadiness = 2
+)
+
+// AerospikeUDFController is the controller for AerospikeUDF resources
+type AerospikeUDFController struct {
+	*genericController
+	aerospikeUDFLister aerospikelisters.AerospikeUDFLister
+	handler            *udf.AerospikeUDFHandler
+}
+
+// NewAerospikeUDFController returns a new controller for AerospikeUDF resources
+func NewAerospikeUDFController(
+	kubeClient kubernetes.Interface,
+	aerospikeClient aerospikeclientset.Interface,
+	kubeInformerFactory kubeinformers.SharedInformerFactory,
+	aerospikeInformerFactory aerospikeinformers.SharedInformerFactory) *AerospikeUDFController {
+
+	// obtain references to shared informers for the required types
+	podInformer := kubeInformerFactory.Core().V1().Pods()
+	configMapInformer := kubeInformerFactory.Core().V1().ConfigMaps()
+	aerospikeClusterInformer := aerospikeInformerFactory.Aerospike().V1alpha2().AerospikeClusters()
+	aerospikeNamespaceRestoreInformer := aerospikeInformerFactory.Aerospike().V1alpha2().AerospikeNamespaceRestores()
+	aerospikeUDFInformer := aerospikeInformerFactory.Aerospike().V1alpha2().AerospikeUDFs()
+
+	// obtain references to listers for the required types
+	podsLister := podInformer.Lister()
+	configMapsLister := configMapInformer.Lister()
+	aerospikeClustersLister := aerospikeClusterInformer.Lister()
+	aerospikeUDFLister := aerospikeUDFInformer.Lister()
+
+	c := &AerospikeUDFController{
+		genericController:  newGenericController("aerospikeudf", udfControllerDefaultThreadiness, kubeClient),
+		aerospikeUDFLister: aerospikeUDFLister,
+	}
+	c.hasSyncedFuncs = []cache.InformerSynced{
+		podInformer.Informer().HasSynced,
+		configMapInformer.Informer().HasSynced,
+		aerospikeClusterInformer.Informer().HasSynced,
+		aerospikeNamespaceRestoreInformer.Informer().HasSynced,
+		aerospikeUDFInformer.Informer().HasSynced,
+	}
+	c.syncHandler = c.processQueueItem
+
+	c.handler = udf.New(aerospikeClient, aerospikeClustersLister, podsLister, configMapsLister, c.recorder)
+	c.logger.Debug("setting up event handlers")
+
+	// setup an event handler for when AerospikeUDF resources change. since
+	// periodic resyncs also trigger update events, udf modules are
+	// periodically checked for existence and integrity.
+	aerospikeUDFInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueue,
+		UpdateFunc: func(_, obj interface{}) {
+			c.enqueue(obj)
+		},
+	})
+	// setup an event handler for when configmaps change. this handler will
+	// enqueue every AerospikeUDF resource reading its source from the affected
+	// configmap so that changes to the lua code are picked up.
+	configMapInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.handleConfigMap,
+		UpdateFunc: func(_, obj interface{}) {
+			c.handleConfigMap(obj)
+		},
+	})
+	// setup an event handler for when AerospikeCluster and
+	// AerospikeNamespaceRestore resources change. These handlers will enqueue
+	// every AerospikeUDF resource targeting the affected cluster so that udf
+	// modules are re-registered as soon as possible after a cluster is
+	// re-created or a namespace is restored.
+	aerospikeClusterInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.handleAerospikeCluster,
+		UpdateFunc: func(_, obj interface{}) {
+			c.handleAerospikeCluster(obj)
+		},
+	})
+	aerospikeNamespaceRestoreInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.handleAerospikeNamespaceRestore,
+		UpdateFunc: func(_, obj interface{}) {
+			c.handleAerospikeNamespaceRestore(obj)
+		},
+	})
+
+	return c
+}
+
+// processQueueItem compares the actual state with the desired, and attempts to converge the two
+func (c *AerospikeUDFController) processQueueItem(key string) error {
+	// Convert the namespace/name string into a distinct namespace and name
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("invalid resource key: %s", key))
+		return nil
+	}
+
+	// Get the AerospikeUDF resource with this namespace/name
+	aerospikeUDF, err := c.aerospikeUDFLister.AerospikeUDFs(namespace).Get(name)
+	if err != nil {
+		// The AerospikeUDF resource may no longer exist, in which case we stop
+		// processing.
+		if errors.IsNotFound(err) {
+			runtime.HandleError(fmt.Errorf("aerospikeudf '%s' in work queue no longer exists", key))
+			return nil
+		}
+		return err
+	}
+
+	// deepcopy aerospikeUDF before handling it so we don't possibly mutate the cache
+	return c.handler.Handle(aerospikeUDF.DeepCopy())
+}
+
+// handleConfigMap enqueues every AerospikeUDF resource reading its source from
+// the specified configmap.
+func (c *AerospikeUDFController) handleConfigMap(obj interface{}) {
+	configMap, ok := obj.(*v1.ConfigMap)
+	if !ok {
+		runtime.HandleError(fmt.Errorf("error decoding object, invalid type"))
+		return
+	}
+	udfs, err := c.aerospikeUDFLister.AerospikeUDFs(configMap.Namespace).List(labels.Everything())
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	for _, aerospikeUDF := range udfs {
+		if ref := aerospikeUDF.Spec.Source.ConfigMapKeyRef; ref != nil && ref.Name == configMap.Name {
+			c.enqueue(aerospikeUDF)
+		}
+	}
+}
+
+// handleAerospikeCluster enqueues every AerospikeUDF resource targeting the
+// specified AerospikeCluster resource.
+func (c *AerospikeUDFController) handleAerospikeCluster(obj interface{}) {
+	aerospikeCluster, ok := obj.(*aerospikev1alpha2.AerospikeCluster)
+	if !ok {
+		runtime.HandleError(fmt.Errorf("error decoding object, invalid type"))
+		return
+	}
+	c.enqueueByTarget(aerospikeCluster.Namespace, aerospikeCluster.Name)
+}
+
+// handleAerospikeNamespaceRestore enqueues every AerospikeUDF resource
+// targeting the cluster targeted by the specified AerospikeNamespaceRestore
+// resource.
+func (c *AerospikeUDFController) handleAerospikeNamespaceRestore(obj interface{}) {
+	aerospikeNamespaceRestore, ok := obj.(*aerospikev1alpha2.AerospikeNamespaceRestore)
+	if !ok {
+		runtime.HandleError(fmt.Errorf("error decoding object, invalid type"))
+		return
+	}
+	c.enqueueByTarget(aerospikeNamespaceRestore.Namespace, aerospikeNamespaceRestore.Spec.Target.Cluster)
+}
+
+// enqueueByTarget enqueues every AerospikeUDF resource in the specified
+// namespace targeting the specified cluster.
+func (c *AerospikeUDFController) enqueueByTarget(namespace, cluster string) {
+	udfs, err := c.aerospikeUDFLister.AerospikeUDFs(namespace).List(labels.Everything())
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	for _, aerospikeUDF := range udfs {
+		if aerospikeUDF.Spec.Target.Cluster == cluster {
+			c.enqueue(aerospikeUDF)
+		}
+	}
+}
@@ -19,6 +19,7 @@ package crd
 import (
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
 	extsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -42,18 +43,227 @@ const (
 	AerospikeNamespaceRestorePlural = "aerospikenamespacerestores"
 	AerospikeNamespaceRestoreShort  = "asnr"
 
+	AerospikeSecondaryIndexKind   = common.AerospikeSecondaryIndexKind
+	AerospikeSecondaryIndexPlural = "aerospikesecondaryindexes"
+	AerospikeSecondaryIndexShort  = "assi"
+
+	AerospikeUDFKind   = common.AerospikeUDFKind
+	AerospikeUDFPlural = "aerospikeudfs"
+	AerospikeUDFShort  = "asudf"
+
 	// ttlPattern is the regex used to match a number of days (with
 	// optional fraction) suffixed with a "d"
 	ttlPattern = `^([0-9]*[.])?[0-9]+d$`
+	// configValuePattern is the regex used to match the value of a property
+	// of the Aerospike configuration (i.e. a single token with no whitespace,
+	// braces or comments)
+	configValuePattern = `^[^\s{}#]+$`
 )
 
 var (
 	AerospikeClusterCRDName          = fmt.Sprintf("%s.%s", AerospikeClusterPlural, aerospikev1alpha2.SchemeGroupVersion.Group)
 	AerospikeNamespaceBackupCRDName  = fmt.Sprintf("%s.%s", AerospikeNamespaceBackupPlural, aerospikev1alpha2.SchemeGroupVersion.Group)
 	AerospikeNamespaceRestoreCRDName = fmt.Sprintf("%s.%s", AerospikeNamespaceRestorePlural, aerospikev1alpha2.SchemeGroupVersion.Group)
+	AerospikeSecondaryIndexCRDName   = fmt.Sprintf("%s.%s", AerospikeSecondaryIndexPlural, aerospikev1alpha2.SchemeGroupVersion.Group)
+	AerospikeUDFCRDName              = fmt.Sprintf("%s.%s", AerospikeUDFPlural, aerospikev1alpha2.SchemeGroupVersion.Group)
 )
 
 var (
+	configPropertiesProps = extsv1beta1.JSONSchemaProps{
+		Type: "object",
+		AdditionalProperties: &extsv1beta1.JSONSchemaPropsOrBool{
+			Allows: true,
+			Schema: &extsv1beta1.JSONSchemaProps{
+				Type:    "string",
+				Pattern: configValuePattern,
+			},
+		},
+	}
+
+	aerospikeConfigProps = extsv1beta1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]extsv1beta1.JSONSchemaProps{
+			"service": configPropertiesProps,
+			"network": {
+				Type: "object",
+				Properties: map[string]extsv1beta1.JSONSchemaProps{
+					"heartbeat": configPropertiesProps,
+					"fabric":    configPropertiesProps,
+				},
+			},
+			"logging": configPropertiesProps,
+		},
+	}
+
+	resourceRequirementsProps = extsv1beta1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]extsv1beta1.JSONSchemaProps{
+			"limits": {
+				Type: "object",
+			},
+			"requests": {
+				Type: "object",
+			},
+		},
+	}
+
+	resourcesProps = extsv1beta1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]extsv1beta1.JSONSchemaProps{
+			"aerospikeServer": resourceRequirementsProps,
+			"asprom":          resourceRequirementsProps,
+			"init":            resourceRequirementsProps,
+		},
+	}
+
+	stringMapProps = extsv1beta1.JSONSchemaProps{
+		Type: "object",
+		AdditionalProperties: &extsv1beta1.JSONSchemaPropsOrBool{
+			Allows: true,
+			Schema: &extsv1beta1.JSONSchemaProps{
+				Type: "string",
+			},
+		},
+	}
+
+	podSpecProps = extsv1beta1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]extsv1beta1.JSONSchemaProps{
+			"labels":       stringMapProps,
+			"annotations":  stringMapProps,
+			"nodeSelector": stringMapProps,
+			"tolerations": {
+				Type: "array",
+				Items: &extsv1beta1.JSONSchemaPropsOrArray{
+					Schema: &extsv1beta1.JSONSchemaProps{
+						Type: "object",
+					},
+				},
+			},
+			"affinity": {
+				Type: "object",
+			},
+			"priorityClassName": {
+				Type:      "string",
+				MinLength: pointers.NewInt64(1),
+			},
+		},
+	}
+
+	racksProps = extsv1beta1.JSONSchemaProps{
+		Type: "array",
+		Items: &extsv1beta1.JSONSchemaPropsOrArray{
+			Schema: &extsv1beta1.JSONSchemaProps{
+				Type: "object",
+				Properties: map[string]extsv1beta1.JSONSchemaProps{
+					"id": {
+						Type:    "integer",
+						Minimum: pointers.NewFloat64(1),
+						Maximum: pointers.NewFloat64(1000000),
+					},
+					"zone": {
+						Type:      "string",
+						MinLength: pointers.NewInt64(1),
+					},
+					"nodeSelector": stringMapProps,
+				},
+				Required: []string{
+					"id",
+				},
+			},
+		},
+	}
+
+	imagesProps = extsv1beta1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]extsv1beta1.JSONSchemaProps{
+			"aerospikeServerRepository": {
+				Type:      "string",
+				MinLength: pointers.NewInt64(1),
+			},
+			"toolsRepository": {
+				Type:      "string",
+				MinLength: pointers.NewInt64(1),
+			},
+			"pullPolicy": {
+				Type: "string",
+				Enum: []extsv1beta1.JSON{
+					{Raw: []byte(asstrings.DoubleQuoted(string(corev1.PullAlways)))},
+					{Raw: []byte(asstrings.DoubleQuoted(string(corev1.PullIfNotPresent)))},
+					{Raw: []byte(asstrings.DoubleQuoted(string(corev1.PullNever)))},
+				},
+			},
+			"pullSecrets": {
+				Type: "array",
+				Items: &extsv1beta1.JSONSchemaPropsOrArray{
+					Schema: &extsv1beta1.JSONSchemaProps{
+						Type: "object",
+						Properties: map[string]extsv1beta1.JSONSchemaProps{
+							"name": {
+								Type:      "string",
+								MinLength: pointers.NewInt64(1),
+							},
+						},
+						Required: []string{
+							"name",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	externalAccessProps = extsv1beta1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]extsv1beta1.JSONSchemaProps{
+			"type": {
+				Type: "string",
+				Enum: []extsv1beta1.JSON{
+					{Raw: []byte(asstrings.DoubleQuoted(common.ExternalAccessTypeNodePort))},
+					{Raw: []byte(asstrings.DoubleQuoted(common.ExternalAccessTypeLoadBalancer))},
+					{Raw: []byte(asstrings.DoubleQuoted(common.ExternalAccessTypeHostNetwork))},
+				},
+			},
+			"serviceAnnotations": stringMapProps,
+		},
+		Required: []string{
+			"type",
+		},
+	}
+
+	recoveryProps = extsv1beta1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]extsv1beta1.JSONSchemaProps{
+			"action": {
+				Type: "string",
+				Enum: []extsv1beta1.JSON{
+					{Raw: []byte(asstrings.DoubleQuoted(common.RecoveryActionResume))},
+					{Raw: []byte(asstrings.DoubleQuoted(common.RecoveryActionAbort))},
+				},
+			},
+		},
+		Required: []string{
+			"action",
+		},
+	}
+
+	featureKeySecretProps = extsv1beta1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]extsv1beta1.JSONSchemaProps{
+			"name": {
+				Type:      "string",
+				MinLength: pointers.NewInt64(1),
+			},
+			"key": {
+				Type:      "string",
+				MinLength: pointers.NewInt64(1),
+			},
+		},
+		Required: []string{
+			"name",
+		},
+	}
+
 	backupStorageSpecProps = extsv1beta1.JSONSchemaProps{
 		Type: "object",
 		Properties: map[string]extsv1beta1.JSONSchemaProps{
@@ -140,10 +350,49 @@ var (
 										Maximum: pointers.NewFloat64(8),
 										Minimum: pointers.NewFloat64(1),
 									},
+									"paused": {
+										Type: "boolean",
+									},
+									"restartedAt": {
+										Type:   "string",
+										Format: "date-time",
+									},
+									"maxSurge": {
+										Type:    "integer",
+										Maximum: pointers.NewFloat64(8),
+										Minimum: pointers.NewFloat64(1),
+									},
+									"onUpgradeFailure": {
+										Type: "string",
+										Enum: []extsv1beta1.JSON{
+											{Raw: []byte(asstrings.DoubleQuoted(common.UpgradeFailurePolicyHalt))},
+											{Raw: []byte(asstrings.DoubleQuoted(common.UpgradeFailurePolicyRollback))},
+											{Raw: []byte(asstrings.DoubleQuoted(common.UpgradeFailurePolicyRestore))},
+										},
+									},
+									"recovery": recoveryProps,
+									"nodesUnderMaintenance": {
+										Type: "array",
+										Items: &extsv1beta1.JSONSchemaPropsOrArray{
+											Schema: &extsv1beta1.JSONSchemaProps{
+												Type:    "integer",
+												Minimum: pointers.NewFloat64(0),
+												Maximum: pointers.NewFloat64(7),
+											},
+										},
+									},
 									"version": {
 										Type:    "string",
-										Pattern: `^\d+\.\d+\.\d+(\.\d+)?$`,
+										Pattern: `^\d+\.\d+\.\d+(\.\d+)?(-ce|-ee)?$`,
+									},
+									"edition": {
+										Type: "string",
+										Enum: []extsv1beta1.JSON{
+											{Raw: []byte(asstrings.DoubleQuoted(common.EditionCommunity))},
+											{Raw: []byte(asstrings.DoubleQuoted(common.EditionEnterprise))},
+										},
 									},
+									"featureKeySecret": featureKeySecretProps,
 									"namespaces": {
 										Type: "array",
 										Items: &extsv1beta1.JSONSchemaPropsOrArray{
@@ -168,6 +417,7 @@ var (
 														Type:    "string",
 														Pattern: `^\d+s$`,
 													},
+													"aerospikeConfig": configPropertiesProps,
 													"storage": {
 														Type: "object",
 														Properties: map[string]extsv1beta1.JSONSchemaProps{
@@ -176,12 +426,18 @@ var (
 																Enum: []extsv1beta1.JSON{
 																	{Raw: []byte(asstrings.DoubleQuoted(common.StorageTypeFile))},
 																	{Raw: []byte(asstrings.DoubleQuoted(common.StorageTypeDevice))},
+																	{Raw: []byte(asstrings.DoubleQuoted(common.StorageTypeMemory))},
 																},
 															},
 															"size": {
 																Type:    "string",
 																Pattern: `^(20{3}|1?\d{1,3}|[1-9])G$`,
 															},
+															"volumeCount": {
+																Type:    "integer",
+																Minimum: pointers.NewFloat64(1),
+																Maximum: pointers.NewFloat64(16),
+															},
 															"storageClassName": {
 																Type: "string",
 															},
@@ -195,7 +451,6 @@ var (
 														},
 														Required: []string{
 															"type",
-															"size",
 														},
 													},
 												},
@@ -219,6 +474,12 @@ var (
 											"storage",
 										},
 									},
+									"aerospikeConfig": aerospikeConfigProps,
+									"resources":       resourcesProps,
+									"podSpec":         podSpecProps,
+									"racks":           racksProps,
+									"images":          imagesProps,
+									"externalAccess":  externalAccessProps,
 								},
 								Required: []string{
 									"nodeCount",
@@ -234,7 +495,7 @@ var (
 					Scale: &extsv1beta1.CustomResourceSubresourceScale{
 						SpecReplicasPath:   ".spec.nodeCount",
 						StatusReplicasPath: ".status.nodeCount",
-						LabelSelectorPath:  nil,
+						LabelSelectorPath:  pointers.NewString(".status.selector"),
 					},
 				},
 				AdditionalPrinterColumns: []extsv1beta1.CustomResourceColumnDefinition{
@@ -250,6 +511,18 @@ var (
 						Description: "The number of nodes in the Aerospike cluster",
 						JSONPath:    ".status.nodeCount",
 					},
+					{
+						Name:        "Ready",
+						Type:        "integer",
+						Description: "The number of nodes in the Aerospike cluster that are running and ready",
+						JSONPath:    ".status.readyNodes",
+					},
+					{
+						Name:        "Phase",
+						Type:        "string",
+						Description: "The current phase of the Aerospike cluster",
+						JSONPath:    ".status.phase",
+					},
 					{
 						Name:        "Age",
 						Type:        "date",
@@ -391,5 +664,191 @@ var (
 				},
 			},
 		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: AerospikeSecondaryIndexCRDName,
+			},
+			Spec: extsv1beta1.CustomResourceDefinitionSpec{
+				Group: aerospikev1alpha2.SchemeGroupVersion.Group,
+				Versions: []extsv1beta1.CustomResourceDefinitionVersion{
+					{
+						Name:    aerospikev1alpha2.SchemeGroupVersion.Version,
+						Served:  true,
+						Storage: true,
+					},
+				},
+				Scope: extsv1beta1.NamespaceScoped,
+				Names: extsv1beta1.CustomResourceDefinitionNames{
+					Plural:     AerospikeSecondaryIndexPlural,
+					Kind:       AerospikeSecondaryIndexKind,
+					ShortNames: []string{AerospikeSecondaryIndexShort},
+				},
+				Validation: &extsv1beta1.CustomResourceValidation{
+					OpenAPIV3Schema: &extsv1beta1.JSONSchemaProps{
+						Properties: map[string]extsv1beta1.JSONSchemaProps{
+							"spec": {
+								Properties: map[string]extsv1beta1.JSONSchemaProps{
+									"target": backupRestoreTargetProps,
+									"set": {
+										Type:      "string",
+										MinLength: pointers.NewInt64(1),
+										MaxLength: pointers.NewInt64(63),
+									},
+									"bin": {
+										Type:      "string",
+										MinLength: pointers.NewInt64(1),
+										MaxLength: pointers.NewInt64(14),
+									},
+									"type": {
+										Type: "string",
+										Enum: []extsv1beta1.JSON{
+											{Raw: []byte(asstrings.DoubleQuoted(common.SecondaryIndexTypeNumeric))},
+											{Raw: []byte(asstrings.DoubleQuoted(common.SecondaryIndexTypeString))},
+											{Raw: []byte(asstrings.DoubleQuoted(common.SecondaryIndexTypeGeo2DSphere))},
+										},
+									},
+								},
+								Required: []string{
+									"target",
+									"bin",
+									"type",
+								},
+							},
+						},
+					},
+				},
+				Subresources: &extsv1beta1.CustomResourceSubresources{
+					Status: &extsv1beta1.CustomResourceSubresourceStatus{},
+				},
+				AdditionalPrinterColumns: []extsv1beta1.CustomResourceColumnDefinition{
+					{
+						Name:        "Target Cluster",
+						Type:        "string",
+						Description: "The name of the Aerospike cluster in which the secondary index is created",
+						JSONPath:    ".status.target.cluster",
+					},
+					{
+						Name:        "Target Namespace",
+						Type:        "string",
+						Description: "The name of the Aerospike namespace in which the secondary index is created",
+						JSONPath:    ".status.target.namespace",
+					},
+					{
+						Name:        "Bin",
+						Type:        "string",
+						Description: "The name of the indexed bin",
+						JSONPath:    ".status.bin",
+					},
+					{
+						Name:        "Progress",
+						Type:        "integer",
+						Description: "The build progress of the secondary index",
+						JSONPath:    ".status.buildProgress",
+					},
+					{
+						Name:        "Age",
+						Type:        "date",
+						Description: "Time elapsed since the resource was created",
+						JSONPath:    ".metadata.creationTimestamp",
+					},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: AerospikeUDFCRDName,
+			},
+			Spec: extsv1beta1.CustomResourceDefinitionSpec{
+				Group: aerospikev1alpha2.SchemeGroupVersion.Group,
+				Versions: []extsv1beta1.CustomResourceDefinitionVersion{
+					{
+						Name:    aerospikev1alpha2.SchemeGroupVersion.Version,
+						Served:  true,
+						Storage: true,
+					},
+				},
+				Scope: extsv1beta1.NamespaceScoped,
+				Names: extsv1beta1.CustomResourceDefinitionNames{
+					Plural:     AerospikeUDFPlural,
+					Kind:       AerospikeUDFKind,
+					ShortNames: []string{AerospikeUDFShort},
+				},
+				Validation: &extsv1beta1.CustomResourceValidation{
+					OpenAPIV3Schema: &extsv1beta1.JSONSchemaProps{
+						Properties: map[string]extsv1beta1.JSONSchemaProps{
+							"spec": {
+								Properties: map[string]extsv1beta1.JSONSchemaProps{
+									"target": {
+										Type: "object",
+										Properties: map[string]extsv1beta1.JSONSchemaProps{
+											"cluster": {
+												Type:      "string",
+												MinLength: pointers.NewInt64(1),
+											},
+										},
+										Required: []string{
+											"cluster",
+										},
+									},
+									"source": {
+										Type: "object",
+										Properties: map[string]extsv1beta1.JSONSchemaProps{
+											"inline": {
+												Type:      "string",
+												MinLength: pointers.NewInt64(1),
+											},
+											"configMapKeyRef": {
+												Type: "object",
+												Properties: map[string]extsv1beta1.JSONSchemaProps{
+													"name": {
+														Type:      "string",
+														MinLength: pointers.NewInt64(1),
+													},
+													"key": {
+														Type:      "string",
+														MinLength: pointers.NewInt64(1),
+													},
+												},
+												Required: []string{
+													"name",
+													"key",
+												},
+											},
+										},
+									},
+								},
+								Required: []string{
+									"target",
+									"source",
+								},
+							},
+						},
+					},
+				},
+				Subresources: &extsv1beta1.CustomResourceSubresources{
+					Status: &extsv1beta1.CustomResourceSubresourceStatus{},
+				},
+				AdditionalPrinterColumns: []extsv1beta1.CustomResourceColumnDefinition{
+					{
+						Name:        "Target Cluster",
+						Type:        "string",
+						Description: "The name of the Aerospike cluster in which the UDF module is registered",
+						JSONPath:    ".spec.target.cluster",
+					},
+					{
+						Name:        "Hash",
+						Type:        "string",
+						Description: "The hash of the registered UDF module",
+						JSONPath:    ".status.hash",
+					},
+					{
+						Name:        "Age",
+						Type:        "date",
+						Description: "Time elapsed since the resource was created",
+						JSONPath:    ".metadata.creationTimestamp",
+					},
+				},
+			},
+		},
 	}
 )
@@ -37,7 +37,7 @@ func TestCreateCRDFailsOnInternalError(t *testing.T) {
 	extsClient.PrependReactor("create", "customresourcedefinitions", func(_ kubetesting.Action) (bool, runtime.Object, error) {
 		return true, nil, errors.NewInternalError(assert.AnError)
 	})
-	r := NewCRDRegistry(extsClient)
+	r := NewCRDRegistry(extsClient, nil)
 	err := r.createCRD(crds[0])
 	assert.Error(t, err)
 }
@@ -47,7 +47,7 @@ func TestCreateCRDDoesNotFailOnAlreadyExists(t *testing.T) {
 	extsClient.PrependReactor("create", "customresourcedefinitions", func(_ kubetesting.Action) (bool, runtime.Object, error) {
 		return true, nil, errors.NewAlreadyExists(schema.GroupResource{}, "")
 	})
-	r := NewCRDRegistry(extsClient)
+	r := NewCRDRegistry(extsClient, nil)
 	err := r.createCRD(crds[0])
 	assert.NoError(t, err)
 }
@@ -58,7 +58,7 @@ func TestAwaitCRDWaitsForEstablishedCondition(t *testing.T) {
 	extsClient.PrependWatchReactor("customresourcedefinitions", func(_ kubetesting.Action) (bool, watch.Interface, error) {
 		return true, fw, nil
 	})
-	r := NewCRDRegistry(extsClient)
+	r := NewCRDRegistry(extsClient, nil)
 
 	var (
 		wg  sync.WaitGroup
@@ -72,7 +72,7 @@ func TestAwaitCRDWaitsForEstablishedCondition(t *testing.T) {
 	t0 = time.Now()
 	go func() {
 		defer wg.Done()
-		err = r.awaitCRD(crds[0])
+		err = r.awaitCRD(crds[0], watchTimeout)
 		t1 = time.Now()
 	}()
 	wg.Add(1)
@@ -16,9 +16,46 @@ limitations under the License.
 
 package errors
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 var (
-	PodUpgradeFailed    = fmt.Errorf("pod upgrade failed")
-	ClusterBackupFailed = fmt.Errorf("cluster backup failed")
+	PodUpgradeFailed     = fmt.Errorf("pod upgrade failed")
+	ClusterBackupFailed  = fmt.Errorf("cluster backup failed")
+	ClusterRestoreFailed = fmt.Errorf("cluster restore failed")
 )
+
+// RequeueError is returned by a reconciler when an operation is still in
+// progress, and indicates that the resource being reconciled should be
+// processed again after the specified delay.
+type RequeueError struct {
+	// After is the delay after which the resource should be processed again.
+	After time.Duration
+	// Reason is a human-readable description of what is being waited for.
+	Reason string
+}
+
+// Error returns a string representation of the RequeueError.
+func (e *RequeueError) Error() string {
+	return fmt.Sprintf("%s (requeuing after %s)", e.Reason, e.After)
+}
+
+// NewRequeueError returns a RequeueError with the specified delay and a
+// reason built from the specified format and arguments.
+func NewRequeueError(after time.Duration, format string, args ...interface{}) error {
+	return &RequeueError{
+		After:  after,
+		Reason: fmt.Sprintf(format, args...),
+	}
+}
+
+// IsRequeue indicates whether the specified error is a RequeueError, and
+// returns the delay after which the resource should be processed again.
+func IsRequeue(err error) (time.Duration, bool) {
+	if e, ok := err.(*RequeueError); ok {
+		return e.After, true
+	}
+	return 0, false
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package images
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/api/core/v1"
+
+	"github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/common"
+	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
+	"github.com/travelaudience/aerospike-operator/pkg/versioning"
+)
+
+var (
+	// AerospikeServerRepository is the repository from which to pull the
+	// aerospike-server image, unless otherwise specified for a given cluster.
+	AerospikeServerRepository = "aerospike/aerospike-server"
+	// AerospikeServerEnterpriseRepository is the repository from which to
+	// pull the aerospike-server-enterprise image, unless otherwise specified
+	// for a given cluster.
+	AerospikeServerEnterpriseRepository = "aerospike/aerospike-server-enterprise"
+	// ToolsRepository is the repository from which to pull the
+	// aerospike-operator-tools image, unless otherwise specified for a given
+	// cluster.
+	ToolsRepository = "quay.io/travelaudience/aerospike-operator-tools"
+	// PullPolicy is the pull policy to use for every image, unless otherwise
+	// specified for a given cluster. If empty, the default pull policy for
+	// each container is used.
+	PullPolicy string
+	// PullSecrets is the comma-separated list of names of the secrets to use
+	// when pulling images, unless otherwise specified for a given cluster.
+	PullSecrets string
+)
+
+// ValidatePullPolicy makes sure that the value of PullPolicy is valid.
+func ValidatePullPolicy() error {
+	switch v1.PullPolicy(PullPolicy) {
+	case "", v1.PullAlways, v1.PullIfNotPresent, v1.PullNever:
+		return nil
+	default:
+		return fmt.Errorf("invalid image pull policy %q", PullPolicy)
+	}
+}
+
+// AerospikeServerImage returns the aerospike-server image to use for the
+// specified cluster, edition and aerospike version.
+func AerospikeServerImage(spec *aerospikev1alpha2.AerospikeImagesSpec, edition string, version versioning.Version) string {
+	repository := AerospikeServerRepository
+	if edition == common.EditionEnterprise {
+		repository = AerospikeServerEnterpriseRepository
+	}
+	if spec != nil && spec.AerospikeServerRepository != "" {
+		repository = spec.AerospikeServerRepository
+	}
+	return fmt.Sprintf("%s:%s", repository, version)
+}
+
+// ToolsImage returns the aerospike-operator-tools image to use for the
+// specified cluster.
+func ToolsImage(spec *aerospikev1alpha2.AerospikeImagesSpec) string {
+	repository := ToolsRepository
+	if spec != nil && spec.ToolsRepository != "" {
+		repository = spec.ToolsRepository
+	}
+	return fmt.Sprintf("%s:%s", repository, versioning.OperatorVersion)
+}
+
+// GetPullPolicy returns the pull policy to use for the specified cluster,
+// falling back to the value of PullPolicy and then to defaultPolicy.
+func GetPullPolicy(spec *aerospikev1alpha2.AerospikeImagesSpec, defaultPolicy v1.PullPolicy) v1.PullPolicy {
+	if spec != nil && spec.PullPolicy != "" {
+		return spec.PullPolicy
+	}
+	if PullPolicy != "" {
+		return v1.PullPolicy(PullPolicy)
+	}
+	return defaultPolicy
+}
+
+// GetPullSecrets returns the secrets to use when pulling images for the
+// specified cluster. The secrets specified for the cluster replace the ones
+// specified in PullSecrets, since the latter may not exist in the cluster's
+// namespace.
+func GetPullSecrets(spec *aerospikev1alpha2.AerospikeImagesSpec) []v1.LocalObjectReference {
+	if spec != nil && len(spec.PullSecrets) > 0 {
+		res := make([]v1.LocalObjectReference, len(spec.PullSecrets))
+		copy(res, spec.PullSecrets)
+		return res
+	}
+	var res []v1.LocalObjectReference
+	for _, name := range strings.Split(PullSecrets, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			res = append(res, v1.LocalObjectReference{Name: name})
+		}
+	}
+	return res
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package images
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/api/core/v1"
+
+	"github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/common"
+	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
+	"github.com/travelaudience/aerospike-operator/pkg/versioning"
+)
+
+func TestAerospikeServerImage(t *testing.T) {
+	tests := []struct {
+		spec     *aerospikev1alpha2.AerospikeImagesSpec
+		edition  string
+		version  string
+		expected string
+	}{
+		{nil, common.EditionCommunity, "4.2.0.10", "aerospike/aerospike-server:4.2.0.10"},
+		{&aerospikev1alpha2.AerospikeImagesSpec{}, common.EditionCommunity, "4.2.0.10", "aerospike/aerospike-server:4.2.0.10"},
+		{&aerospikev1alpha2.AerospikeImagesSpec{AerospikeServerRepository: "mirror.local/aerospike-server"}, common.EditionCommunity, "4.2.0.10", "mirror.local/aerospike-server:4.2.0.10"},
+		{nil, common.EditionEnterprise, "4.2.0.10", "aerospike/aerospike-server-enterprise:4.2.0.10"},
+		{nil, common.EditionEnterprise, "4.2.0.10-ee", "aerospike/aerospike-server-enterprise:4.2.0.10"},
+		{&aerospikev1alpha2.AerospikeImagesSpec{AerospikeServerRepository: "mirror.local/aerospike-server-enterprise"}, common.EditionEnterprise, "4.2.0.10", "mirror.local/aerospike-server-enterprise:4.2.0.10"},
+	}
+	for _, test := range tests {
+		version, err := versioning.NewVersionFromString(test.version)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, AerospikeServerImage(test.spec, test.edition, version))
+	}
+}
+
+func TestToolsImage(t *testing.T) {
+	spec := &aerospikev1alpha2.AerospikeImagesSpec{ToolsRepository: "mirror.local/tools"}
+	assert.Equal(t, "quay.io/travelaudience/aerospike-operator-tools:"+versioning.OperatorVersion, ToolsImage(nil))
+	assert.Equal(t, "mirror.local/tools:"+versioning.OperatorVersion, ToolsImage(spec))
+}
+
+func TestGetPullPolicy(t *testing.T) {
+	defer func(v string) { PullPolicy = v }(PullPolicy)
+
+	PullPolicy = ""
+	assert.Equal(t, v1.PullAlways, GetPullPolicy(nil, v1.PullAlways))
+	assert.Equal(t, v1.PullPolicy(""), GetPullPolicy(nil, ""))
+	PullPolicy = string(v1.PullNever)
+	assert.Equal(t, v1.PullNever, GetPullPolicy(nil, v1.PullAlways))
+	assert.Equal(t, v1.PullIfNotPresent, GetPullPolicy(&aerospikev1alpha2.AerospikeImagesSpec{PullPolicy: v1.PullIfNotPresent}, v1.PullAlways))
+}
+
+func TestGetPullSecrets(t *testing.T) {
+	defer func(v string) { PullSecrets = v }(PullSecrets)
+
+	PullSecrets = ""
+	assert.Empty(t, GetPullSecrets(nil))
+	PullSecrets = "foo, bar,"
+	assert.Equal(t, []v1.LocalObjectReference{{Name: "foo"}, {Name: "bar"}}, GetPullSecrets(nil))
+	spec := &aerospikev1alpha2.AerospikeImagesSpec{PullSecrets: []v1.LocalObjectReference{{Name: "baz"}}}
+	assert.Equal(t, []v1.LocalObjectReference{{Name: "baz"}}, GetPullSecrets(spec))
+}
+
+func TestValidatePullPolicy(t *testing.T) {
+	defer func(v string) { PullPolicy = v }(PullPolicy)
+
+	for _, policy := range []string{"", "Always", "IfNotPresent", "Never"} {
+		PullPolicy = policy
+		assert.NoError(t, ValidatePullPolicy())
+	}
+	PullPolicy = "Sometimes"
+	assert.Error(t, ValidatePullPolicy())
+}
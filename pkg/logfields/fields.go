@@ -23,6 +23,8 @@ const (
 	AerospikeCluster          = "aerospikecluster"
 	AerospikeNamespaceBackup  = "aerospikenamespacebackup"
 	AerospikeNamespaceRestore = "aerospikenamespacerestore"
+	AerospikeSecondaryIndex   = "aerospikesecondaryindex"
+	AerospikeUDF              = "aerospikeudf"
 	Pod                       = "pod"
 	Node                      = "node"
 	Service                   = "service"
@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -43,9 +44,17 @@ func (r *AerospikeClusterReconciler) backupCluster(aerospikeCluster *aerospikev1
 }
 
 func (r *AerospikeClusterReconciler) isClusterBackupFinished(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) (bool, error) {
+	return r.isUpgradeBackupFinished(aerospikeCluster, aerospikeCluster.Status.Version, aerospikeCluster.Spec.Version)
+}
+
+// isUpgradeBackupFinished indicates whether the backup of every namespace
+// specified in .spec.namespaces made before upgrading from sourceVersion to
+// targetVersion has finished.
+func (r *AerospikeClusterReconciler) isUpgradeBackupFinished(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, sourceVersion, targetVersion string) (bool, error) {
 	// if the backup of one of the namespaces have not finished, return false
 	for _, namespace := range aerospikeCluster.Spec.Namespaces {
-		if finished, err := r.isBackupCompleted(aerospikeCluster, namespace.Name); err != nil {
+		name := GetBackupName(namespace.Name, sourceVersion, targetVersion)
+		if finished, err := r.isBackupCompleted(aerospikeCluster, name); err != nil {
 			return false, err
 		} else if !finished {
 			return false, nil
@@ -98,9 +107,9 @@ func (r *AerospikeClusterReconciler) createNamespaceBackup(aerospikeCluster *aer
 	return nil
 }
 
-func (r *AerospikeClusterReconciler) isBackupCompleted(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, ns string) (bool, error) {
+func (r *AerospikeClusterReconciler) isBackupCompleted(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, name string) (bool, error) {
 	// get the AerospikeNamespaceBackup resource
-	backup, err := r.aerospikeBackupsLister.AerospikeNamespaceBackups(aerospikeCluster.Namespace).Get(GetBackupName(ns, aerospikeCluster.Status.Version, aerospikeCluster.Spec.Version))
+	backup, err := r.aerospikeBackupsLister.AerospikeNamespaceBackups(aerospikeCluster.Namespace).Get(name)
 	if err != nil {
 		return false, err
 	}
@@ -118,6 +127,144 @@ func (r *AerospikeClusterReconciler) isBackupCompleted(aerospikeCluster *aerospi
 	return false, nil
 }
 
+// deleteUnfinishedUpgradeBackups deletes the backups of the namespaces
+// specified in .spec.namespaces made before upgrading from sourceVersion to
+// targetVersion that have not finished, so that they can be made again. it
+// returns whether any of them still existed.
+func (r *AerospikeClusterReconciler) deleteUnfinishedUpgradeBackups(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, sourceVersion, targetVersion string) (bool, error) {
+	deleted := false
+	for _, namespace := range aerospikeCluster.Spec.Namespaces {
+		name := GetBackupName(namespace.Name, sourceVersion, targetVersion)
+		if finished, err := r.isBackupCompleted(aerospikeCluster, name); err != nil && err != errors.ClusterBackupFailed {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return false, err
+		} else if finished {
+			continue
+		}
+		err := r.aerospikeclientset.AerospikeV1alpha2().AerospikeNamespaceBackups(aerospikeCluster.Namespace).Delete(name, &metav1.DeleteOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return false, err
+		}
+		deleted = true
+	}
+	return deleted, nil
+}
+
+// restoreCluster restores each namespace specified in .spec.namespaces from
+// the backup made before upgrading from sourceVersion to targetVersion. It
+// returns errors.ClusterBackupFailed if any of the backups does not exist or
+// has failed, and requests the cluster to be requeued if any of them has not
+// finished yet.
+func (r *AerospikeClusterReconciler) restoreCluster(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, sourceVersion, targetVersion string) error {
+	for _, namespace := range aerospikeCluster.Spec.Namespaces {
+		name := GetBackupName(namespace.Name, sourceVersion, targetVersion)
+		if finished, err := r.isBackupCompleted(aerospikeCluster, name); err != nil {
+			if k8serrors.IsNotFound(err) {
+				return errors.ClusterBackupFailed
+			}
+			return err
+		} else if !finished {
+			return errors.NewRequeueError(backupRequeuePeriod, "waiting for backup %s to finish before restoring it", name)
+		}
+	}
+	for _, namespace := range aerospikeCluster.Spec.Namespaces {
+		if err := r.createNamespaceRestore(aerospikeCluster, namespace.Name, GetBackupName(namespace.Name, sourceVersion, targetVersion)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isClusterRestoreFinished indicates whether the restore of every namespace
+// specified in .spec.namespaces from the backup made before upgrading from
+// sourceVersion to targetVersion has finished.
+func (r *AerospikeClusterReconciler) isClusterRestoreFinished(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, sourceVersion, targetVersion string) (bool, error) {
+	for _, namespace := range aerospikeCluster.Spec.Namespaces {
+		if finished, err := r.isRestoreCompleted(aerospikeCluster, GetBackupName(namespace.Name, sourceVersion, targetVersion)); err != nil {
+			return false, err
+		} else if !finished {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (r *AerospikeClusterReconciler) createNamespaceRestore(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, ns, name string) error {
+	// the restore must have the same name as the backup it restores
+	restore := aerospikev1alpha2.AerospikeNamespaceRestore{
+		ObjectMeta: v1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				selectors.LabelAppKey:       selectors.LabelAppVal,
+				selectors.LabelClusterKey:   aerospikeCluster.Name,
+				selectors.LabelNamespaceKey: ns,
+			},
+			Namespace: aerospikeCluster.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion:         aerospikev1alpha2.SchemeGroupVersion.String(),
+					Kind:               crd.AerospikeClusterKind,
+					Name:               aerospikeCluster.Name,
+					UID:                aerospikeCluster.UID,
+					Controller:         pointers.NewBool(true),
+					BlockOwnerDeletion: pointers.NewBool(true),
+				},
+			},
+		},
+		Spec: aerospikev1alpha2.AerospikeNamespaceRestoreSpec{
+			Target: aerospikev1alpha2.TargetNamespace{
+				Cluster:   aerospikeCluster.Name,
+				Namespace: ns,
+			},
+			Storage: &aerospikev1alpha2.BackupStorageSpec{
+				Type:            aerospikeCluster.Spec.BackupSpec.Storage.Type,
+				Bucket:          aerospikeCluster.Spec.BackupSpec.Storage.Bucket,
+				Secret:          aerospikeCluster.Spec.BackupSpec.Storage.GetSecret(),
+				SecretNamespace: aerospikeCluster.Spec.BackupSpec.Storage.SecretNamespace,
+				SecretKey:       aerospikeCluster.Spec.BackupSpec.Storage.SecretKey,
+			},
+		},
+	}
+
+	_, err := r.aerospikeclientset.AerospikeV1alpha2().AerospikeNamespaceRestores(aerospikeCluster.Namespace).Create(&restore)
+	if err != nil && !k8serrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+func (r *AerospikeClusterReconciler) isRestoreCompleted(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, name string) (bool, error) {
+	// get the AerospikeNamespaceRestore resource
+	restore, err := r.aerospikeRestoresLister.AerospikeNamespaceRestores(aerospikeCluster.Namespace).Get(name)
+	if k8serrors.IsNotFound(err) {
+		// the lister may not know about a recently created restore yet, so
+		// we confirm its absence with the api before deciding it is missing
+		restore, err = r.aerospikeclientset.AerospikeV1alpha2().AerospikeNamespaceRestores(aerospikeCluster.Namespace).Get(name, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			// the restore was never created or has since been deleted, so
+			// it will never finish
+			return false, errors.ClusterRestoreFailed
+		}
+	}
+	if err != nil {
+		return false, err
+	}
+
+	// look for ConditionRestoreFinished
+	for _, condition := range restore.Status.Conditions {
+		if condition.Type == common.ConditionRestoreFinished &&
+			condition.Status == apiextensions.ConditionTrue {
+			return true, nil
+		} else if condition.Type == common.ConditionRestoreFailed &&
+			condition.Status == apiextensions.ConditionTrue {
+			return false, errors.ClusterRestoreFailed
+		}
+	}
+	return false, nil
+}
+
 // GetBackupName returns the name of a backup created automatically before upgrading
 func GetBackupName(ns, sourceVersion, targetVersion string) string {
 	return fmt.Sprintf("%s-%s-%s-upgrade", ns,
@@ -17,31 +17,42 @@ limitations under the License.
 package reconciler
 
 import (
+	"sync"
+	"time"
+
 	log "github.com/sirupsen/logrus"
 	"k8s.io/client-go/kubernetes"
 	listersv1 "k8s.io/client-go/listers/core/v1"
 	storagelistersv1 "k8s.io/client-go/listers/storage/v1"
 	"k8s.io/client-go/tools/record"
 
+	"github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/common"
 	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
 	aerospikeclientset "github.com/travelaudience/aerospike-operator/pkg/client/clientset/versioned"
 	aerospikelisters "github.com/travelaudience/aerospike-operator/pkg/client/listers/aerospike/v1alpha2"
 	"github.com/travelaudience/aerospike-operator/pkg/errors"
 	"github.com/travelaudience/aerospike-operator/pkg/logfields"
 	"github.com/travelaudience/aerospike-operator/pkg/meta"
+	"github.com/travelaudience/aerospike-operator/pkg/utils/annotations"
 	"github.com/travelaudience/aerospike-operator/pkg/versioning"
 )
 
 type AerospikeClusterReconciler struct {
-	kubeclientset          kubernetes.Interface
-	aerospikeclientset     aerospikeclientset.Interface
-	podsLister             listersv1.PodLister
-	configMapsLister       listersv1.ConfigMapLister
-	servicesLister         listersv1.ServiceLister
-	pvcsLister             listersv1.PersistentVolumeClaimLister
-	scsLister              storagelistersv1.StorageClassLister
-	aerospikeBackupsLister aerospikelisters.AerospikeNamespaceBackupLister
-	recorder               record.EventRecorder
+	kubeclientset           kubernetes.Interface
+	aerospikeclientset      aerospikeclientset.Interface
+	podsLister              listersv1.PodLister
+	configMapsLister        listersv1.ConfigMapLister
+	servicesLister          listersv1.ServiceLister
+	pvcsLister              listersv1.PersistentVolumeClaimLister
+	scsLister               storagelistersv1.StorageClassLister
+	nodesLister             listersv1.NodeLister
+	aerospikeBackupsLister  aerospikelisters.AerospikeNamespaceBackupLister
+	aerospikeRestoresLister aerospikelisters.AerospikeNamespaceRestoreLister
+	recorder                record.EventRecorder
+	// nodeStatisticsReportedAt holds the time at which the statistics of the
+	// aerospike nodes of each cluster were last reported
+	nodeStatisticsReportedAt map[string]time.Time
+	nodeStatisticsMutex      sync.Mutex
 }
 
 func New(kubeclientset kubernetes.Interface,
@@ -51,18 +62,23 @@ func New(kubeclientset kubernetes.Interface,
 	servicesLister listersv1.ServiceLister,
 	pvcsLister listersv1.PersistentVolumeClaimLister,
 	scsLister storagelistersv1.StorageClassLister,
+	nodesLister listersv1.NodeLister,
 	aerospikeBackupsLister aerospikelisters.AerospikeNamespaceBackupLister,
+	aerospikeRestoresLister aerospikelisters.AerospikeNamespaceRestoreLister,
 	recorder record.EventRecorder) *AerospikeClusterReconciler {
 	return &AerospikeClusterReconciler{
-		kubeclientset:          kubeclientset,
-		aerospikeclientset:     aerospikeclientset,
-		podsLister:             podsLister,
-		configMapsLister:       configMapsLister,
-		servicesLister:         servicesLister,
-		pvcsLister:             pvcsLister,
-		scsLister:              scsLister,
-		aerospikeBackupsLister: aerospikeBackupsLister,
-		recorder:               recorder,
+		kubeclientset:            kubeclientset,
+		aerospikeclientset:       aerospikeclientset,
+		podsLister:               podsLister,
+		configMapsLister:         configMapsLister,
+		servicesLister:           servicesLister,
+		pvcsLister:               pvcsLister,
+		scsLister:                scsLister,
+		nodesLister:              nodesLister,
+		aerospikeBackupsLister:   aerospikeBackupsLister,
+		aerospikeRestoresLister:  aerospikeRestoresLister,
+		recorder:                 recorder,
+		nodeStatisticsReportedAt: make(map[string]time.Time),
 	}
 }
 
@@ -72,20 +88,86 @@ func (r *AerospikeClusterReconciler) MaybeReconcile(aerospikeCluster *aerospikev
 		logfields.AerospikeCluster: meta.Key(aerospikeCluster),
 	}).Info("processing cluster")
 
-	// check if a previous upgrade operation has failed, in which case we return
-	if v, ok := aerospikeCluster.ObjectMeta.Annotations[UpgradeStatusAnnotationKey]; ok {
-		if v == UpgradeStatusFailedAnnotationValue {
-			log.WithFields(log.Fields{
-				logfields.AerospikeCluster: meta.Key(aerospikeCluster),
-			}).Warn("a previous version upgrade has failed. aborting")
-			return nil
+	// check if reconciliation has been paused, in which case we only reflect
+	// that fact in the status of the resource and return
+	if aerospikeCluster.Spec.Paused {
+		if !aerospikeCluster.Status.Paused {
+			if _, err := r.signalPaused(aerospikeCluster); err != nil {
+				return err
+			}
+		}
+		// keep reporting the observed state of each aerospike node
+		if err := r.reportNodeStatus(aerospikeCluster); err != nil {
+			return err
+		}
+		log.WithFields(log.Fields{
+			logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+		}).Debug("reconciliation is paused")
+		return nil
+	}
+	// check if reconciliation has just been resumed
+	if aerospikeCluster.Status.Paused {
+		var err error
+		if aerospikeCluster, err = r.signalResumed(aerospikeCluster); err != nil {
+			return err
 		}
 	}
 
-	// check if the current reconcile operation is an upgrade, and if it is
-	// get the corresponding upgrade object
+	// check if a previous upgrade operation has failed, in which case we
+	// either start rolling back the cluster or return, according to
+	// .spec.onUpgradeFailure
+	if v, ok := aerospikeCluster.ObjectMeta.Annotations[annotations.UpgradeStatusAnnotationKey]; ok {
+		if v == annotations.UpgradeStatusFailedAnnotationValue && aerospikeCluster.Spec.GetOnUpgradeFailure() != common.UpgradeFailurePolicyHalt {
+			var err error
+			if aerospikeCluster, err = r.signalRollbackStarted(aerospikeCluster); err != nil {
+				return err
+			}
+		} else if v == annotations.UpgradeStatusFailedAnnotationValue || v == annotations.UpgradeStatusHaltedAnnotationValue {
+			// perform the recovery action requested in .spec.recovery (if
+			// any), in which case we may carry on
+			recovered := false
+			if aerospikeCluster.Spec.Recovery != nil {
+				var err error
+				if aerospikeCluster, recovered, err = r.recoverCluster(aerospikeCluster); err != nil {
+					return err
+				}
+			}
+			if !recovered {
+				log.WithFields(log.Fields{
+					logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+				}).Warn("a previous version upgrade has failed. aborting")
+				_, err := r.signalPhase(aerospikeCluster, common.ClusterPhaseFailed)
+				return err
+			}
+		}
+	}
+
+	// check if the namespaces are being restored after a rollback, in which
+	// case we record the outcome of the restore once it is known
+	if aerospikeCluster.Annotations[annotations.UpgradeStatusAnnotationKey] == annotations.UpgradeStatusRestoreAnnotationValue {
+		finished, err := r.isClusterRestoreFinished(aerospikeCluster, aerospikeCluster.Spec.Version, aerospikeCluster.Annotations[annotations.FailedUpgradeVersionAnnotationKey])
+		if err == errors.ClusterRestoreFailed {
+			if aerospikeCluster, err = r.signalAutoRestoreFailed(aerospikeCluster); err != nil {
+				return err
+			}
+		} else if err != nil {
+			return err
+		} else if finished {
+			if aerospikeCluster, err = r.signalAutoRestoreFinished(aerospikeCluster); err != nil {
+				return err
+			}
+		}
+	}
+
+	// check if the current reconcile operation is an upgrade (or the rollback
+	// of a failed upgrade), and if it is get the corresponding upgrade object
 	var upgrade *versioning.VersionUpgrade
-	if aerospikeCluster.Status.Version != "" && aerospikeCluster.Spec.Version != aerospikeCluster.Status.Version {
+	if isRollingBack(aerospikeCluster) {
+		var err error
+		if upgrade, err = getRollback(aerospikeCluster); err != nil {
+			return err
+		}
+	} else if aerospikeCluster.Status.Version != "" && aerospikeCluster.Spec.Version != aerospikeCluster.Status.Version {
 		// parse the source version
 		source, err := versioning.NewVersionFromString(aerospikeCluster.Status.Version)
 		if err != nil {
@@ -96,21 +178,24 @@ func (r *AerospikeClusterReconciler) MaybeReconcile(aerospikeCluster *aerospikev
 		if err != nil {
 			return err
 		}
-		// get the versionupgrade object
-		upgrade = &versioning.VersionUpgrade{source, target}
+		// get the versionupgrade object, unless only the edition suffix of
+		// the version has changed
+		if !source.Equals(target) {
+			upgrade = &versioning.VersionUpgrade{Source: source, Target: target}
+		}
 	}
 
 	// if the current reconcile operation is an upgrade set the
 	// appropriate annotations (for internal use) and conditions
 	if upgrade != nil {
 		// start the backup if no annotation is present
-		if status, ok := aerospikeCluster.Annotations[UpgradeStatusAnnotationKey]; !ok {
+		if status, ok := aerospikeCluster.Annotations[annotations.UpgradeStatusAnnotationKey]; !ok {
 			var err error
 			if aerospikeCluster, err = r.signalBackupStarted(aerospikeCluster); err != nil {
 				return err
 			}
 			return r.backupCluster(aerospikeCluster)
-		} else if status == UpgradeStatusBackupAnnotationValue {
+		} else if status == annotations.UpgradeStatusBackupAnnotationValue {
 			// check if autobackups have finished
 			if backupsCompleted, err := r.isClusterBackupFinished(aerospikeCluster); err != nil {
 				// if a backup failed, signal with the appropriate annotations
@@ -145,6 +230,32 @@ func (r *AerospikeClusterReconciler) MaybeReconcile(aerospikeCluster *aerospikev
 		}
 	}
 
+	// check if the current reconcile operation changes the replication factor
+	// or storage spec of existing namespaces, in which case we set the
+	// appropriate annotations (for internal use) and conditions
+	updatedNamespaces := getUpdatedNamespaces(aerospikeCluster)
+	if len(updatedNamespaces) > 0 {
+		if _, ok := aerospikeCluster.Annotations[NamespaceUpdateStatusAnnotationKey]; !ok {
+			var err error
+			if aerospikeCluster, err = r.signalNamespaceUpdateStarted(aerospikeCluster, updatedNamespaces); err != nil {
+				return err
+			}
+		}
+	}
+
+	// check if the current reconcile operation is a rolling restart requested
+	// through restartedAt, in which case we set the appropriate annotations
+	// (for internal use) and conditions
+	restart := isRestartRequested(aerospikeCluster)
+	if restart {
+		if _, ok := aerospikeCluster.Annotations[RestartStatusAnnotationKey]; !ok {
+			var err error
+			if aerospikeCluster, err = r.signalRestartStarted(aerospikeCluster); err != nil {
+				return err
+			}
+		}
+	}
+
 	// validate fields that cannot be validated statically
 	valid, err := r.validate(aerospikeCluster)
 	if err != nil {
@@ -154,6 +265,10 @@ func (r *AerospikeClusterReconciler) MaybeReconcile(aerospikeCluster *aerospikev
 	if !valid {
 		return nil
 	}
+	// reflect the operation about to be performed in the phase of the resource
+	if aerospikeCluster, err = r.signalPhase(aerospikeCluster, getProgressPhase(aerospikeCluster, upgrade)); err != nil {
+		return err
+	}
 	// create the service for the cluster
 	if err := r.ensureService(aerospikeCluster); err != nil {
 		return err
@@ -171,12 +286,29 @@ func (r *AerospikeClusterReconciler) MaybeReconcile(aerospikeCluster *aerospikev
 	oldCluster := aerospikeCluster.DeepCopy()
 	// make sure that pods are up-to-date with the spec
 	if err := r.ensurePods(aerospikeCluster, configMap, upgrade); err != nil {
+		// if a pod operation is still in progress, the cluster will be
+		// requeued and processed again later on, so we just keep reporting
+		// the observed state of each aerospike node
+		if isRequeue(err) {
+			if err := r.reportNodeStatus(aerospikeCluster); err != nil {
+				log.WithFields(log.Fields{
+					logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+				}).Warnf("failed to report node status: %v", err)
+			}
+			return err
+		}
 		// if a pod upgrade failed, signal with the appropriate annotations
 		// and conditions
-		if err == errors.PodUpgradeFailed {
+		if err == errors.PodUpgradeFailed && isRollingBack(aerospikeCluster) {
+			if _, err := r.signalRollbackFailed(aerospikeCluster, upgrade); err != nil {
+				log.Errorf("failed to signal failed rollback: %v", err)
+			}
+		} else if err == errors.PodUpgradeFailed {
 			if _, err := r.signalUpgradeFailed(aerospikeCluster, upgrade); err != nil {
 				log.Errorf("failed to signal failed upgrade: %v", err)
 			}
+		} else if _, err := r.signalPhase(aerospikeCluster, common.ClusterPhaseDegraded); err != nil {
+			log.Errorf("failed to signal degraded cluster: %v", err)
 		}
 		// return the original error
 		return err
@@ -184,6 +316,10 @@ func (r *AerospikeClusterReconciler) MaybeReconcile(aerospikeCluster *aerospikev
 
 	// update the status field of aerospikeCluster
 	r.updateStatus(aerospikeCluster)
+	if err := r.updateNodeStatus(aerospikeCluster); err != nil {
+		return err
+	}
+	updateReadiness(aerospikeCluster)
 
 	// patch the cluster with the changes performed in the ensurePods and
 	// updateStatus
@@ -191,12 +327,48 @@ func (r *AerospikeClusterReconciler) MaybeReconcile(aerospikeCluster *aerospikev
 		return err
 	}
 
-	// set the appropriate annotations and conditions if performing an upgrade
-	if upgrade != nil {
+	// set the appropriate annotations and conditions if rolling back a failed
+	// upgrade, restoring the namespaces from the pre-upgrade backups first if
+	// required by .spec.onUpgradeFailure
+	if isRollingBack(aerospikeCluster) {
+		restoreStarted := false
+		if aerospikeCluster.Spec.GetOnUpgradeFailure() == common.UpgradeFailurePolicyRestore {
+			// an unfinished backup causes the cluster to be requeued, while
+			// a missing or failed one causes the rollback to finish without
+			// restoring the namespaces
+			if err := r.restoreCluster(aerospikeCluster, aerospikeCluster.Spec.Version, aerospikeCluster.Annotations[annotations.FailedUpgradeVersionAnnotationKey]); err == nil {
+				restoreStarted = true
+			} else if err != errors.ClusterBackupFailed {
+				return err
+			}
+		}
+		if aerospikeCluster, err = r.signalRollbackFinished(aerospikeCluster, upgrade, restoreStarted); err != nil {
+			return err
+		}
+	} else if upgrade != nil {
+		// set the appropriate annotations and conditions if performing an
+		// upgrade
 		if _, err := r.signalUpgradeFinished(aerospikeCluster, upgrade); err != nil {
 			return err
 		}
 	}
+	// set the appropriate annotations and conditions if updating namespaces
+	if len(updatedNamespaces) > 0 {
+		if _, err := r.signalNamespaceUpdateFinished(aerospikeCluster); err != nil {
+			return err
+		}
+	}
+	// set the appropriate annotations and conditions if restarting the cluster
+	if restart {
+		if _, err := r.signalRestartFinished(aerospikeCluster); err != nil {
+			return err
+		}
+	}
+
+	// keep checking the progress of the restore (if any)
+	if aerospikeCluster.Annotations[annotations.UpgradeStatusAnnotationKey] == annotations.UpgradeStatusRestoreAnnotationValue {
+		return errors.NewRequeueError(restoreRequeuePeriod, "waiting for the restore of cluster %s to finish", meta.Key(aerospikeCluster))
+	}
 
 	return nil
 }
@@ -18,6 +18,8 @@ package reconciler
 
 import (
 	"bytes"
+	"encoding/json"
+	"path"
 	"strconv"
 	"strings"
 
@@ -28,6 +30,7 @@ import (
 
 	"github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/common"
 	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
+	"github.com/travelaudience/aerospike-operator/pkg/asutils"
 	"github.com/travelaudience/aerospike-operator/pkg/crd"
 	"github.com/travelaudience/aerospike-operator/pkg/logfields"
 	"github.com/travelaudience/aerospike-operator/pkg/meta"
@@ -64,8 +67,9 @@ func (r *AerospikeClusterReconciler) updateConfigMap(aerospikeCluster *aerospike
 		return nil, err
 	}
 	// check whether the current configmap resource needs to be updated
-	outdated := asstrings.Hash(currentConfigMap.Data[configFileName]) != currentConfigMap.Annotations[configMapHashAnnotation] ||
-		desiredConfigMap.Annotations[configMapHashAnnotation] != currentConfigMap.Annotations[configMapHashAnnotation]
+	outdated := currentConfigMap.Data[configFileName] != desiredConfigMap.Data[configFileName] ||
+		desiredConfigMap.Annotations[configMapHashAnnotation] != currentConfigMap.Annotations[configMapHashAnnotation] ||
+		desiredConfigMap.Annotations[staticConfigHashAnnotation] != currentConfigMap.Annotations[staticConfigHashAnnotation]
 	// if the configmap is up-to-date, we're good to go
 	if !outdated {
 		log.WithFields(log.Fields{
@@ -109,6 +113,9 @@ func buildConfig(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) string {
 func buildConfigMap(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) *v1.ConfigMap {
 	// build the aerospike config file based on the current spec
 	aerospikeConfig := buildConfig(aerospikeCluster)
+	// build the aerospike config file without any dynamic properties, so
+	// that we can tell whether pods need to be restarted when it changes
+	staticCluster := withoutDynamicConfig(aerospikeCluster)
 	// return a configmap object containing aerospikeConfig
 	return &v1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
@@ -129,21 +136,112 @@ func buildConfigMap(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) *v1.Co
 				},
 			},
 			Annotations: map[string]string{
-				configMapHashAnnotation: asstrings.Hash(aerospikeConfig),
+				configMapHashAnnotation:    computeConfigMapHash(aerospikeCluster, aerospikeConfig),
+				staticConfigHashAnnotation: computeConfigMapHash(staticCluster, buildConfig(staticCluster)),
+				dynamicConfigAnnotation:    marshalDynamicConfig(buildDynamicConfig(aerospikeCluster)),
 			},
 		},
 		Data: map[string]string{configFileName: aerospikeConfig},
 	}
 }
 
+// computeConfigMapHash computes the hash to be stored in the configmap and in
+// every pod that mounts it, covering the aerospike configuration and every
+// other field of the spec whose change requires pods to be restarted.
+func computeConfigMapHash(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, aerospikeConfig string) string {
+	parts := []string{aerospikeConfig}
+	if aerospikeCluster.Spec.Resources != nil {
+		parts = append(parts, marshalForHash(aerospikeCluster, aerospikeCluster.Spec.Resources))
+	}
+	if aerospikeCluster.Spec.PodSpec != nil {
+		parts = append(parts, marshalForHash(aerospikeCluster, aerospikeCluster.Spec.PodSpec))
+	}
+	if aerospikeCluster.Spec.Images != nil {
+		parts = append(parts, marshalForHash(aerospikeCluster, aerospikeCluster.Spec.Images))
+	}
+	if aerospikeCluster.Spec.ExternalAccess != nil {
+		parts = append(parts, marshalForHash(aerospikeCluster, aerospikeCluster.Spec.ExternalAccess))
+	}
+	if aerospikeCluster.Spec.FeatureKeySecret != nil {
+		parts = append(parts, marshalForHash(aerospikeCluster, aerospikeCluster.Spec.FeatureKeySecret))
+	}
+	if aerospikeCluster.Spec.RestartedAt != nil {
+		parts = append(parts, marshalForHash(aerospikeCluster, aerospikeCluster.Spec.RestartedAt))
+	}
+	if len(parts) == 1 {
+		return asstrings.Hash(aerospikeConfig)
+	}
+	return asstrings.HashSlice(parts)
+}
+
+// marshalForHash returns the json representation of obj so that it can be
+// included in the configmap hash.
+func marshalForHash(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, obj interface{}) string {
+	res, err := json.Marshal(obj)
+	if err != nil {
+		log.WithFields(log.Fields{
+			logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+		}).Warnf("failed to marshal %T: %v", obj, err)
+		return ""
+	}
+	return string(res)
+}
+
 func getClusterProps(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, namespacesConfig []string) map[string]interface{} {
+	// grab the user-provided overrides to the aerospike configuration
+	var serviceConfig, loggingConfig, heartbeatConfig, fabricConfig map[string]string
+	if config := aerospikeCluster.Spec.AerospikeConfig; config != nil {
+		serviceConfig = config.Service
+		loggingConfig = config.Logging
+		if config.Network != nil {
+			heartbeatConfig = config.Network.Heartbeat
+			fabricConfig = config.Network.Fabric
+		}
+	}
+	// the "any" context must be rendered before all others, since it sets
+	// the logging level for every context
+	loggingConfig = mergeConfig(loggingConfig)
+	loggingDefaultLevel := defaultLoggingLevel
+	if level, ok := loggingConfig[loggingContextAny]; ok {
+		loggingDefaultLevel = level
+		delete(loggingConfig, loggingContextAny)
+	}
+
+	// aerospike enterprise edition requires a feature key file, which is
+	// mounted into the pod from the secret specified by the user
+	featureKeyFile := ""
+	if aerospikeCluster.Spec.GetEdition() == common.EditionEnterprise {
+		featureKeyFile = path.Join(featureKeyMountPath, featureKeyFileName)
+	}
+
 	return map[string]interface{}{
 		serviceNodeIdKey:            ServiceNodeIdValue,
+		featureKeyFileKey:           featureKeyFile,
+		alternateAccessKey:          aerospikeCluster.Spec.ExternalAccess != nil,
+		alternateAccessAddressKey:   ServiceAlternateAccessAddressValue,
+		alternateAccessPortKey:      ServiceAlternateAccessPortValue,
 		clusterNamespacesKey:        namespacesConfig,
 		heartbeatAddressesConfigKey: HeartbeatAddressesValue,
+		serviceConfigKey:            mergeConfig(defaultServiceConfig, serviceConfig),
+		loggingDefaultLevelKey:      loggingDefaultLevel,
+		loggingConfigKey:            loggingConfig,
+		heartbeatConfigKey:          mergeConfig(defaultHeartbeatConfig, heartbeatConfig),
+		fabricConfigKey:             mergeConfig(fabricConfig),
 	}
 }
 
+// mergeConfig returns a new map containing the properties in every specified
+// map. properties in later maps take precedence over the ones in earlier maps.
+func mergeConfig(configs ...map[string]string) map[string]string {
+	res := make(map[string]string)
+	for _, config := range configs {
+		for key, value := range config {
+			res[key] = value
+		}
+	}
+	return res
+}
+
 func getNamespaceProps(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, index int, namespace *aerospikev1alpha2.AerospikeNamespaceSpec) map[string]interface{} {
 	props := make(map[string]interface{})
 
@@ -157,31 +255,76 @@ func getNamespaceProps(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, ind
 		}
 	}
 
-	if namespace.MemorySize != nil && *namespace.MemorySize != "" {
-		props[nsMemorySizeKey] = namespace.MemorySize
-	} else {
-		// explicitly set a value for memory-size since it is required from aerospike 4.3.0.2 onwards
-		props[nsMemorySizeKey] = defaultMemorySize
+	props[nsMemorySizeKey] = getNamespaceMemorySize(namespace)
+
+	if value, ok := getNamespaceDefaultTTL(namespace); ok {
+		props[nsDefaultTTLKey] = value
 	}
 
-	if namespace.DefaultTTL != nil {
-		if value, err := strconv.Atoi(strings.TrimSuffix(*namespace.DefaultTTL, "s")); err == nil {
-			props[nsDefaultTTLKey] = value
-		}
+	// the rack id depends on the pod, so we use a placeholder that is replaced
+	// by asinit
+	if len(aerospikeCluster.Spec.Racks) > 0 {
+		props[nsRackId] = NamespaceRackIdValue
 	}
 
 	props[nsStorageTypeKey] = namespace.Storage.Type
 
+	// list every file or device across which aerospike stripes writes
 	if namespace.Storage.Type == common.StorageTypeFile {
 		props[nsStorageSizeKey] = namespace.Storage.Size
-		props[nsFilePath] = defaultFilePath
+		filePaths := make([]string, namespace.Storage.GetVolumeCount())
+		for i := range filePaths {
+			filePaths[i] = getFilePath(namespace.Name, i)
+		}
+		props[nsFilePaths] = filePaths
 	} else if namespace.Storage.Type == common.StorageTypeDevice {
-		props[nsDevicePath] = getIndexBasedDevicePath(index)
+		devicePaths := make([]string, namespace.Storage.GetVolumeCount())
+		for i := range devicePaths {
+			devicePaths[i] = getDevicePath(index, i)
+		}
+		props[nsDevicePaths] = devicePaths
 	}
 
 	if namespace.Storage.DataInMemory != nil {
 		props[nsDataInMemory] = *namespace.Storage.DataInMemory
 	}
 
+	// split the user-provided overrides between the namespace stanza and the
+	// storage-engine sub-stanza
+	config := make(map[string]string)
+	storageEngineConfig := make(map[string]string)
+	for key, value := range namespace.AerospikeConfig {
+		if asutils.StorageEngineConfigKeys.Has(key) {
+			storageEngineConfig[key] = value
+		} else {
+			config[key] = value
+		}
+	}
+	props[nsConfig] = config
+	props[nsStorageEngineConfig] = storageEngineConfig
+
 	return props
 }
+
+// getNamespaceMemorySize returns the value of memory-size for the specified
+// namespace.
+func getNamespaceMemorySize(namespace *aerospikev1alpha2.AerospikeNamespaceSpec) string {
+	if namespace.MemorySize != nil && *namespace.MemorySize != "" {
+		return *namespace.MemorySize
+	}
+	// explicitly set a value for memory-size since it is required from aerospike 4.3.0.2 onwards
+	return defaultMemorySize
+}
+
+// getNamespaceDefaultTTL returns the value of default-ttl (in seconds) for
+// the specified namespace, and whether it has been specified at all.
+func getNamespaceDefaultTTL(namespace *aerospikev1alpha2.AerospikeNamespaceSpec) (int, bool) {
+	if namespace.DefaultTTL == nil {
+		return 0, false
+	}
+	value, err := strconv.Atoi(strings.TrimSuffix(*namespace.DefaultTTL, "s"))
+	if err != nil {
+		return 0, false
+	}
+	return value, true
+}
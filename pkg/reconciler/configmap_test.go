@@ -0,0 +1,174 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/common"
+	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
+	"github.com/travelaudience/aerospike-operator/pkg/pointers"
+)
+
+// configLines returns the non-empty lines of the specified aerospike
+// configuration, stripped of leading and trailing whitespace.
+func configLines(config string) []string {
+	var res []string
+	for _, line := range strings.Split(config, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			res = append(res, line)
+		}
+	}
+	return res
+}
+
+// indexOf returns the index of the first line equal to value at or after the
+// specified index, or -1 if there is none.
+func indexOf(lines []string, value string, from int) int {
+	for i := from; i < len(lines); i++ {
+		if lines[i] == value {
+			return i
+		}
+	}
+	return -1
+}
+
+func newAerospikeClusterForConfig(storageType string) *aerospikev1alpha2.AerospikeCluster {
+	return &aerospikev1alpha2.AerospikeCluster{
+		Spec: aerospikev1alpha2.AerospikeClusterSpec{
+			Version:   "4.2.0.10",
+			NodeCount: 2,
+			Namespaces: []aerospikev1alpha2.AerospikeNamespaceSpec{
+				{
+					Name:              "ns0",
+					ReplicationFactor: pointers.NewInt32(2),
+					MemorySize:        pointers.NewString("2G"),
+					Storage: aerospikev1alpha2.StorageSpec{
+						Type: storageType,
+						Size: "4G",
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestBuildConfigWithoutOverrides(t *testing.T) {
+	lines := configLines(buildConfig(newAerospikeClusterForConfig(common.StorageTypeFile)))
+	assert.Contains(t, lines, "node-id "+ServiceNodeIdValue)
+	assert.Contains(t, lines, "context any "+defaultLoggingLevel)
+	assert.Contains(t, lines, "namespace ns0 {")
+	assert.Contains(t, lines, "replication-factor 2")
+	assert.Contains(t, lines, "memory-size 2G")
+	assert.Contains(t, lines, "file "+getFilePath("ns0", 0))
+	assert.Contains(t, lines, "filesize 4G")
+	assert.NotContains(t, lines, "feature-key-file "+featureKeyMountPath+"/"+featureKeyFileName)
+	assert.NotContains(t, lines, "alternate-access-address "+ServiceAlternateAccessAddressValue)
+	for key, value := range defaultServiceConfig {
+		assert.Contains(t, lines, key+" "+value)
+	}
+	for key, value := range defaultHeartbeatConfig {
+		assert.Contains(t, lines, key+" "+value)
+	}
+}
+
+func TestBuildConfigWithOverrides(t *testing.T) {
+	aerospikeCluster := newAerospikeClusterForConfig(common.StorageTypeFile)
+	aerospikeCluster.Spec.AerospikeConfig = &aerospikev1alpha2.AerospikeConfigSpec{
+		Service: map[string]string{
+			"migrate-threads": "2",
+		},
+		Logging: map[string]string{
+			loggingContextAny: "warning",
+			"migrate":         "debug",
+		},
+		Network: &aerospikev1alpha2.AerospikeNetworkConfigSpec{
+			Heartbeat: map[string]string{
+				"interval": "250",
+			},
+			Fabric: map[string]string{
+				"send-threads": "4",
+			},
+		},
+	}
+	aerospikeCluster.Spec.Namespaces[0].AerospikeConfig = map[string]string{
+		"high-water-memory-pct": "70",
+		"write-block-size":      "128K",
+	}
+	lines := configLines(buildConfig(aerospikeCluster))
+
+	// service overrides are rendered in the service stanza
+	service := indexOf(lines, "service {", 0)
+	assert.NotEqual(t, -1, service)
+	assert.NotEqual(t, -1, indexOf(lines, "migrate-threads 2", service))
+
+	// the "any" logging context replaces the default level and is rendered
+	// before the remaining contexts in both sinks
+	assert.NotContains(t, lines, "context any "+defaultLoggingLevel)
+	for from, i := 0, 0; i < 2; i++ {
+		anyContext := indexOf(lines, "context any warning", from)
+		assert.NotEqual(t, -1, anyContext)
+		migrate := indexOf(lines, "context migrate debug", anyContext)
+		assert.NotEqual(t, -1, migrate)
+		from = migrate + 1
+	}
+
+	// network overrides are rendered in their sub-stanzas and take
+	// precedence over the defaults
+	heartbeat := indexOf(lines, "heartbeat {", 0)
+	fabric := indexOf(lines, "fabric {", 0)
+	assert.NotEqual(t, -1, heartbeat)
+	assert.NotEqual(t, -1, fabric)
+	interval := indexOf(lines, "interval 250", heartbeat)
+	assert.True(t, interval > heartbeat && interval < fabric)
+	assert.NotEqual(t, -1, indexOf(lines, "send-threads 4", fabric))
+
+	// namespace overrides are split between the namespace stanza and the
+	// storage-engine sub-stanza
+	namespace := indexOf(lines, "namespace ns0 {", 0)
+	storageEngine := indexOf(lines, "storage-engine device {", namespace)
+	assert.NotEqual(t, -1, namespace)
+	assert.NotEqual(t, -1, storageEngine)
+	highWaterMemoryPct := indexOf(lines, "high-water-memory-pct 70", namespace)
+	assert.True(t, highWaterMemoryPct > namespace && highWaterMemoryPct < storageEngine)
+	assert.NotEqual(t, -1, indexOf(lines, "write-block-size 128K", storageEngine))
+}
+
+func TestBuildConfigWithMemoryStorage(t *testing.T) {
+	aerospikeCluster := newAerospikeClusterForConfig(common.StorageTypeMemory)
+	aerospikeCluster.Spec.Namespaces[0].Storage.Size = ""
+	aerospikeCluster.Spec.Namespaces[0].AerospikeConfig = map[string]string{
+		"high-water-memory-pct": "70",
+	}
+	lines := configLines(buildConfig(aerospikeCluster))
+	assert.Contains(t, lines, "storage-engine memory")
+	assert.Contains(t, lines, "high-water-memory-pct 70")
+	assert.NotContains(t, lines, "storage-engine device {")
+}
+
+func TestComputeConfigMapHashChangesWithOverrides(t *testing.T) {
+	aerospikeCluster := newAerospikeClusterForConfig(common.StorageTypeFile)
+	before := computeConfigMapHash(aerospikeCluster, buildConfig(aerospikeCluster))
+	aerospikeCluster.Spec.Namespaces[0].AerospikeConfig = map[string]string{
+		"high-water-memory-pct": "70",
+	}
+	after := computeConfigMapHash(aerospikeCluster, buildConfig(aerospikeCluster))
+	assert.NotEqual(t, before, after)
+}
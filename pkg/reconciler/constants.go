@@ -36,10 +36,40 @@ const (
 	finalConfigMountPath = "/aerospike-conf"
 	// the name of the aerospike.conf file
 	configFileName = "aerospike.conf"
+	// the name of the volume that will contain the feature key file required
+	// by aerospike enterprise edition
+	featureKeyVolumeName = "feature-key"
+	// the mount path of the volume that will contain the feature key file
+	featureKeyMountPath = "/etc/aerospike/feature-key"
+	// the name of the feature key file
+	featureKeyFileName = "features.conf"
+	// the name of the volume that will contain the asprobe binary (copied
+	// from the tools image)
+	probeVolumeName = "asprobe"
+	// the mount path of the volume that will contain the asprobe binary
+	probeMountPath = "/opt/aerospike-operator"
+	// the name of the asprobe binary
+	probeFileName = "asprobe"
+	// the name of the file where asprobe stores the cluster key observed by
+	// the previous readiness probe
+	probeStateFileName = "cluster-key"
+	// the name of the volume that will contain the pod annotations exposed
+	// through the downward api
+	podInfoVolumeName = "podinfo"
+	// the mount path of the volume that will contain the pod annotations
+	// exposed through the downward api
+	podInfoMountPath = "/etc/podinfo"
+	// the name of the file that will contain the expected cluster size
+	expectedClusterSizeFileName = "expected-cluster-size"
+	// the name of the file that will contain the address at which the pod
+	// can be reached from outside the kubernetes cluster
+	externalAddressFileName = "external-address"
+
+	// the name of the container that runs aerospike
+	aerospikeServerContainerName = "aerospike-server"
 
 	namespaceVolumePrefix = "data-ns"
 
-	ServicePort       = 3000
 	servicePortName   = "service"
 	HeartbeatPort     = 3002
 	heartbeatPortName = "heartbeat"
@@ -48,21 +78,63 @@ const (
 	infoPort          = 3003
 	infoPortName      = "info"
 
-	watchCreatePodTimeout  = 3 * time.Hour
-	watchDeletePodTimeout  = 3 * time.Minute
+	// waitPodStartTimeout is how long we will wait for aerospike to start on
+	// a new pod before reporting a failure
+	waitPodStartTimeout    = 3 * time.Hour
 	terminationGracePeriod = 2 * time.Minute
 	waitMigrationsTimeout  = 1 * time.Hour
+	waitResizePVCTimeout   = 10 * time.Minute
+	// waitLoadBalancerTimeout is how long we will wait for the load balancer
+	// that exposes a pod to be provisioned
+	waitLoadBalancerTimeout = 5 * time.Minute
 	// waitClusterSizeTimeout is how long we will wait for a new pod to report
 	// the correct cluster size before forcibly deleting it
 	waitClusterSizeTimeout = 1 * time.Minute
 
-	podOperationFeedbackPeriod = 2 * time.Minute
-	aerospikeClientTimeout     = 10 * time.Second
+	// podOperationRequeuePeriod is how long we wait before checking again
+	// whether a pod has started, terminated or joined the cluster
+	podOperationRequeuePeriod = 10 * time.Second
+	// migrationsRequeuePeriod is how long we wait before checking again
+	// whether migrations have finished on a pod
+	migrationsRequeuePeriod = 30 * time.Second
+	// backupRequeuePeriod is how long we wait before checking again whether
+	// a pre-upgrade backup that must be restored has finished
+	backupRequeuePeriod = 1 * time.Minute
+	// restoreRequeuePeriod is how long we wait before checking again whether
+	// the restore of the pre-upgrade backups has finished
+	restoreRequeuePeriod = 1 * time.Minute
+	// backupDeletionRequeuePeriod is how long we wait before checking again
+	// whether the unfinished pre-upgrade backups have been deleted
+	backupDeletionRequeuePeriod = 5 * time.Second
+	aerospikeClientTimeout      = 10 * time.Second
+	// nodeStatisticsReportPeriod is how often changes to the statistics of
+	// the aerospike nodes (such as the number of partitions remaining to be
+	// migrated) are reported in the status of a cluster
+	nodeStatisticsReportPeriod = 30 * time.Second
 
 	// the name of the annotation that holds the hash of the mounted configmap
 	configMapHashAnnotation = "aerospike.travelaudience.com/config-map-hash"
+	// the name of the annotation that holds the hash of the mounted configmap
+	// computed without taking dynamic configuration properties into account
+	staticConfigHashAnnotation = "aerospike.travelaudience.com/static-config-hash"
+	// the name of the annotation that holds the values of the dynamic
+	// configuration properties in the mounted configmap
+	dynamicConfigAnnotation = "aerospike.travelaudience.com/dynamic-config"
 	// the name of the annotation that holds the aerospike node id
 	nodeIdAnnotation = "aerospike.travelaudience.com/node-id"
+	// the name of the annotation that holds the address of the kubernetes
+	// node in which a pod is running, at which the pod can be reached from
+	// outside the kubernetes cluster
+	externalAddressAnnotation = "aerospike.travelaudience.com/external-address"
+	// the name of the annotation that holds the cluster size the aerospike
+	// node is expected to observe in order to be considered ready
+	expectedClusterSizeAnnotation = "aerospike.travelaudience.com/expected-cluster-size"
+	// the name of the annotation that holds the timestamp at which we started
+	// waiting for migrations to finish on a pod
+	waitForMigrationsSinceAnnotation = "aerospike.travelaudience.com/wait-for-migrations-since"
+	// the name of the annotation that holds the timestamp at which we started
+	// waiting for the load balancer that exposes a pod to be provisioned
+	waitForLoadBalancerSinceAnnotation = "aerospike.travelaudience.com/wait-for-load-balancer-since"
 	// the name of the annotation that holds the name of the pod with which a
 	// PVC is associated
 	PodAnnotation = "aerospike.travelaudience.com/pod-name"
@@ -72,6 +144,15 @@ const (
 	// the name of the annotation that holds the timestamp at which a PVC
 	// was last unmounted from a pod
 	LastUnmountedOnAnnotation = "aerospike.travelaudience.com/last-unmounted-on"
+	// the name of the annotation that holds the index of a PVC among the
+	// PVCs used by a pod to store data for a given namespace
+	VolumeIndexAnnotation = "aerospike.travelaudience.com/volume-index"
+	// the name of the annotation that holds the timestamp at which a PVC was
+	// marked for replacement as part of a storage update
+	ReplacedOnAnnotation = "aerospike.travelaudience.com/replaced-on"
+	// the name of the annotation that holds the timestamp at which the
+	// expansion of a PVC was requested as part of a storage update
+	ExpansionRequestedOnAnnotation = "aerospike.travelaudience.com/expansion-requested-on"
 
 	// the name of the key that corresponds to the service.node-id property
 	// (used for templating)
@@ -82,19 +163,68 @@ const (
 	clusterNamespacesKey        = "namespaces"
 	heartbeatAddressesConfigKey = "heartbeatAddresses"
 	HeartbeatAddressesValue     = "__NETWORK__HEARTBEAT__MESH_SEED_ADDRESS_PORT__"
+	serviceConfigKey            = "serviceConfig"
+	loggingDefaultLevelKey      = "loggingDefaultLevel"
+	loggingConfigKey            = "loggingConfig"
+	heartbeatConfigKey          = "heartbeatConfig"
+	fabricConfigKey             = "fabricConfig"
+	// the name of the key that corresponds to the service.feature-key-file
+	// property (used for templating)
+	featureKeyFileKey = "featureKeyFile"
+	// the values of the keys that correspond to the
+	// network.service.alternate-access-address and
+	// network.service.alternate-access-port properties (used for templating)
+	alternateAccessKey                 = "alternateAccess"
+	alternateAccessAddressKey          = "alternateAccessAddress"
+	ServiceAlternateAccessAddressValue = "__NETWORK__SERVICE__ALTERNATE_ACCESS_ADDRESS__"
+	alternateAccessPortKey             = "alternateAccessPort"
+	ServiceAlternateAccessPortValue    = "__NETWORK__SERVICE__ALTERNATE_ACCESS_PORT__"
+	// the value of the key that corresponds to the namespace.rack-id property
+	// (used for templating)
+	NamespaceRackIdValue = "__NAMESPACE__RACK_ID__"
+
+	// the logging context that sets the logging level for every context
+	loggingContextAny = "any"
+	// the default logging level for every context
+	defaultLoggingLevel = "info"
+
+	// the set-config contexts in which dynamic properties are set
+	dynamicConfigContextService = "service"
+	dynamicConfigContextNetwork = "network"
+	// the prefix of heartbeat properties in the network context
+	heartbeatPropertyPrefix = "heartbeat."
+	// the names of the namespace properties which are set from dedicated
+	// fields in the spec
+	memorySizeKey = "memory-size"
+	defaultTTLKey = "default-ttl"
+
+	// the suffix appended to the name of a pod in order to obtain the name of
+	// the service that exposes it outside the kubernetes cluster
+	externalServiceSuffix = "external"
+
+	// the label that holds the zone of a kubernetes node
+	zoneLabel = "failure-domain.beta.kubernetes.io/zone"
 
 	defaultFilePath         = "/opt/aerospike/data/"
 	defaultDevicePathPrefix = "/dev/xvd"
 
+	// the maximum number of namespaces in a cluster and of volumes per
+	// namespace (used to compute distinct device paths for every volume)
+	maxNamespaces          = 32
+	maxVolumesPerNamespace = 16
+
 	nsNameKey              = "name"
 	nsReplicationFactorKey = "replicationFactor"
 	nsMemorySizeKey        = "memorySize"
 	nsDefaultTTLKey        = "defaultTTL"
 	nsStorageTypeKey       = "storageType"
 	nsStorageSizeKey       = "storageSize"
-	nsFilePath             = "filePath"
-	nsDevicePath           = "devicePath"
+	nsFilePaths            = "filePaths"
+	nsDevicePaths          = "devicePaths"
 	nsDataInMemory         = "dataInMemory"
+	nsConfig               = "config"
+	nsStorageEngineConfig  = "storageEngineConfig"
+	nsRackId               = "rackId"
 
 	aspromPortName      = "prometheus"
 	aspromPort          = 9145
@@ -102,10 +232,15 @@ const (
 	aspromMemoryRequest = "32Mi"
 
 	asReadinessInitialDelaySeconds = 3
-	asReadinessTimeoutSeconds      = 2
+	asReadinessTimeoutSeconds      = 5
 	asReadinessPeriodSeconds       = 10
 	asReadinessFailureThreshold    = 3
 
+	asLivenessInitialDelaySeconds = 30
+	asLivenessTimeoutSeconds      = 5
+	asLivenessPeriodSeconds       = 10
+	asLivenessFailureThreshold    = 6
+
 	// the cpu request for the init container
 	initContainerCpuRequest = "10m"
 	// the memory request for the init container
@@ -117,18 +252,37 @@ const (
 	// https://www.aerospike.com/docs/reference/configuration#memory-size
 	aerospikeServerContainerDefaultMemoryRequestGi = 4
 
-	// UpgradeStatusAnnotationKey is the name of the annotation added to
-	// AerospikeCluster resources that are being upgraded.
-	UpgradeStatusAnnotationKey = "aerospike.travelaudience.com/upgrade-status"
-	// UpgradeStatusStartedAnnotationValue is the value of the annotation added
-	// to AerospikeCluster resources that are being upgrade.
-	UpgradeStatusStartedAnnotationValue = "started"
-	// UpgradeStatusFailedAnnotationValue is the value of the annotation added
-	// to AerospikeCluster resources that have not been successfully upgraded.
-	UpgradeStatusFailedAnnotationValue = "failed"
-	// UpgradeStatusBackupAnnotationValue is the value of the annotation added
-	// to AerospikeCluster resources that are undergoing a pre-upgrade backup.
-	UpgradeStatusBackupAnnotationValue = "backup"
+	// NamespaceUpdateStatusAnnotationKey is the name of the annotation added
+	// to AerospikeCluster resources whose existing namespaces are being
+	// updated.
+	NamespaceUpdateStatusAnnotationKey = "aerospike.travelaudience.com/namespace-update-status"
+	// NamespaceUpdateStatusStartedAnnotationValue is the value of the
+	// annotation added to AerospikeCluster resources whose existing namespaces
+	// are being updated.
+	NamespaceUpdateStatusStartedAnnotationValue = "started"
+
+	// RestartStatusAnnotationKey is the name of the annotation added to
+	// AerospikeCluster resources that are undergoing a rolling restart.
+	RestartStatusAnnotationKey = "aerospike.travelaudience.com/restart-status"
+	// RestartStatusStartedAnnotationValue is the value of the annotation
+	// added to AerospikeCluster resources that are undergoing a rolling
+	// restart.
+	RestartStatusStartedAnnotationValue = "started"
+
+	// PodOperationAnnotationKey is the name of the annotation added to
+	// AerospikeCluster resources in which a pod is being deleted or
+	// (re-)created. It holds the operation being performed, so that it can
+	// be resumed in subsequent reconcile loops.
+	PodOperationAnnotationKey = "aerospike.travelaudience.com/pod-operation"
+
+	// the types of operation that may be performed on a pod
+	podOperationCreate        = "create"
+	podOperationRecreate      = "recreate"
+	podOperationRestart       = "restart"
+	podOperationUpgrade       = "upgrade"
+	podOperationStorageUpdate = "storage-update"
+	podOperationMaintenance   = "maintenance"
+	podOperationScaleDown     = "scale-down"
 
 	// terminal state reasons when pod status is Pending
 	// container image pull failed
@@ -148,6 +302,24 @@ const (
 	defaultMemorySize = "4G"
 )
 
+var (
+	// defaultServiceConfig holds the default values for the properties in the
+	// service stanza that can be overridden by the user
+	defaultServiceConfig = map[string]string{
+		"paxos-single-replica-limit":    "1",
+		"service-threads":               "4",
+		"transaction-queues":            "4",
+		"transaction-threads-per-queue": "4",
+		"proto-fd-max":                  "15000",
+	}
+	// defaultHeartbeatConfig holds the default values for the properties in
+	// the network.heartbeat stanza that can be overridden by the user
+	defaultHeartbeatConfig = map[string]string{
+		"interval": "100",
+		"timeout":  "10",
+	}
+)
+
 var asConfigTemplate = template.Must(template.New("aerospike-config").Parse(aerospikeConfig))
 var asNamespaceTemplate = template.Must(template.New("as-namespace-config").Parse(aerospikeNamespaceConfig))
 
@@ -155,22 +327,29 @@ const aerospikeConfig = `
 service {
 	user root
 	group root
-	paxos-single-replica-limit 1
 	pidfile /var/run/aerospike/asd.pid
-	service-threads 4
-	transaction-queues 4
-	transaction-threads-per-queue 4
-	proto-fd-max 15000
 	node-id {{.nodeId}}
+	{{- if .featureKeyFile}}
+	feature-key-file {{.featureKeyFile}}
+	{{- end}}
+	{{- range $key, $value := .serviceConfig}}
+	{{$key}} {{$value}}
+	{{- end}}
 }
 
 logging {
 	file /var/log/aerospike/aerospike.log {
-		context any info
+		context any {{.loggingDefaultLevel}}
+		{{- range $context, $level := .loggingConfig}}
+		context {{$context}} {{$level}}
+		{{- end}}
 	}
 
 	console {
-		context any info 
+		context any {{.loggingDefaultLevel}}
+		{{- range $context, $level := .loggingConfig}}
+		context {{$context}} {{$level}}
+		{{- end}}
 	}
 }
 
@@ -178,6 +357,10 @@ network {
 	service {
 		address any
 		port 3000
+		{{- if .alternateAccess}}
+		alternate-access-address {{.alternateAccessAddress}}
+		alternate-access-port {{.alternateAccessPort}}
+		{{- end}}
 	}
 
 	heartbeat {
@@ -185,13 +368,16 @@ network {
 		port 3002
 
 		{{.heartbeatAddresses}}
-
-		interval 100
-		timeout 10
+		{{- range $key, $value := .heartbeatConfig}}
+		{{$key}} {{$value}}
+		{{- end}}
 	}
 
 	fabric {
 		port 3001
+		{{- range $key, $value := .fabricConfig}}
+		{{$key}} {{$value}}
+		{{- end}}
 	}
 
 	info {
@@ -219,12 +405,27 @@ namespace {{.name}} {
 		default-ttl {{.defaultTTL}}
 	{{end}}
 
+	{{if .rackId}}
+		rack-id {{.rackId}}
+	{{end}}
+
+	{{- range $key, $value := .config}}
+	{{$key}} {{$value}}
+	{{- end}}
+
+	{{if eq .storageType "memory"}}
+	storage-engine memory
+	{{else}}
 	storage-engine device {
 
 		{{if eq .storageType "file"}}
-			file {{.filePath}}{{.name}}/{{.name}}.dat
+			{{- range .filePaths}}
+			file {{.}}
+			{{- end}}
 		{{else if eq .storageType "device"}}
-			device {{.devicePath}}
+			{{- range .devicePaths}}
+			device {{.}}
+			{{- end}}
 		{{end}}
 
 		{{if .storageSize}}
@@ -234,5 +435,10 @@ namespace {{.name}} {
 		{{- if .dataInMemory}}
 			data-in-memory {{.dataInMemory}}
 		{{- end}}
+
+		{{- range $key, $value := .storageEngineConfig}}
+		{{$key}} {{$value}}
+		{{- end}}
 	}
+	{{end}}
 }`
@@ -0,0 +1,301 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/api/core/v1"
+
+	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
+	"github.com/travelaudience/aerospike-operator/pkg/asutils"
+	"github.com/travelaudience/aerospike-operator/pkg/logfields"
+	"github.com/travelaudience/aerospike-operator/pkg/meta"
+	"github.com/travelaudience/aerospike-operator/pkg/pointers"
+	"github.com/travelaudience/aerospike-operator/pkg/utils/events"
+)
+
+// dynamicConfig holds the values of the configuration properties that can be
+// changed on a running aerospike node. values are indexed by the context to
+// which they belong (as understood by the set-config info command) and by
+// the name of the property.
+type dynamicConfig map[string]map[string]string
+
+// set sets the value of the specified property in the specified context.
+func (c dynamicConfig) set(context, key, value string) {
+	if c[context] == nil {
+		c[context] = make(map[string]string)
+	}
+	c[context][key] = value
+}
+
+// buildDynamicConfig returns the values of the dynamic configuration
+// properties in the aerospike config generated for aerospikeCluster.
+func buildDynamicConfig(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) dynamicConfig {
+	res := make(dynamicConfig)
+
+	var serviceConfig, heartbeatConfig map[string]string
+	if config := aerospikeCluster.Spec.AerospikeConfig; config != nil {
+		serviceConfig = config.Service
+		if config.Network != nil {
+			heartbeatConfig = config.Network.Heartbeat
+		}
+	}
+	for key, value := range mergeConfig(defaultServiceConfig, serviceConfig) {
+		if asutils.DynamicServiceConfigKeys.Has(key) {
+			res.set(dynamicConfigContextService, key, value)
+		}
+	}
+	// heartbeat properties are set in the network context
+	for key, value := range mergeConfig(defaultHeartbeatConfig, heartbeatConfig) {
+		if asutils.DynamicHeartbeatConfigKeys.Has(key) {
+			res.set(dynamicConfigContextNetwork, heartbeatPropertyPrefix+key, value)
+		}
+	}
+
+	for _, namespace := range aerospikeCluster.Spec.Namespaces {
+		context := namespaceConfigContext(namespace.Name)
+		if isMemorySizeDynamic(aerospikeCluster) {
+			res.set(context, memorySizeKey, getNamespaceMemorySize(&namespace))
+		}
+		if ttl, ok := getNamespaceDefaultTTL(&namespace); ok {
+			res.set(context, defaultTTLKey, strconv.Itoa(ttl))
+		}
+		for key, value := range namespace.AerospikeConfig {
+			if asutils.DynamicNamespaceConfigKeys.Has(key) {
+				res.set(context, key, value)
+			}
+		}
+	}
+
+	return res
+}
+
+// withoutDynamicConfig returns a copy of aerospikeCluster from which every
+// dynamic configuration property has been removed. it is used to compute the
+// hash of the static part of the aerospike config.
+func withoutDynamicConfig(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) *aerospikev1alpha2.AerospikeCluster {
+	res := aerospikeCluster.DeepCopy()
+	if config := res.Spec.AerospikeConfig; config != nil {
+		deleteConfigKeys(config.Service, asutils.DynamicServiceConfigKeys)
+		if config.Network != nil {
+			deleteConfigKeys(config.Network.Heartbeat, asutils.DynamicHeartbeatConfigKeys)
+		}
+	}
+	for i := range res.Spec.Namespaces {
+		if isMemorySizeDynamic(aerospikeCluster) {
+			res.Spec.Namespaces[i].MemorySize = nil
+		}
+		res.Spec.Namespaces[i].DefaultTTL = nil
+		deleteConfigKeys(res.Spec.Namespaces[i].AerospikeConfig, asutils.DynamicNamespaceConfigKeys)
+	}
+	return res
+}
+
+// isMemorySizeDynamic indicates whether the memory size of the namespaces can
+// be changed without restarting pods. this is only the case when the memory
+// of the aerospike-server container is set explicitly in spec.resources, as
+// its memory request is otherwise computed from the memory size of each
+// namespace and cannot be changed on a running pod.
+func isMemorySizeDynamic(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) bool {
+	if aerospikeCluster.Spec.Resources == nil || aerospikeCluster.Spec.Resources.AerospikeServer == nil {
+		return false
+	}
+	_, request := aerospikeCluster.Spec.Resources.AerospikeServer.Requests[v1.ResourceMemory]
+	_, limit := aerospikeCluster.Spec.Resources.AerospikeServer.Limits[v1.ResourceMemory]
+	return request || limit
+}
+
+// deleteConfigKeys removes every property in keys from config.
+func deleteConfigKeys(config map[string]string, keys asutils.KeySet) {
+	for key := range config {
+		if keys.Has(key) {
+			delete(config, key)
+		}
+	}
+}
+
+// namespaceConfigContext returns the set-config context that corresponds to
+// the specified namespace.
+func namespaceConfigContext(name string) string {
+	return fmt.Sprintf("namespace;id=%s", name)
+}
+
+// marshalDynamicConfig returns the json representation of config, which is
+// stored in the configmap and in every pod that mounts it.
+func marshalDynamicConfig(config dynamicConfig) string {
+	res, err := json.Marshal(config)
+	if err != nil {
+		return ""
+	}
+	return string(res)
+}
+
+// unmarshalDynamicConfig parses the value of the dynamic config annotation.
+func unmarshalDynamicConfig(value string) (dynamicConfig, error) {
+	if value == "" {
+		return nil, fmt.Errorf("no dynamic config present")
+	}
+	var res dynamicConfig
+	if err := json.Unmarshal([]byte(value), &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// parseMemorySize returns the number of bytes represented by value, which
+// must either be a number of gigabytes (e.g. 4G, as used in the spec) or a
+// number of bytes (as reported by aerospike).
+func parseMemorySize(value string) (int64, error) {
+	if strings.HasSuffix(value, "G") {
+		res, err := strconv.ParseInt(strings.TrimSuffix(value, "G"), 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		return res * 1024 * 1024 * 1024, nil
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
+
+// normalizeConfigValue returns value in the format used by aerospike to
+// report the value of the specified property.
+func normalizeConfigValue(key, value string) string {
+	if key == memorySizeKey {
+		if res, err := parseMemorySize(value); err == nil {
+			return strconv.FormatInt(res, 10)
+		}
+	}
+	return value
+}
+
+// canUpdateConfigDynamically indicates whether the aerospike config used by
+// pod can be brought up-to-date with configMap without restarting it. this
+// is the case when only dynamic properties have changed, none of them has
+// been removed (as we cannot tell which value aerospike would use by default)
+// and memory-size has not been decreased in any namespace.
+func canUpdateConfigDynamically(configMap *v1.ConfigMap, pod *v1.Pod) bool {
+	if pod.Annotations[staticConfigHashAnnotation] == "" || pod.Annotations[staticConfigHashAnnotation] != configMap.Annotations[staticConfigHashAnnotation] {
+		return false
+	}
+	current, err := unmarshalDynamicConfig(pod.Annotations[dynamicConfigAnnotation])
+	if err != nil {
+		return false
+	}
+	desired, err := unmarshalDynamicConfig(configMap.Annotations[dynamicConfigAnnotation])
+	if err != nil {
+		return false
+	}
+	for context, props := range current {
+		for key, currentValue := range props {
+			desiredValue, ok := desired[context][key]
+			if !ok {
+				return false
+			}
+			if key == memorySizeKey {
+				currentSize, err := parseMemorySize(currentValue)
+				if err != nil {
+					return false
+				}
+				desiredSize, err := parseMemorySize(desiredValue)
+				if err != nil || desiredSize < currentSize {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// maybeUpdatePodConfig applies the dynamic configuration changes in configMap
+// to pod using set-config info commands, checks that aerospike reports the
+// new values and updates the pod's annotations accordingly. it returns false
+// if the changes cannot be applied dynamically, in which case the pod must be
+// restarted.
+func (r *AerospikeClusterReconciler) maybeUpdatePodConfig(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, configMap *v1.ConfigMap, pod *v1.Pod) bool {
+	// never interfere with an operation that is already in progress
+	if getPodOperationWithIndex(aerospikeCluster, podIndex(pod)) != nil || !canUpdateConfigDynamically(configMap, pod) {
+		return false
+	}
+	if err := r.updatePodConfig(configMap, pod); err != nil {
+		r.recorder.Eventf(aerospikeCluster, v1.EventTypeWarning, events.ReasonNodeConfigUpdateFailed,
+			"failed to apply dynamic configuration changes to pod %s, restarting it", meta.Key(pod))
+		log.WithFields(log.Fields{
+			logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+			logfields.Pod:              meta.Key(pod),
+		}).Warnf("failed to apply dynamic configuration changes, restarting pod: %v", err)
+		return false
+	}
+	r.recorder.Eventf(aerospikeCluster, v1.EventTypeNormal, events.ReasonNodeConfigUpdated,
+		"dynamic configuration changes applied to pod %s", meta.Key(pod))
+	log.WithFields(log.Fields{
+		logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+		logfields.Pod:              meta.Key(pod),
+	}).Debug("dynamic configuration changes applied")
+	return true
+}
+
+// updatePodConfig sets the values of the dynamic properties that differ
+// between configMap and the config currently used by pod. since the
+// aerospike-server container is never restarted in place (pods are created
+// with a restart policy of "Never"), these values are kept for the lifetime
+// of the pod.
+func (r *AerospikeClusterReconciler) updatePodConfig(configMap *v1.ConfigMap, pod *v1.Pod) error {
+	current, err := unmarshalDynamicConfig(pod.Annotations[dynamicConfigAnnotation])
+	if err != nil {
+		return err
+	}
+	desired, err := unmarshalDynamicConfig(configMap.Annotations[dynamicConfigAnnotation])
+	if err != nil {
+		return err
+	}
+
+	// set the value of every property that has changed
+	changed := make(dynamicConfig)
+	for context, props := range desired {
+		for key, value := range props {
+			if current[context][key] != value {
+				if err := asutils.SetConfig(pod.Status.PodIP, asutils.ServicePort, context, key, value); err != nil {
+					return err
+				}
+				changed.set(context, key, value)
+			}
+		}
+	}
+	// check that aerospike reports the new values
+	for context, props := range changed {
+		actual, err := asutils.GetConfig(pod.Status.PodIP, asutils.ServicePort, context)
+		if err != nil {
+			return err
+		}
+		for key, value := range props {
+			if normalizeConfigValue(key, actual[key]) != normalizeConfigValue(key, value) {
+				return fmt.Errorf("%s is %q in context %s (expected %q)", key, actual[key], context, value)
+			}
+		}
+	}
+
+	// record the fact that the pod is now using the current configuration
+	return r.patchPodAnnotations(pod, map[string]*string{
+		configMapHashAnnotation:    pointers.NewString(configMap.Annotations[configMapHashAnnotation]),
+		staticConfigHashAnnotation: pointers.NewString(configMap.Annotations[staticConfigHashAnnotation]),
+		dynamicConfigAnnotation:    pointers.NewString(configMap.Annotations[dynamicConfigAnnotation]),
+	})
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/common"
+	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
+)
+
+// withConfigAnnotations returns the object metadata holding the specified
+// static config hash and dynamic config.
+func withConfigAnnotations(staticHash string, config dynamicConfig) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Annotations: map[string]string{
+			staticConfigHashAnnotation: staticHash,
+			dynamicConfigAnnotation:    marshalDynamicConfig(config),
+		},
+	}
+}
+
+func TestParseMemorySize(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected int64
+		err      bool
+	}{
+		{"1G", 1073741824, false},
+		{"4G", 4294967296, false},
+		{"2147483648", 2147483648, false},
+		{"0", 0, false},
+		{"G", 0, true},
+		{"1.5G", 0, true},
+		{"4Gi", 0, true},
+		{"", 0, true},
+	}
+	for _, test := range tests {
+		res, err := parseMemorySize(test.value)
+		if test.err {
+			assert.Error(t, err, test.value)
+			continue
+		}
+		assert.NoError(t, err, test.value)
+		assert.Equal(t, test.expected, res, test.value)
+	}
+}
+
+func TestCanUpdateConfigDynamically(t *testing.T) {
+	current := dynamicConfig{
+		"namespace;id=ns0": {
+			memorySizeKey: "2147483648",
+			defaultTTLKey: "0",
+		},
+	}
+	tests := []struct {
+		name     string
+		pod      metav1.ObjectMeta
+		desired  metav1.ObjectMeta
+		expected bool
+	}{
+		{
+			name: "changed dynamic property",
+			pod:  withConfigAnnotations("hash", current),
+			desired: withConfigAnnotations("hash", dynamicConfig{
+				"namespace;id=ns0": {memorySizeKey: "2G", defaultTTLKey: "3600"},
+			}),
+			expected: true,
+		},
+		{
+			name: "added dynamic property",
+			pod:  withConfigAnnotations("hash", current),
+			desired: withConfigAnnotations("hash", dynamicConfig{
+				"namespace;id=ns0": {memorySizeKey: "2G", defaultTTLKey: "0", "high-water-memory-pct": "70"},
+			}),
+			expected: true,
+		},
+		{
+			name: "increased memory size",
+			pod:  withConfigAnnotations("hash", current),
+			desired: withConfigAnnotations("hash", dynamicConfig{
+				"namespace;id=ns0": {memorySizeKey: "4G", defaultTTLKey: "0"},
+			}),
+			expected: true,
+		},
+		{
+			name: "decreased memory size",
+			pod:  withConfigAnnotations("hash", current),
+			desired: withConfigAnnotations("hash", dynamicConfig{
+				"namespace;id=ns0": {memorySizeKey: "1G", defaultTTLKey: "0"},
+			}),
+			expected: false,
+		},
+		{
+			name: "removed dynamic property",
+			pod:  withConfigAnnotations("hash", current),
+			desired: withConfigAnnotations("hash", dynamicConfig{
+				"namespace;id=ns0": {memorySizeKey: "2G"},
+			}),
+			expected: false,
+		},
+		{
+			name:     "changed static config",
+			pod:      withConfigAnnotations("hash", current),
+			desired:  withConfigAnnotations("other-hash", current),
+			expected: false,
+		},
+		{
+			name:     "missing static config hash",
+			pod:      withConfigAnnotations("", current),
+			desired:  withConfigAnnotations("", current),
+			expected: false,
+		},
+		{
+			name: "invalid dynamic config",
+			pod: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					staticConfigHashAnnotation: "hash",
+					dynamicConfigAnnotation:    "{",
+				},
+			},
+			desired:  withConfigAnnotations("hash", current),
+			expected: false,
+		},
+	}
+	for _, test := range tests {
+		res := canUpdateConfigDynamically(&v1.ConfigMap{ObjectMeta: test.desired}, &v1.Pod{ObjectMeta: test.pod})
+		assert.Equal(t, test.expected, res, test.name)
+	}
+}
+
+func TestMemorySizeIsStaticWithoutExplicitMemoryResources(t *testing.T) {
+	aerospikeCluster := newAerospikeClusterForConfig(common.StorageTypeFile)
+	assert.False(t, isMemorySizeDynamic(aerospikeCluster))
+	assert.NotContains(t, buildDynamicConfig(aerospikeCluster)["namespace;id=ns0"], memorySizeKey)
+	assert.Equal(t, "2G", *withoutDynamicConfig(aerospikeCluster).Spec.Namespaces[0].MemorySize)
+
+	// cpu resources alone do not prevent the memory request from being
+	// computed from the memory size
+	aerospikeCluster.Spec.Resources = &aerospikev1alpha2.AerospikeClusterResourcesSpec{
+		AerospikeServer: &v1.ResourceRequirements{
+			Requests: v1.ResourceList{
+				v1.ResourceCPU: resource.MustParse("1"),
+			},
+		},
+	}
+	assert.False(t, isMemorySizeDynamic(aerospikeCluster))
+}
+
+func TestMemorySizeIsDynamicWithExplicitMemoryResources(t *testing.T) {
+	for _, resources := range []v1.ResourceRequirements{
+		{Requests: v1.ResourceList{v1.ResourceMemory: resource.MustParse("4Gi")}},
+		{Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("4Gi")}},
+	} {
+		aerospikeCluster := newAerospikeClusterForConfig(common.StorageTypeFile)
+		aerospikeCluster.Spec.Resources = &aerospikev1alpha2.AerospikeClusterResourcesSpec{
+			AerospikeServer: resources.DeepCopy(),
+		}
+		assert.True(t, isMemorySizeDynamic(aerospikeCluster))
+		assert.Equal(t, "2G", buildDynamicConfig(aerospikeCluster)["namespace;id=ns0"][memorySizeKey])
+		assert.Nil(t, withoutDynamicConfig(aerospikeCluster).Spec.Namespaces[0].MemorySize)
+	}
+}
@@ -0,0 +1,346 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/common"
+	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
+	"github.com/travelaudience/aerospike-operator/pkg/asutils"
+	"github.com/travelaudience/aerospike-operator/pkg/crd"
+	aserrors "github.com/travelaudience/aerospike-operator/pkg/errors"
+	"github.com/travelaudience/aerospike-operator/pkg/logfields"
+	"github.com/travelaudience/aerospike-operator/pkg/meta"
+	"github.com/travelaudience/aerospike-operator/pkg/pointers"
+	"github.com/travelaudience/aerospike-operator/pkg/utils/selectors"
+)
+
+// applyExternalAccess configures pod so that it can be accessed from outside
+// the kubernetes cluster, and tells asinit which address and port to use as
+// the value of alternate-access-address and alternate-access-port.
+func (r *AerospikeClusterReconciler) applyExternalAccess(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, pod *v1.Pod) error {
+	externalAccess := aerospikeCluster.Spec.ExternalAccess
+	if externalAccess == nil {
+		return nil
+	}
+
+	var address string
+	port := int32(asutils.ServicePort)
+
+	switch externalAccess.Type {
+	case common.ExternalAccessTypeHostNetwork:
+		pod.Spec.HostNetwork = true
+		pod.Spec.DNSPolicy = v1.DNSClusterFirstWithHostNet
+	case common.ExternalAccessTypeNodePort, common.ExternalAccessTypeLoadBalancer:
+		// label the pod so that it can be targeted by its own service
+		pod.Labels[selectors.LabelPodKey] = pod.Name
+		service, err := r.ensureExternalService(aerospikeCluster, pod.Name)
+		if err != nil {
+			return err
+		}
+		if externalAccess.Type == common.ExternalAccessTypeNodePort {
+			port = service.Spec.Ports[0].NodePort
+			break
+		}
+		if err := r.waitForLoadBalancer(aerospikeCluster, service); err != nil {
+			return err
+		}
+		ingress := service.Status.LoadBalancer.Ingress[0]
+		if ingress.IP != "" {
+			address = ingress.IP
+		} else {
+			address = ingress.Hostname
+		}
+	default:
+		// should not happen, as the type is validated as an enum
+		return fmt.Errorf("unsupported external access type %s", externalAccess.Type)
+	}
+
+	if address != "" {
+		pod.Spec.InitContainers[0].Command = append(pod.Spec.InitContainers[0].Command, "--alternate-access-address", address)
+	} else {
+		// the address of the kubernetes node in which the pod is running is
+		// only known after the pod is scheduled, so ensureExternalAddress
+		// sets it in an annotation that asinit reads through the downward api
+		for i := range pod.Spec.Volumes {
+			if downwardAPI := pod.Spec.Volumes[i].DownwardAPI; pod.Spec.Volumes[i].Name == podInfoVolumeName && downwardAPI != nil {
+				downwardAPI.Items = append(downwardAPI.Items, v1.DownwardAPIVolumeFile{
+					Path: externalAddressFileName,
+					FieldRef: &v1.ObjectFieldSelector{
+						FieldPath: fmt.Sprintf("metadata.annotations['%s']", externalAddressAnnotation),
+					},
+				})
+			}
+		}
+		pod.Spec.InitContainers[0].VolumeMounts = append(pod.Spec.InitContainers[0].VolumeMounts, v1.VolumeMount{
+			Name:      podInfoVolumeName,
+			MountPath: podInfoMountPath,
+		})
+		pod.Spec.InitContainers[0].Command = append(pod.Spec.InitContainers[0].Command,
+			"--alternate-access-address-file", path.Join(podInfoMountPath, externalAddressFileName))
+	}
+	pod.Spec.InitContainers[0].Command = append(pod.Spec.InitContainers[0].Command,
+		"--alternate-access-port", strconv.Itoa(int(port)))
+	return nil
+}
+
+// ensureExternalAddress records the address of the kubernetes node in which
+// the specified pod has been scheduled in an annotation of the pod, so that
+// asinit can use it as the value of alternate-access-address. the external
+// ip of the node is preferred over its internal ip, which is the one
+// reported in status.hostIP.
+func (r *AerospikeClusterReconciler) ensureExternalAddress(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, pod *v1.Pod) error {
+	externalAccess := aerospikeCluster.Spec.ExternalAccess
+	if externalAccess == nil || externalAccess.Type == common.ExternalAccessTypeLoadBalancer {
+		return nil
+	}
+	// there is nothing to do if the pod hasn't been scheduled yet or if the
+	// address has already been recorded
+	if _, ok := pod.Annotations[externalAddressAnnotation]; ok || pod.Spec.NodeName == "" {
+		return nil
+	}
+	node, err := r.nodesLister.Get(pod.Spec.NodeName)
+	if err != nil {
+		return err
+	}
+	address := getNodeAddress(node)
+	if address == "" {
+		return fmt.Errorf("node %s has no external or internal ip", node.Name)
+	}
+	if err := r.patchPodAnnotation(pod, externalAddressAnnotation, &address); err != nil {
+		return err
+	}
+	log.WithFields(log.Fields{
+		logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+		logfields.Pod:              meta.Key(pod),
+	}).Debugf("using address %s of node %s as the external address", address, node.Name)
+	return nil
+}
+
+// getNodeAddress returns the external ip of the specified kubernetes node,
+// or its internal ip if it has no external ip.
+func getNodeAddress(node *v1.Node) string {
+	for _, addressType := range []v1.NodeAddressType{v1.NodeExternalIP, v1.NodeInternalIP} {
+		for _, address := range node.Status.Addresses {
+			if address.Type == addressType {
+				return address.Address
+			}
+		}
+	}
+	return ""
+}
+
+// ensureExternalService creates (or updates) the service that exposes the
+// pod with the specified name outside the kubernetes cluster.
+func (r *AerospikeClusterReconciler) ensureExternalService(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, podName string) (*v1.Service, error) {
+	desired := buildExternalService(aerospikeCluster, podName)
+
+	current, err := r.servicesLister.Services(aerospikeCluster.Namespace).Get(desired.Name)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, err
+		}
+		res, err := r.kubeclientset.CoreV1().Services(aerospikeCluster.Namespace).Create(desired)
+		if err != nil {
+			return nil, err
+		}
+		log.WithFields(log.Fields{
+			logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+			logfields.Service:          res.Name,
+		}).Debug("service created")
+		return res, nil
+	}
+
+	// the service exists, so we make sure its type and annotations match the
+	// desired ones while preserving any allocated node port. the time at
+	// which we started waiting for its load balancer is kept unless its type
+	// changes.
+	if since, ok := current.Annotations[waitForLoadBalancerSinceAnnotation]; ok && current.Spec.Type == desired.Spec.Type {
+		if desired.Annotations == nil {
+			desired.Annotations = make(map[string]string)
+		}
+		desired.Annotations[waitForLoadBalancerSinceAnnotation] = since
+	}
+	if current.Spec.Type == desired.Spec.Type && reflect.DeepEqual(current.Annotations, desired.Annotations) {
+		return current, nil
+	}
+	updated := current.DeepCopy()
+	updated.Annotations = desired.Annotations
+	updated.Spec.Type = desired.Spec.Type
+	res, err := r.kubeclientset.CoreV1().Services(aerospikeCluster.Namespace).Update(updated)
+	if err != nil {
+		return nil, err
+	}
+	log.WithFields(log.Fields{
+		logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+		logfields.Service:          res.Name,
+	}).Debug("service updated")
+	return res, nil
+}
+
+// buildExternalService returns the service that exposes the pod with the
+// specified name outside the kubernetes cluster.
+func buildExternalService(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, podName string) *v1.Service {
+	var annotations map[string]string
+	if len(aerospikeCluster.Spec.ExternalAccess.ServiceAnnotations) > 0 {
+		annotations = make(map[string]string, len(aerospikeCluster.Spec.ExternalAccess.ServiceAnnotations))
+		for key, value := range aerospikeCluster.Spec.ExternalAccess.ServiceAnnotations {
+			annotations[key] = value
+		}
+	}
+	return &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: getExternalServiceName(podName),
+			Labels: map[string]string{
+				selectors.LabelAppKey:     selectors.LabelAppVal,
+				selectors.LabelClusterKey: aerospikeCluster.Name,
+				selectors.LabelPodKey:     podName,
+			},
+			Annotations: annotations,
+			Namespace:   aerospikeCluster.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion:         aerospikev1alpha2.SchemeGroupVersion.String(),
+					Kind:               crd.AerospikeClusterKind,
+					Name:               aerospikeCluster.Name,
+					UID:                aerospikeCluster.UID,
+					Controller:         pointers.NewBool(true),
+					BlockOwnerDeletion: pointers.NewBool(true),
+				},
+			},
+		},
+		Spec: v1.ServiceSpec{
+			Type: v1.ServiceType(aerospikeCluster.Spec.ExternalAccess.Type),
+			Selector: map[string]string{
+				selectors.LabelAppKey:     selectors.LabelAppVal,
+				selectors.LabelClusterKey: aerospikeCluster.Name,
+				selectors.LabelPodKey:     podName,
+			},
+			Ports: []v1.ServicePort{
+				{
+					Name:       servicePortName,
+					Port:       asutils.ServicePort,
+					TargetPort: intstr.IntOrString{StrVal: servicePortName},
+				},
+			},
+			// make sure that traffic reaching a given kubernetes node is not
+			// forwarded to other nodes
+			ExternalTrafficPolicy: v1.ServiceExternalTrafficPolicyTypeLocal,
+		},
+	}
+}
+
+// waitForLoadBalancer checks whether the load balancer associated with the
+// specified service has been provisioned, requesting the cluster to be
+// requeued otherwise. the time at which we started waiting is recorded in an
+// annotation of the service so that the timeout survives operator restarts.
+func (r *AerospikeClusterReconciler) waitForLoadBalancer(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, service *v1.Service) error {
+	since, waiting := service.Annotations[waitForLoadBalancerSinceAnnotation]
+
+	if len(service.Status.LoadBalancer.Ingress) > 0 {
+		if waiting {
+			return r.patchServiceAnnotation(service, waitForLoadBalancerSinceAnnotation, nil)
+		}
+		return nil
+	}
+
+	if !waiting {
+		log.WithFields(log.Fields{
+			logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+			logfields.Service:          service.Name,
+		}).Debug("waiting for load balancer to be provisioned")
+		now := time.Now().Format(time.RFC3339)
+		if err := r.patchServiceAnnotation(service, waitForLoadBalancerSinceAnnotation, &now); err != nil {
+			return err
+		}
+	} else {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return err
+		}
+		if time.Since(t) > waitLoadBalancerTimeout {
+			return fmt.Errorf("timed out waiting for the load balancer of service %s to be provisioned", meta.Key(service))
+		}
+	}
+	return aserrors.NewRequeueError(podOperationRequeuePeriod, "waiting for the load balancer of service %s to be provisioned", meta.Key(service))
+}
+
+// patchServiceAnnotation sets the annotation with the specified key on the
+// specified service, or removes it if value is nil.
+func (r *AerospikeClusterReconciler) patchServiceAnnotation(service *v1.Service, key string, value *string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]*string{key: value},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = r.kubeclientset.CoreV1().Services(service.Namespace).Patch(service.Name, types.MergePatchType, patch)
+	return err
+}
+
+// deleteOrphanExternalServices deletes the services that expose pods which
+// no longer exist (e.g. after scaling down) or which should not be exposed
+// anymore (e.g. after external access is disabled).
+func (r *AerospikeClusterReconciler) deleteOrphanExternalServices(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) error {
+	services, err := r.servicesLister.Services(aerospikeCluster.Namespace).List(selectors.ResourcesByClusterName(aerospikeCluster.Name))
+	if err != nil {
+		return err
+	}
+
+	// build the set of names of the services that should exist
+	desired := make(map[string]bool)
+	if externalAccess := aerospikeCluster.Spec.ExternalAccess; externalAccess != nil && externalAccess.Type != common.ExternalAccessTypeHostNetwork {
+		for i := 0; i < int(aerospikeCluster.Spec.NodeCount); i++ {
+			desired[getExternalServiceName(fmt.Sprintf("%s-%d", aerospikeCluster.Name, i))] = true
+		}
+	}
+
+	for _, service := range services {
+		if _, ok := service.Labels[selectors.LabelPodKey]; !ok || desired[service.Name] {
+			continue
+		}
+		if err := r.kubeclientset.CoreV1().Services(service.Namespace).Delete(service.Name, &metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		log.WithFields(log.Fields{
+			logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+			logfields.Service:          service.Name,
+		}).Debug("service deleted")
+	}
+	return nil
+}
+
+// getExternalServiceName returns the name of the service that exposes the
+// pod with the specified name outside the kubernetes cluster.
+func getExternalServiceName(podName string) string {
+	return fmt.Sprintf("%s-%s", podName, externalServiceSuffix)
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"k8s.io/api/core/v1"
+
+	"github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/common"
+	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
+)
+
+// applyFeatureKey mounts the feature key file contained in the secret
+// specified for the cluster into the aerospike-server container of pod. it
+// does nothing unless aerospike enterprise edition is being used.
+func applyFeatureKey(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, pod *v1.Pod) {
+	secret := aerospikeCluster.Spec.FeatureKeySecret
+	if aerospikeCluster.Spec.GetEdition() != common.EditionEnterprise || secret == nil {
+		return
+	}
+	pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{
+		Name: featureKeyVolumeName,
+		VolumeSource: v1.VolumeSource{
+			Secret: &v1.SecretVolumeSource{
+				SecretName: secret.Name,
+				Items: []v1.KeyToPath{
+					{
+						Key:  secret.GetKey(),
+						Path: featureKeyFileName,
+					},
+				},
+			},
+		},
+	})
+	pod.Spec.Containers[0].VolumeMounts = append(pod.Spec.Containers[0].VolumeMounts, v1.VolumeMount{
+		Name:      featureKeyVolumeName,
+		MountPath: featureKeyMountPath,
+		ReadOnly:  true,
+	})
+}
@@ -0,0 +1,286 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/api/core/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/common"
+	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
+	aserrors "github.com/travelaudience/aerospike-operator/pkg/errors"
+	"github.com/travelaudience/aerospike-operator/pkg/logfields"
+	"github.com/travelaudience/aerospike-operator/pkg/meta"
+	"github.com/travelaudience/aerospike-operator/pkg/utils/events"
+	"github.com/travelaudience/aerospike-operator/pkg/utils/selectors"
+)
+
+// getUpdatedNamespaces returns the names of the existing namespaces whose
+// replication factor or storage spec have changed since the last successful
+// reconcile.
+func getUpdatedNamespaces(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) []string {
+	var res []string
+	for _, current := range aerospikeCluster.Status.Namespaces {
+		for _, desired := range aerospikeCluster.Spec.Namespaces {
+			if current.Name != desired.Name {
+				continue
+			}
+			if !reflect.DeepEqual(current.ReplicationFactor, desired.ReplicationFactor) || isStorageUpdated(current.Storage, desired.Storage) {
+				res = append(res, desired.Name)
+			}
+		}
+	}
+	return res
+}
+
+// isStorageUpdated indicates whether the fields of the storage spec that
+// require the pvcs of each pod to be expanded or replaced differ between
+// current and desired. other fields (such as the retention period of pvcs)
+// can be changed without touching the existing pods.
+func isStorageUpdated(current, desired aerospikev1alpha2.StorageSpec) bool {
+	return current.Type != desired.Type ||
+		current.Size != desired.Size ||
+		current.GetVolumeCount() != desired.GetVolumeCount() ||
+		!reflect.DeepEqual(current.StorageClassName, desired.StorageClassName)
+}
+
+// updatePodStorageWithIndex expands the outdated PVCs of the pod with the
+// specified index whenever possible, and safely deletes the pod once they have
+// been expanded so that it is re-created in a subsequent reconcile loop with
+// new PVCs replacing the ones that cannot be expanded.
+func (r *AerospikeClusterReconciler) updatePodStorageWithIndex(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, index int, outdatedPVCs []*v1.PersistentVolumeClaim) error {
+	// check whether a pod with the specified index exists
+	pod, err := r.getPodWithIndex(aerospikeCluster, index)
+	if err != nil {
+		// we've failed to get the pod with the specified index
+		return err
+	}
+	if pod == nil {
+		// no pod with the specified index exists, so we return
+		return nil
+	}
+
+	// the storage of the pod is already being updated (e.g. it is waiting for
+	// its pvcs to be expanded or for migrations to finish), so we just keep on
+	// restarting it once its pvcs have been expanded
+	if getPodOperationWithIndex(aerospikeCluster, index) != nil {
+		if err := r.waitForPersistentVolumeClaimsToBeExpanded(aerospikeCluster, pod); err != nil {
+			return err
+		}
+		return r.safeRestartPodWithIndex(aerospikeCluster, index, podOperationStorageUpdate)
+	}
+
+	log.WithFields(log.Fields{
+		logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+	}).Debugf("updating storage for pod %s", meta.Key(pod))
+	r.recorder.Eventf(aerospikeCluster, v1.EventTypeNormal, events.ReasonNodeStorageUpdateStarted,
+		"updating storage for pod %s", meta.Key(pod))
+
+	// record the operation before touching the pvcs, since pvcs whose
+	// expansion has been requested already match the current storage spec
+	// and the storage update must be resumed if the operator restarts while
+	// they are being expanded
+	if err := r.setPodOperation(aerospikeCluster, &podOperation{Type: podOperationStorageUpdate, Index: index}); err != nil {
+		return err
+	}
+
+	// expand the outdated pvcs in place whenever possible, and mark the
+	// remaining ones for replacement. the mark is recorded in the pvcs
+	// themselves before the pod is restarted so that they can be retired
+	// once the new pod has been repopulated, even if the operator restarts
+	// in the meantime.
+	expanding := false
+	for _, pvc := range outdatedPVCs {
+		namespace := getNamespaceForPVC(aerospikeCluster, pvc)
+		if namespace == nil {
+			continue
+		}
+		expandable, err := r.canExpandPersistentVolumeClaim(pvc, namespace)
+		if err != nil {
+			return err
+		}
+		if !expandable {
+			if err := r.signalReplaced(pvc.DeepCopy()); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := r.expandPersistentVolumeClaim(aerospikeCluster, pvc, namespace); err != nil {
+			return err
+		}
+		expanding = true
+	}
+
+	// the persistent volumes take a while to be resized, so we check on them
+	// in a subsequent reconcile loop
+	if expanding {
+		return aserrors.NewRequeueError(podOperationRequeuePeriod, "waiting for the persistentvolumeclaims of pod %s to be expanded", meta.Key(pod))
+	}
+
+	// restart the target pod so that expanded filesystems are resized and
+	// aerospike picks up the new storage size. since the pvcs marked for
+	// replacement do not match the current storage spec, new pvcs will be
+	// created for the new pod.
+	return r.safeRestartPodWithIndex(aerospikeCluster, index, podOperationStorageUpdate)
+}
+
+// finishPodStorageUpdate waits for the specified pod, which has been
+// re-created as part of a storage update, to be repopulated, and retires the
+// pvcs it has replaced.
+func (r *AerospikeClusterReconciler) finishPodStorageUpdate(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, pod *v1.Pod) error {
+	replacedPVCs, err := r.getReplacedPersistentVolumeClaims(aerospikeCluster, pod)
+	if err != nil {
+		return err
+	}
+	if len(replacedPVCs) > 0 {
+		if err := r.waitForPodToBeRepopulated(aerospikeCluster, pod); err != nil {
+			return err
+		}
+	}
+	// the data has been repopulated, so we can now retire the replaced pvcs
+	for _, pvc := range replacedPVCs {
+		if err := r.deletePersistentVolumeClaim(aerospikeCluster, pvc); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	log.WithFields(log.Fields{
+		logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+	}).Debugf("updated storage for pod %s", meta.Key(pod))
+	r.recorder.Eventf(aerospikeCluster, v1.EventTypeNormal, events.ReasonNodeStorageUpdateFinished,
+		"updated storage for pod %s", meta.Key(pod))
+
+	// report progress in the status of the cluster
+	if _, err := r.signalNodeStorageUpdated(aerospikeCluster, pod); err != nil {
+		return err
+	}
+	return nil
+}
+
+// waitForPodToBeRepopulated checks whether the specified (new) pod has joined
+// the cluster and has been repopulated by migrations, requesting the cluster
+// to be requeued otherwise.
+func (r *AerospikeClusterReconciler) waitForPodToBeRepopulated(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, pod *v1.Pod) error {
+	// make sure the pod has joined the cluster before waiting for migrations
+	if err := r.ensureClusterSize(aerospikeCluster, pod); err != nil {
+		return err
+	}
+	return r.waitForMigrationsToFinishOnPod(aerospikeCluster, pod)
+}
+
+// isPodStorageUpdatePending indicates whether the storage of the pod with the
+// specified index is being updated but the pod has not been deleted yet (e.g.
+// because its pvcs are still being expanded).
+func isPodStorageUpdatePending(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, index int) bool {
+	op := getPodOperationWithIndex(aerospikeCluster, index)
+	return op != nil && op.Type == podOperationStorageUpdate && !op.PodDeleted
+}
+
+// getNamespaceForPVC returns the spec of the namespace to which the specified
+// pvc belongs, or nil if no such namespace exists.
+func getNamespaceForPVC(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, pvc *v1.PersistentVolumeClaim) *aerospikev1alpha2.AerospikeNamespaceSpec {
+	for i := range aerospikeCluster.Spec.Namespaces {
+		if aerospikeCluster.Spec.Namespaces[i].Name == pvc.Labels[selectors.LabelNamespaceKey] {
+			return &aerospikeCluster.Spec.Namespaces[i]
+		}
+	}
+	return nil
+}
+
+func (r *AerospikeClusterReconciler) signalNamespaceUpdateStarted(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, namespaces []string) (*aerospikev1alpha2.AerospikeCluster, error) {
+	// grab a copy of aerospikeCluster in its current state so we can later
+	// create a patch
+	oldCluster := aerospikeCluster.DeepCopy()
+
+	appendCondition(aerospikeCluster, apiextensions.CustomResourceDefinitionCondition{
+		Type:               common.ConditionNamespaceUpdateStarted,
+		Status:             apiextensions.ConditionTrue,
+		Reason:             events.ReasonNamespaceUpdateStarted,
+		Message:            fmt.Sprintf("update of namespaces %s started", strings.Join(namespaces, ", ")),
+		LastTransitionTime: metav1.NewTime(time.Now()),
+	})
+	setAerospikeClusterAnnotation(aerospikeCluster, NamespaceUpdateStatusAnnotationKey, NamespaceUpdateStatusStartedAnnotationValue)
+
+	if err := r.patchCluster(oldCluster, aerospikeCluster); err != nil {
+		return nil, err
+	}
+
+	r.recorder.Eventf(aerospikeCluster, v1.EventTypeNormal, events.ReasonNamespaceUpdateStarted,
+		"update of namespaces %s started", strings.Join(namespaces, ", "))
+
+	log.WithFields(log.Fields{
+		logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+	}).Debugf("update of namespaces %s started", strings.Join(namespaces, ", "))
+
+	return aerospikeCluster, nil
+}
+
+func (r *AerospikeClusterReconciler) signalNodeStorageUpdated(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, pod *v1.Pod) (*aerospikev1alpha2.AerospikeCluster, error) {
+	// grab a copy of aerospikeCluster in its current state so we can later
+	// create a patch
+	oldCluster := aerospikeCluster.DeepCopy()
+
+	appendCondition(aerospikeCluster, apiextensions.CustomResourceDefinitionCondition{
+		Type:               common.ConditionNodeStorageUpdated,
+		Status:             apiextensions.ConditionTrue,
+		Reason:             events.ReasonNodeStorageUpdateFinished,
+		Message:            fmt.Sprintf("updated storage for pod %s", meta.Key(pod)),
+		LastTransitionTime: metav1.NewTime(time.Now()),
+	})
+
+	if err := r.patchCluster(oldCluster, aerospikeCluster); err != nil {
+		return nil, err
+	}
+
+	return aerospikeCluster, nil
+}
+
+func (r *AerospikeClusterReconciler) signalNamespaceUpdateFinished(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) (*aerospikev1alpha2.AerospikeCluster, error) {
+	// grab a copy of aerospikeCluster in its current state so we can later
+	// create a patch
+	oldCluster := aerospikeCluster.DeepCopy()
+
+	appendCondition(aerospikeCluster, apiextensions.CustomResourceDefinitionCondition{
+		Type:               common.ConditionNamespaceUpdateFinished,
+		Status:             apiextensions.ConditionTrue,
+		Reason:             events.ReasonNamespaceUpdateFinished,
+		Message:            "namespace update finished",
+		LastTransitionTime: metav1.NewTime(time.Now()),
+	})
+	removeAerospikeClusterAnnotation(aerospikeCluster, NamespaceUpdateStatusAnnotationKey)
+
+	if err := r.patchCluster(oldCluster, aerospikeCluster); err != nil {
+		return nil, err
+	}
+
+	r.recorder.Eventf(aerospikeCluster, v1.EventTypeNormal, events.ReasonNamespaceUpdateFinished,
+		"namespace update finished")
+
+	log.WithFields(log.Fields{
+		logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+	}).Debugf("namespace update finished")
+
+	return aerospikeCluster, nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/common"
+	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
+	"github.com/travelaudience/aerospike-operator/pkg/pointers"
+)
+
+func newAerospikeClusterForNamespaceUpdate(replicationFactor int32, storage aerospikev1alpha2.StorageSpec) *aerospikev1alpha2.AerospikeCluster {
+	aerospikeCluster := &aerospikev1alpha2.AerospikeCluster{}
+	aerospikeCluster.Spec.Namespaces = []aerospikev1alpha2.AerospikeNamespaceSpec{
+		{
+			Name:              "ns0",
+			ReplicationFactor: pointers.NewInt32(replicationFactor),
+			Storage:           storage,
+		},
+	}
+	aerospikeCluster.Status.Namespaces = []aerospikev1alpha2.AerospikeNamespaceSpec{
+		{
+			Name:              "ns0",
+			ReplicationFactor: pointers.NewInt32(2),
+			Storage: aerospikev1alpha2.StorageSpec{
+				Type: common.StorageTypeFile,
+				Size: "4G",
+			},
+		},
+	}
+	return aerospikeCluster
+}
+
+func TestGetUpdatedNamespaces(t *testing.T) {
+	tests := []struct {
+		replicationFactor int32
+		storage           aerospikev1alpha2.StorageSpec
+		updated           bool
+	}{
+		{2, aerospikev1alpha2.StorageSpec{Type: common.StorageTypeFile, Size: "4G"}, false},
+		{3, aerospikev1alpha2.StorageSpec{Type: common.StorageTypeFile, Size: "4G"}, true},
+		{2, aerospikev1alpha2.StorageSpec{Type: common.StorageTypeFile, Size: "8G"}, true},
+		{2, aerospikev1alpha2.StorageSpec{Type: common.StorageTypeDevice, Size: "4G"}, true},
+		{2, aerospikev1alpha2.StorageSpec{Type: common.StorageTypeFile, Size: "4G", VolumeCount: pointers.NewInt32(2)}, true},
+		{2, aerospikev1alpha2.StorageSpec{Type: common.StorageTypeFile, Size: "4G", StorageClassName: pointers.NewString("ssd")}, true},
+		// an explicit volume count equal to the default does not change the storage
+		{2, aerospikev1alpha2.StorageSpec{Type: common.StorageTypeFile, Size: "4G", VolumeCount: pointers.NewInt32(1)}, false},
+		// the retention period of pvcs does not require touching the existing pods
+		{2, aerospikev1alpha2.StorageSpec{Type: common.StorageTypeFile, Size: "4G", PersistentVolumeClaimTTL: pointers.NewString("7d")}, false},
+	}
+	for _, test := range tests {
+		res := getUpdatedNamespaces(newAerospikeClusterForNamespaceUpdate(test.replicationFactor, test.storage))
+		if test.updated {
+			assert.Equal(t, []string{"ns0"}, res)
+		} else {
+			assert.Empty(t, res)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/api/core/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/common"
+	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
+	"github.com/travelaudience/aerospike-operator/pkg/logfields"
+	"github.com/travelaudience/aerospike-operator/pkg/meta"
+	"github.com/travelaudience/aerospike-operator/pkg/utils/events"
+)
+
+func (r *AerospikeClusterReconciler) signalPaused(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) (*aerospikev1alpha2.AerospikeCluster, error) {
+	// grab a copy of aerospikeCluster in its current state so we can later
+	// create a patch
+	oldCluster := aerospikeCluster.DeepCopy()
+
+	aerospikeCluster.Status.Paused = true
+	appendCondition(aerospikeCluster, apiextensions.CustomResourceDefinitionCondition{
+		Type:               common.ConditionClusterPaused,
+		Status:             apiextensions.ConditionTrue,
+		Reason:             events.ReasonClusterPaused,
+		Message:            "reconciliation paused",
+		LastTransitionTime: metav1.NewTime(time.Now()),
+	})
+
+	if err := r.patchCluster(oldCluster, aerospikeCluster); err != nil {
+		return nil, err
+	}
+
+	r.recorder.Event(aerospikeCluster, v1.EventTypeNormal, events.ReasonClusterPaused,
+		"reconciliation paused")
+
+	log.WithFields(log.Fields{
+		logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+	}).Info("reconciliation paused")
+
+	return aerospikeCluster, nil
+}
+
+func (r *AerospikeClusterReconciler) signalResumed(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) (*aerospikev1alpha2.AerospikeCluster, error) {
+	// grab a copy of aerospikeCluster in its current state so we can later
+	// create a patch
+	oldCluster := aerospikeCluster.DeepCopy()
+
+	aerospikeCluster.Status.Paused = false
+	appendCondition(aerospikeCluster, apiextensions.CustomResourceDefinitionCondition{
+		Type:               common.ConditionClusterResumed,
+		Status:             apiextensions.ConditionTrue,
+		Reason:             events.ReasonClusterResumed,
+		Message:            "reconciliation resumed",
+		LastTransitionTime: metav1.NewTime(time.Now()),
+	})
+
+	if err := r.patchCluster(oldCluster, aerospikeCluster); err != nil {
+		return nil, err
+	}
+
+	r.recorder.Event(aerospikeCluster, v1.EventTypeNormal, events.ReasonClusterResumed,
+		"reconciliation resumed")
+
+	log.WithFields(log.Fields{
+		logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+	}).Info("reconciliation resumed")
+
+	return aerospikeCluster, nil
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/common"
+	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
+	"github.com/travelaudience/aerospike-operator/pkg/logfields"
+	"github.com/travelaudience/aerospike-operator/pkg/meta"
+	"github.com/travelaudience/aerospike-operator/pkg/utils/events"
+	"github.com/travelaudience/aerospike-operator/pkg/versioning"
+)
+
+// getProgressPhase returns the phase that describes the operation that is
+// about to be performed on the specified Aerospike cluster.
+func getProgressPhase(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, upgrade *versioning.VersionUpgrade) string {
+	switch {
+	case aerospikeCluster.Status.Version == "":
+		return common.ClusterPhaseCreating
+	case upgrade != nil:
+		return common.ClusterPhaseUpgrading
+	case aerospikeCluster.Spec.NodeCount != aerospikeCluster.Status.NodeCount:
+		return common.ClusterPhaseScaling
+	case aerospikeCluster.Status.Phase == "" || aerospikeCluster.Status.Phase == common.ClusterPhaseInvalid:
+		return common.ClusterPhaseRunning
+	default:
+		// keep the current phase until the end of the reconcile loop
+		return aerospikeCluster.Status.Phase
+	}
+}
+
+// updateReadiness sets the phase of aerospikeCluster and its ClusterReady
+// condition according to the number of ready nodes reported in its status.
+// IMPORTANT this method MUST only be called after a successful reconcile
+func updateReadiness(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) {
+	// nodes under maintenance are not expected to be running
+	expectedNodes := aerospikeCluster.Spec.NodeCount - int32(len(aerospikeCluster.Spec.NodesUnderMaintenance))
+	if aerospikeCluster.Status.ReadyNodes >= expectedNodes {
+		aerospikeCluster.Status.Phase = common.ClusterPhaseRunning
+		setCondition(aerospikeCluster, apiextensions.CustomResourceDefinitionCondition{
+			Type:               common.ConditionClusterReady,
+			Status:             apiextensions.ConditionTrue,
+			Reason:             events.ReasonNodesReady,
+			Message:            fmt.Sprintf("%d of %d nodes ready", aerospikeCluster.Status.ReadyNodes, expectedNodes),
+			LastTransitionTime: metav1.NewTime(time.Now()),
+		})
+		return
+	}
+	aerospikeCluster.Status.Phase = common.ClusterPhaseDegraded
+	setCondition(aerospikeCluster, apiextensions.CustomResourceDefinitionCondition{
+		Type:               common.ConditionClusterReady,
+		Status:             apiextensions.ConditionFalse,
+		Reason:             events.ReasonNodesNotReady,
+		Message:            fmt.Sprintf("%d of %d nodes ready", aerospikeCluster.Status.ReadyNodes, expectedNodes),
+		LastTransitionTime: metav1.NewTime(time.Now()),
+	})
+}
+
+// signalPhase sets the phase of aerospikeCluster, as well as the generation
+// of the resource that has been observed.
+func (r *AerospikeClusterReconciler) signalPhase(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, phase string) (*aerospikev1alpha2.AerospikeCluster, error) {
+	// grab a copy of aerospikeCluster in its current state so we can later
+	// create a patch
+	oldCluster := aerospikeCluster.DeepCopy()
+
+	aerospikeCluster.Status.Phase = phase
+	aerospikeCluster.Status.ObservedGeneration = aerospikeCluster.Generation
+
+	if err := r.patchCluster(oldCluster, aerospikeCluster); err != nil {
+		return nil, err
+	}
+
+	if oldCluster.Status.Phase != phase {
+		log.WithFields(log.Fields{
+			logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+		}).Debugf("phase changed to %s", phase)
+	}
+
+	return aerospikeCluster, nil
+}
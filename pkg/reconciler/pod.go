@@ -25,7 +25,6 @@ import (
 	"sort"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -34,13 +33,14 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
-	"k8s.io/apimachinery/pkg/watch"
 
 	"github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/common"
 	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
 	"github.com/travelaudience/aerospike-operator/pkg/asutils"
 	"github.com/travelaudience/aerospike-operator/pkg/crd"
 	"github.com/travelaudience/aerospike-operator/pkg/debug"
+	aserrors "github.com/travelaudience/aerospike-operator/pkg/errors"
+	"github.com/travelaudience/aerospike-operator/pkg/images"
 	"github.com/travelaudience/aerospike-operator/pkg/logfields"
 	"github.com/travelaudience/aerospike-operator/pkg/meta"
 	"github.com/travelaudience/aerospike-operator/pkg/pointers"
@@ -72,18 +72,64 @@ func (r *AerospikeClusterReconciler) ensurePods(aerospikeCluster *aerospikev1alp
 		logfields.DesiredSize:      desiredSize,
 	}).Debug("checking if pods need to be updated")
 
-	// scale down if necessary
-	for i := currentSize - 1; i >= desiredSize; i-- {
-		if err := r.safeDeletePodWithIndex(aerospikeCluster, i); err != nil {
-			log.WithFields(log.Fields{
-				logfields.AerospikeCluster: meta.Key(aerospikeCluster),
-			}).Errorf("failed to delete pod with index %d: %v", i, err)
+	// make sure that the readiness probe of each existing pod does not expect
+	// pods that are missing or about to be deleted for maintenance
+	if err := r.setExpectedClusterSize(aerospikeCluster, countExpectedNodes(aerospikeCluster, pods, -1), -1); err != nil {
+		return err
+	}
+
+	// finish the deletion of the pod targeted by the in-flight pod operation
+	// (if any), so that the operation can be resumed
+	if err := r.maybeFinishPodDeletion(aerospikeCluster); err != nil {
+		return err
+	}
+
+	// scale down if necessary. since nodes under maintenance may leave gaps
+	// in the sequence of indexes, we look at the index of every pod rather
+	// than at the number of pods
+	for j := currentSize - 1; j >= 0; j-- {
+		i := podIndex(pods[j])
+		if i < desiredSize {
+			break
+		}
+		if err := r.safeDeletePodWithIndex(aerospikeCluster, i, podOperationScaleDown); err != nil {
+			if !isRequeue(err) {
+				log.WithFields(log.Fields{
+					logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+				}).Errorf("failed to delete pod with index %d: %v", i, err)
+			}
 			return err
 		}
 	}
 
+	// new pods (i.e. pods that hold no data) may be started in parallel, up to
+	// the maximum surge, as long as there is at least one running pod they can
+	// use as a mesh seed. we keep track of how many of them are starting
+	canSurge := countExpectedNodes(aerospikeCluster, pods, -1) > 0
+	maxSurge := aerospikeCluster.Spec.GetMaxSurge()
+	starting := 0
+	var startingErr error
+
 	// create/upgrade/restart existing pods as required
 	for i := 0; i < desiredSize; i++ {
+		// check whether the node is under maintenance, in which case we make
+		// sure the pod is safely deleted and do not re-create it
+		if aerospikeCluster.Spec.IsNodeUnderMaintenance(i) {
+			if err := r.ensurePodUnderMaintenance(aerospikeCluster, i); err != nil {
+				if !isRequeue(err) {
+					log.WithFields(log.Fields{
+						logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+						logfields.PodIndex:         i,
+					}).Errorf("failed to put pod under maintenance: %v", err)
+				}
+				return err
+			}
+			continue
+		}
+
+		// check whether the pod may be started in parallel with other pods
+		surge := canSurge && isNewNode(aerospikeCluster, i)
+
 		// attempt to grab the pod with the specified index
 		pod, err := r.getPodWithIndex(aerospikeCluster, i)
 		if err != nil {
@@ -98,49 +144,153 @@ func (r *AerospikeClusterReconciler) ensurePods(aerospikeCluster *aerospikev1alp
 
 		// check whether the current pod is in a failure state, in which case we must delete and later re-create it
 		if pod != nil && isPodInFailureState(pod) {
+			// a pod re-created as part of an upgrade that fails means that
+			// the upgrade has failed
+			if isPodUpgradeFailing(aerospikeCluster, pod, upgrade) {
+				return r.failPodUpgrade(aerospikeCluster, pod)
+			}
 			log.WithFields(log.Fields{
 				logfields.AerospikeCluster: meta.Key(aerospikeCluster),
 				logfields.Pod:              meta.Key(pod),
 			}).Warn("pod is in a failure state and will be deleted")
+			// during an upgrade the pod is re-created with the target
+			// version, and so it must follow the upgrade strategy
+			if upgrade != nil {
+				if err := r.setPodOperation(aerospikeCluster, &podOperation{Type: podOperationUpgrade, Index: i}); err != nil {
+					return err
+				}
+			}
 			if err := r.deletePod(aerospikeCluster, pod); err != nil {
 				return err
 			}
-			// set pod to nil since we have just deleted it
-			pod = nil
+			// the pod will be re-created once it terminates
+			return aserrors.NewRequeueError(podOperationRequeuePeriod, "waiting for pod %s to terminate", meta.Key(pod))
+		}
+
+		if pod != nil {
+			// check whether the current pod is being deleted, in which case
+			// we must wait for it to terminate before re-creating it
+			if pod.DeletionTimestamp != nil {
+				return aserrors.NewRequeueError(podOperationRequeuePeriod, "waiting for pod %s to terminate", meta.Key(pod))
+			}
+			// check whether aerospike is still starting on the current pod,
+			// in which case we must wait for it to be ready. new pods do not
+			// prevent us from creating further new pods.
+			if !isPodRunningAndReady(pod) {
+				err := r.waitForPodToStart(aerospikeCluster, pod, upgrade)
+				if !surge || !isRequeue(err) {
+					return err
+				}
+				starting++
+				startingErr = err
+				continue
+			}
+			// finish the operation that re-created the current pod (if any)
+			if err := r.maybeFinishPodOperation(aerospikeCluster, configMap, pod, upgrade); err != nil {
+				return err
+			}
+		}
+
+		// check whether any of the pod's pvcs does not match the current
+		// storage spec, in which case it must be replaced
+		var outdatedPVCs []*v1.PersistentVolumeClaim
+		if pod != nil {
+			if outdatedPVCs, err = r.getOutdatedPersistentVolumeClaims(aerospikeCluster, pod); err != nil {
+				return err
+			}
+		}
+
+		// new pods are created in batches of at most maxSurge pods
+		if pod == nil && starting > 0 && (!surge || starting >= maxSurge) {
+			return startingErr
 		}
 
 		switch {
 		// check whether the pod needs to be created
 		case pod == nil:
-			// no pod with the specified index exists, so it must be created
-			pod, err = r.createPodWithIndex(aerospikeCluster, configMap, i, nil)
+			// no pod with the specified index exists, so it must be created.
+			// unless the pod is being re-created as part of another
+			// operation, we record its creation
+			op := getPodOperationWithIndex(aerospikeCluster, i)
+			if op == nil {
+				op = &podOperation{Type: podOperationCreate, Index: i, PodDeleted: true}
+				if err := r.setPodOperation(aerospikeCluster, op); err != nil {
+					return err
+				}
+			}
+			// pods re-created as part of an upgrade must follow the upgrade
+			// strategy
+			var podUpgrade *versioning.VersionUpgrade
+			if op.Type == podOperationUpgrade {
+				podUpgrade = upgrade
+			}
+			pod, err = r.createPodWithIndex(aerospikeCluster, configMap, i, podUpgrade)
 			if err != nil {
-				log.WithFields(log.Fields{
-					logfields.AerospikeCluster: meta.Key(aerospikeCluster),
-					logfields.PodIndex:         i,
-				}).Errorf("failed to create pod: %v", err)
+				if !isRequeue(err) {
+					log.WithFields(log.Fields{
+						logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+						logfields.PodIndex:         i,
+					}).Errorf("failed to create pod: %v", err)
+				}
 				return err
 			}
+			// signal the end of the maintenance of the node if necessary
+			if aerospikeCluster.Status.IsNodeUnderMaintenance(i) {
+				r.recorder.Eventf(aerospikeCluster, v1.EventTypeNormal, events.ReasonNodeMaintenanceFinished,
+					"pod %s re-created after maintenance", meta.Key(pod))
+			}
 		// check whether the pod needs to be upgraded
 		case upgrade != nil:
-			pod, err = r.maybeUpgradePodWithIndex(aerospikeCluster, configMap, i, upgrade)
-			if err != nil {
-				log.WithFields(log.Fields{
-					logfields.AerospikeCluster: meta.Key(aerospikeCluster),
-					logfields.PodIndex:         i,
-				}).Errorf("failed to upgrade pod: %v", err)
+			if err := r.maybeUpgradePodWithIndex(aerospikeCluster, pod, upgrade); err != nil {
+				if !isRequeue(err) {
+					log.WithFields(log.Fields{
+						logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+						logfields.PodIndex:         i,
+					}).Errorf("failed to upgrade pod: %v", err)
+				}
+				return err
+			}
+		// check whether the pod's storage needs to be updated, or whether
+		// its update has been interrupted (e.g. while its pvcs were being
+		// expanded)
+		case len(outdatedPVCs) > 0 || isPodStorageUpdatePending(aerospikeCluster, i):
+			if err := r.updatePodStorageWithIndex(aerospikeCluster, i, outdatedPVCs); err != nil {
+				if !isRequeue(err) {
+					log.WithFields(log.Fields{
+						logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+						logfields.PodIndex:         i,
+					}).Errorf("failed to update pod storage: %v", err)
+				}
 				return err
 			}
-		// check whether the pod needs to be restarted
+		// check whether the pod's configuration needs to be updated, in
+		// which case it is restarted unless only dynamic properties have
+		// changed
 		case configMap.Annotations[configMapHashAnnotation] != pod.Annotations[configMapHashAnnotation]:
-			pod, err = r.safeRestartPodWithIndex(aerospikeCluster, configMap, i, upgrade)
-			if err != nil {
-				log.WithFields(log.Fields{
-					logfields.AerospikeCluster: meta.Key(aerospikeCluster),
-					logfields.PodIndex:         i,
-				}).Errorf("failed to restart pod: %v", err)
+			if r.maybeUpdatePodConfig(aerospikeCluster, configMap, pod) {
+				break
+			}
+			if err := r.safeRestartPodWithIndex(aerospikeCluster, i, podOperationRestart); err != nil {
+				if !isRequeue(err) {
+					log.WithFields(log.Fields{
+						logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+						logfields.PodIndex:         i,
+					}).Errorf("failed to restart pod: %v", err)
+				}
+				return err
+			}
+		}
+
+		// wait for aerospike to start on the pod if it has just been created,
+		// unless it is a new pod and further new pods may be created
+		if !isPodRunningAndReady(pod) {
+			err := r.waitForPodToStart(aerospikeCluster, pod, upgrade)
+			if !surge || !isRequeue(err) {
 				return err
 			}
+			starting++
+			startingErr = err
+			continue
 		}
 
 		// ensure aerospike is reachable and reports the correct clusterSize
@@ -149,6 +299,17 @@ func (r *AerospikeClusterReconciler) ensurePods(aerospikeCluster *aerospikev1alp
 		}
 	}
 
+	// wait for the new pods that are still starting (if any)
+	if starting > 0 {
+		return startingErr
+	}
+
+	// delete services that expose pods which no longer exist or which should
+	// not be exposed anymore
+	if err := r.deleteOrphanExternalServices(aerospikeCluster); err != nil {
+		return err
+	}
+
 	// signal that we're good and return
 	log.WithFields(log.Fields{
 		logfields.AerospikeCluster: meta.Key(aerospikeCluster),
@@ -179,6 +340,11 @@ func (r *AerospikeClusterReconciler) createPodWithIndex(aerospikeCluster *aerosp
 	finalConfigFilePath := path.Join(finalConfigMountPath, configFileName)
 	// podName contains the name of the pod
 	podName := fmt.Sprintf("%s-%d", aerospikeCluster.Name, index)
+	// version contains the version of aerospike the pod will run
+	version, err := versioning.NewVersionFromString(aerospikeCluster.Spec.Version)
+	if err != nil {
+		return nil, err
+	}
 	// nodeId will contain the value used as service.node-id for the pod
 	nodeId, err := computeNodeId(podName)
 	if err != nil {
@@ -192,16 +358,23 @@ func (r *AerospikeClusterReconciler) createPodWithIndex(aerospikeCluster *aerosp
 	}
 	// build the list of mesh seeds for the pod, excluding the pod itself
 	// if it is still known to the lister (which may happen if the lister
-	// is not up-to-date)
-	peers := make([]string, len(pods))
+	// is not up-to-date) as well as pods that are not running and ready yet
+	// (e.g. other new pods that are being started in parallel)
+	peers := make([]string, 0, len(pods))
 	for _, pod := range pods {
-		if podName != pod.Name {
+		if podName != pod.Name && isPodRunningAndReady(pod) {
 			// https://kubernetes.io/docs/concepts/services-networking/dns-pod-service/#pod-s-hostname-and-subdomain-fields
 			peers = append(peers, fmt.Sprintf("%s.%s.%s", pod.Name, aerospikeCluster.Name, aerospikeCluster.Namespace))
 		}
 	}
 	// build the comma-separated list of peers which to pass to asinit
 	peerList := strings.Join(peers, ",")
+	// the pod is expected to observe every other pod that is not under
+	// maintenance, as well as itself
+	expectedClusterSize := countExpectedNodes(aerospikeCluster, pods, index) + 1
+	// probePath contains the path to the asprobe binary in the
+	// aerospike-server container
+	probePath := path.Join(probeMountPath, probeFileName)
 
 	// pod represents the pod that will be created
 	pod := &v1.Pod{
@@ -223,8 +396,13 @@ func (r *AerospikeClusterReconciler) createPodWithIndex(aerospikeCluster *aerosp
 				},
 			},
 			Annotations: map[string]string{
-				configMapHashAnnotation: configMap.Annotations[configMapHashAnnotation],
-				nodeIdAnnotation:        nodeId,
+				configMapHashAnnotation:    configMap.Annotations[configMapHashAnnotation],
+				staticConfigHashAnnotation: configMap.Annotations[staticConfigHashAnnotation],
+				dynamicConfigAnnotation:    configMap.Annotations[dynamicConfigAnnotation],
+				nodeIdAnnotation:           nodeId,
+				// the expected cluster size is exposed to asprobe through
+				// the downward api, and is kept up-to-date by ensurePods
+				expectedClusterSizeAnnotation: strconv.Itoa(expectedClusterSize),
 			},
 		},
 		Spec: v1.PodSpec{
@@ -233,8 +411,9 @@ func (r *AerospikeClusterReconciler) createPodWithIndex(aerospikeCluster *aerosp
 			// to the list of currently active nodes
 			InitContainers: []v1.Container{
 				{
-					Name:  "init",
-					Image: fmt.Sprintf("%s:%s", "quay.io/travelaudience/aerospike-operator-tools", versioning.OperatorVersion),
+					Name:            "init",
+					Image:           images.ToolsImage(aerospikeCluster.Spec.Images),
+					ImagePullPolicy: images.GetPullPolicy(aerospikeCluster.Spec.Images, ""),
 					Command: []string{
 						"/usr/local/bin/asinit",
 						"--node-id",
@@ -256,18 +435,33 @@ func (r *AerospikeClusterReconciler) createPodWithIndex(aerospikeCluster *aerosp
 							MountPath: finalConfigMountPath,
 						},
 					},
-					Resources: v1.ResourceRequirements{
-						Requests: v1.ResourceList{
-							v1.ResourceCPU:    resource.MustParse(initContainerCpuRequest),
-							v1.ResourceMemory: resource.MustParse(initContainerMemoryRequest),
+					Resources: computeInitContainerResources(aerospikeCluster),
+				},
+				// copy the asprobe binary from the tools image so that it
+				// can be used by the probes of the aerospike-server container
+				{
+					Name:            "install-probe",
+					Image:           images.ToolsImage(aerospikeCluster.Spec.Images),
+					ImagePullPolicy: images.GetPullPolicy(aerospikeCluster.Spec.Images, ""),
+					Command: []string{
+						"cp",
+						"/usr/local/bin/asprobe",
+						probePath,
+					},
+					VolumeMounts: []v1.VolumeMount{
+						{
+							Name:      probeVolumeName,
+							MountPath: probeMountPath,
 						},
 					},
+					Resources: computeInitContainerResources(aerospikeCluster),
 				},
 			},
 			Containers: []v1.Container{
 				{
-					Name:  "aerospike-server",
-					Image: fmt.Sprintf("aerospike/aerospike-server:%s", aerospikeCluster.Spec.Version),
+					Name:            aerospikeServerContainerName,
+					Image:           images.AerospikeServerImage(aerospikeCluster.Spec.Images, aerospikeCluster.Spec.GetEdition(), version),
+					ImagePullPolicy: images.GetPullPolicy(aerospikeCluster.Spec.Images, ""),
 					Command: []string{
 						"/usr/bin/asd",
 						"--foreground",
@@ -277,7 +471,7 @@ func (r *AerospikeClusterReconciler) createPodWithIndex(aerospikeCluster *aerosp
 					Ports: []v1.ContainerPort{
 						{
 							Name:          servicePortName,
-							ContainerPort: ServicePort,
+							ContainerPort: asutils.ServicePort,
 						},
 						{
 							Name:          heartbeatPortName,
@@ -297,12 +491,32 @@ func (r *AerospikeClusterReconciler) createPodWithIndex(aerospikeCluster *aerosp
 							Name:      finalConfigVolumeName,
 							MountPath: finalConfigMountPath,
 						},
+						{
+							Name:      probeVolumeName,
+							MountPath: probeMountPath,
+						},
+						{
+							Name:      podInfoVolumeName,
+							MountPath: podInfoMountPath,
+						},
 					},
+					// the aerospike node is ready when it responds to the
+					// status info command, observes the expected cluster
+					// size and a stable cluster key, and reports no
+					// unavailable partitions. a single change of the
+					// cluster key fails a single readiness probe, which
+					// is below asReadinessFailureThreshold
 					ReadinessProbe: &v1.Probe{
 						Handler: v1.Handler{
-							TCPSocket: &v1.TCPSocketAction{
-								Port: intstr.IntOrString{
-									IntVal: ServicePort,
+							Exec: &v1.ExecAction{
+								Command: []string{
+									probePath,
+									"--mode",
+									"readiness",
+									"--expected-cluster-size-file",
+									path.Join(podInfoMountPath, expectedClusterSizeFileName),
+									"--state-file",
+									path.Join(probeMountPath, probeStateFileName),
 								},
 							},
 						},
@@ -311,17 +525,29 @@ func (r *AerospikeClusterReconciler) createPodWithIndex(aerospikeCluster *aerosp
 						PeriodSeconds:       asReadinessPeriodSeconds,
 						FailureThreshold:    asReadinessFailureThreshold,
 					},
-					Resources: v1.ResourceRequirements{
-						Requests: v1.ResourceList{
-							v1.ResourceCPU:    computeCpuRequest(aerospikeCluster),
-							v1.ResourceMemory: computeMemoryRequest(aerospikeCluster),
+					// the aerospike node is alive when it responds to the
+					// status info command
+					LivenessProbe: &v1.Probe{
+						Handler: v1.Handler{
+							Exec: &v1.ExecAction{
+								Command: []string{
+									probePath,
+									"--mode",
+									"liveness",
+								},
+							},
 						},
+						InitialDelaySeconds: asLivenessInitialDelaySeconds,
+						TimeoutSeconds:      asLivenessTimeoutSeconds,
+						PeriodSeconds:       asLivenessPeriodSeconds,
+						FailureThreshold:    asLivenessFailureThreshold,
 					},
+					Resources: computeAerospikeServerResources(aerospikeCluster),
 				},
 				{
 					Name:            "asprom",
-					Image:           fmt.Sprintf("%s:%s", "quay.io/travelaudience/aerospike-operator-tools", versioning.OperatorVersion),
-					ImagePullPolicy: v1.PullAlways,
+					Image:           images.ToolsImage(aerospikeCluster.Spec.Images),
+					ImagePullPolicy: images.GetPullPolicy(aerospikeCluster.Spec.Images, v1.PullAlways),
 					Command: []string{
 						"asprom",
 					},
@@ -341,12 +567,7 @@ func (r *AerospikeClusterReconciler) createPodWithIndex(aerospikeCluster *aerosp
 							},
 						},
 					},
-					Resources: v1.ResourceRequirements{
-						Requests: v1.ResourceList{
-							v1.ResourceCPU:    resource.MustParse(aspromCpuRequest),
-							v1.ResourceMemory: resource.MustParse(aspromMemoryRequest),
-						},
-					},
+					Resources: computeAspromResources(aerospikeCluster),
 				},
 			},
 			Volumes: []v1.Volume{
@@ -366,7 +587,30 @@ func (r *AerospikeClusterReconciler) createPodWithIndex(aerospikeCluster *aerosp
 						EmptyDir: &v1.EmptyDirVolumeSource{},
 					},
 				},
+				{
+					Name: probeVolumeName,
+					VolumeSource: v1.VolumeSource{
+						EmptyDir: &v1.EmptyDirVolumeSource{},
+					},
+				},
+				{
+					Name: podInfoVolumeName,
+					VolumeSource: v1.VolumeSource{
+						DownwardAPI: &v1.DownwardAPIVolumeSource{
+							Items: []v1.DownwardAPIVolumeFile{
+								{
+									Path: expectedClusterSizeFileName,
+									FieldRef: &v1.ObjectFieldSelector{
+										FieldPath: fmt.Sprintf("metadata.annotations['%s']", expectedClusterSizeAnnotation),
+									},
+								},
+							},
+						},
+					},
+				},
 			},
+			// use the secrets specified by the user to pull images
+			ImagePullSecrets: images.GetPullSecrets(aerospikeCluster.Spec.Images),
 			// let the reconcile loop handle pod restarts
 			RestartPolicy: v1.RestartPolicyNever,
 			// use the pod's (stable) name as the hostname
@@ -376,7 +620,8 @@ func (r *AerospikeClusterReconciler) createPodWithIndex(aerospikeCluster *aerosp
 		},
 	}
 
-	// only enable in production, so it can be used in 1 node clusters while debugging (minikube)
+	// only enable in production, so it can be used in 1 node clusters while debugging (minikube).
+	// the user may replace this default through spec.podSpec.affinity.
 	if !debug.DebugEnabled {
 		pod.Spec.Affinity = &v1.Affinity{
 			PodAntiAffinity: &v1.PodAntiAffinity{
@@ -403,66 +648,92 @@ func (r *AerospikeClusterReconciler) createPodWithIndex(aerospikeCluster *aerosp
 		}
 	}
 
+	// merge the overrides specified by the user into the pod
+	mergePodSpec(pod, aerospikeCluster.Spec.PodSpec)
+
+	// schedule the pod according to the rack it belongs to (if any), and
+	// tell asinit which rack id to use
+	if rack := getRackForPodIndex(aerospikeCluster, index); rack != nil {
+		applyRack(pod, rack)
+		pod.Spec.InitContainers[0].Command = append(pod.Spec.InitContainers[0].Command, "--rack-id", strconv.Itoa(int(rack.ID)))
+	}
+
+	// mount the feature key file required by aerospike enterprise edition
+	applyFeatureKey(aerospikeCluster, pod)
+
+	// expose the pod outside the kubernetes cluster (if required)
+	if err := r.applyExternalAccess(aerospikeCluster, pod); err != nil {
+		return nil, err
+	}
+
 	// if the pod is being created during an upgrade operation
 	// get the corresponding upgradestrategy
 	var upgradeStrategy *versioning.UpgradeStrategy
 	if upgrade != nil {
-		upgradeStrategy, err = upgrade.GetStrategy()
+		upgradeStrategy, err = getUpgradeStrategy(upgrade)
 		if err != nil {
 			return nil, err
 		}
 	}
 
 	for index, namespace := range aerospikeCluster.Spec.Namespaces {
-		// if recreatepersistentvolumeclaims is true, create a new PVC
-		// else get an existing one, and if it does not exist, create one
-		var pvc *v1.PersistentVolumeClaim
-		if upgradeStrategy != nil && upgradeStrategy.RecreatePersistentVolumeClaims {
-			if pvc, err = r.createPersistentVolumeClaim(aerospikeCluster, pod, &namespace); err != nil {
-				return nil, err
-			}
-		} else {
-			if pvc, err = r.getPersistentVolumeClaim(aerospikeCluster, pod); err != nil {
-				return nil, err
-			}
-			if pvc != nil {
-				// mark the PVC as mounted
-				if err = r.signalMounted(pvc); err != nil {
+		// namespaces that store data in memory do not require a PVC
+		if namespace.Storage.Type == common.StorageTypeMemory {
+			continue
+		}
+		// create or reuse one PVC per volume across which the namespace is striped
+		for volumeIndex := 0; volumeIndex < namespace.Storage.GetVolumeCount(); volumeIndex++ {
+			// if recreatepersistentvolumeclaims is true, create a new PVC
+			// else get an existing one, and if it does not exist, create one
+			var pvc *v1.PersistentVolumeClaim
+			if upgradeStrategy != nil && upgradeStrategy.RecreatePersistentVolumeClaims {
+				if pvc, err = r.createPersistentVolumeClaim(aerospikeCluster, pod, &namespace, volumeIndex); err != nil {
 					return nil, err
 				}
 			} else {
-				if pvc, err = r.createPersistentVolumeClaim(aerospikeCluster, pod, &namespace); err != nil {
+				if pvc, err = r.getPersistentVolumeClaim(aerospikeCluster, pod, &namespace, volumeIndex); err != nil {
 					return nil, err
 				}
+				if pvc != nil {
+					// mark the PVC as mounted
+					if err = r.signalMounted(pvc); err != nil {
+						return nil, err
+					}
+				} else {
+					if pvc, err = r.createPersistentVolumeClaim(aerospikeCluster, pod, &namespace, volumeIndex); err != nil {
+						return nil, err
+					}
+				}
 			}
-		}
 
-		switch namespace.Storage.Type {
-		case common.StorageTypeDevice:
-			// use raw block device
-			pod.Spec.Containers[0].VolumeDevices = append(pod.Spec.Containers[0].VolumeDevices, v1.VolumeDevice{
-				Name:       fmt.Sprintf("%s-%s", namespaceVolumePrefix, namespace.Name),
-				DevicePath: getIndexBasedDevicePath(index),
-			})
-		case common.StorageTypeFile:
-			// use regular storage
-			pod.Spec.Containers[0].VolumeMounts = append(pod.Spec.Containers[0].VolumeMounts, v1.VolumeMount{
-				Name:      fmt.Sprintf("%s-%s", namespaceVolumePrefix, namespace.Name),
-				MountPath: fmt.Sprintf("%s%s", defaultFilePath, namespace.Name),
-			})
-		default:
-			// should not happen, as the type is validated as an enum
-			return nil, fmt.Errorf("unsupported storage type %s", namespace.Storage.Type)
-		}
+			volumeName := getVolumeName(namespace.Name, volumeIndex)
+			switch namespace.Storage.Type {
+			case common.StorageTypeDevice:
+				// use raw block device
+				pod.Spec.Containers[0].VolumeDevices = append(pod.Spec.Containers[0].VolumeDevices, v1.VolumeDevice{
+					Name:       volumeName,
+					DevicePath: getDevicePath(index, volumeIndex),
+				})
+			case common.StorageTypeFile:
+				// use regular storage
+				pod.Spec.Containers[0].VolumeMounts = append(pod.Spec.Containers[0].VolumeMounts, v1.VolumeMount{
+					Name:      volumeName,
+					MountPath: getFileMountPath(namespace.Name, volumeIndex),
+				})
+			default:
+				// should not happen, as the type is validated as an enum
+				return nil, fmt.Errorf("unsupported storage type %s", namespace.Storage.Type)
+			}
 
-		pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{
-			Name: fmt.Sprintf("%s-%s", namespaceVolumePrefix, pvc.Labels[selectors.LabelNamespaceKey]),
-			VolumeSource: v1.VolumeSource{
-				PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
-					ClaimName: pvc.Name,
+			pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{
+				Name: volumeName,
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+						ClaimName: pvc.Name,
+					},
 				},
-			},
-		})
+			})
+		}
 	}
 
 	// create the pod
@@ -471,76 +742,39 @@ func (r *AerospikeClusterReconciler) createPodWithIndex(aerospikeCluster *aerosp
 		return nil, err
 	}
 
-	done := make(chan bool, 1)
-	go func() {
-		ticker := time.NewTicker(podOperationFeedbackPeriod)
-		defer ticker.Stop()
-		for {
-			select {
-			case <-ticker.C:
-				r.recorder.Eventf(aerospikeCluster, v1.EventTypeNormal, events.ReasonNodeStarting,
-					"waiting for aerospike to start on pod %s", meta.Key(res))
-				log.WithFields(log.Fields{
-					logfields.AerospikeCluster: res.Labels[selectors.LabelClusterKey],
-					logfields.Pod:              meta.Key(res),
-				}).Infof("waiting for aerospike to start on pod %s", meta.Key(res))
-			case success := <-done:
-				if success {
-					r.recorder.Eventf(aerospikeCluster, v1.EventTypeNormal, events.ReasonNodeStarted,
-						"aerospike started on pod %s", meta.Key(res))
-					log.WithFields(log.Fields{
-						logfields.AerospikeCluster: res.Labels[selectors.LabelClusterKey],
-						logfields.Pod:              meta.Key(res),
-					}).Infof("aerospike started on pod %s", meta.Key(res))
-				} else {
-					r.recorder.Eventf(aerospikeCluster, v1.EventTypeWarning, events.ReasonNodeStartedFailed,
-						"could not start aerospike on pod %s", meta.Key(res))
-					log.WithFields(log.Fields{
-						logfields.AerospikeCluster: res.Labels[selectors.LabelClusterKey],
-						logfields.Pod:              meta.Key(res),
-					}).Infof("could not start aerospike on pod %s", meta.Key(res))
-				}
-				return
-			}
-		}
-	}()
-
-	currentPod := res
-	err = r.waitForPodCondition(res, func(event watch.Event) (bool, error) {
-		switch event.Type {
-		case watch.Error:
-			return false, fmt.Errorf("got event of type error: %+v", event.Object)
-		case watch.Deleted:
-			currentPod = event.Object.(*v1.Pod)
-			return false, fmt.Errorf("pod %s has been deleted", meta.Key(currentPod))
-		default:
-			currentPod = event.Object.(*v1.Pod)
-			if isPodInFailureState(currentPod) {
-				log.WithFields(log.Fields{
-					logfields.AerospikeCluster: meta.Key(aerospikeCluster),
-					logfields.Pod:              meta.Key(currentPod),
-				}).Warn("pod is in a failure state")
-				if err := r.deletePod(aerospikeCluster, currentPod); err != nil {
-					return false, err
-				}
-				return false, fmt.Errorf("pod %s in a failure state has been deleted", meta.Key(currentPod))
-
-			}
-			return isPodRunningAndReady(currentPod), nil
-		}
-	}, watchCreatePodTimeout)
-	done <- err == nil
-	close(done)
-	if err != nil {
-		return nil, err
-	}
-
+	r.recorder.Eventf(aerospikeCluster, v1.EventTypeNormal, events.ReasonNodeStarting,
+		"waiting for aerospike to start on pod %s", meta.Key(res))
 	log.WithFields(log.Fields{
 		logfields.AerospikeCluster: meta.Key(aerospikeCluster),
-		logfields.Pod:              meta.Key(currentPod),
-	}).Debug("pod created and running")
+		logfields.Pod:              meta.Key(res),
+	}).Infof("waiting for aerospike to start on pod %s", meta.Key(res))
+
+	return res, nil
+}
 
-	return currentPod, nil
+// waitForPodToStart checks whether aerospike has been starting on the
+// specified pod for longer than waitPodStartTimeout, in which case it reports
+// a failure (of the upgrade, if the pod has been re-created as part of the
+// specified upgrade). otherwise, it requests the cluster to be requeued so
+// that the pod is checked again later on.
+func (r *AerospikeClusterReconciler) waitForPodToStart(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, pod *v1.Pod, upgrade *versioning.VersionUpgrade) error {
+	// asinit may be waiting for the external address of the pod
+	if err := r.ensureExternalAddress(aerospikeCluster, pod); err != nil {
+		return err
+	}
+	if time.Since(pod.CreationTimestamp.Time) > waitPodStartTimeout {
+		if isPodUpgradeFailing(aerospikeCluster, pod, upgrade) {
+			return r.failPodUpgrade(aerospikeCluster, pod)
+		}
+		r.recorder.Eventf(aerospikeCluster, v1.EventTypeWarning, events.ReasonNodeStartedFailed,
+			"could not start aerospike on pod %s", meta.Key(pod))
+		log.WithFields(log.Fields{
+			logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+			logfields.Pod:              meta.Key(pod),
+		}).Warnf("could not start aerospike on pod %s", meta.Key(pod))
+		return fmt.Errorf("timed out waiting for aerospike to start on pod %s", meta.Key(pod))
+	}
+	return aserrors.NewRequeueError(podOperationRequeuePeriod, "waiting for aerospike to start on pod %s", meta.Key(pod))
 }
 
 func (r *AerospikeClusterReconciler) deletePod(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, pod *v1.Pod) error {
@@ -563,18 +797,11 @@ func (r *AerospikeClusterReconciler) deletePod(aerospikeCluster *aerospikev1alph
 	if err != nil {
 		return err
 	}
-	// wait for the pod to be successfully deleted
-	err = r.waitForPodCondition(pod, func(event watch.Event) (bool, error) {
-		return event.Type == watch.Deleted, nil
-	}, watchDeletePodTimeout)
-	if err != nil {
-		return err
-	}
 
 	log.WithFields(log.Fields{
 		logfields.AerospikeCluster: meta.Key(aerospikeCluster),
 		logfields.Pod:              meta.Key(pod),
-	}).Debug("pod has been deleted")
+	}).Debug("pod is being deleted")
 	return nil
 }
 
@@ -593,7 +820,11 @@ func (r *AerospikeClusterReconciler) getPodWithIndex(aerospikeCluster *aerospike
 	return p, nil
 }
 
-func (r *AerospikeClusterReconciler) safeDeletePodWithIndex(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, index int) error {
+// safeDeletePodWithIndex deletes the pod with the specified index (if it
+// exists) as part of the specified operation, after waiting for migrations to
+// finish on the pod. since the pod takes a while to terminate, it requests the
+// cluster to be requeued until the pod no longer exists.
+func (r *AerospikeClusterReconciler) safeDeletePodWithIndex(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, index int, operationType string) error {
 	// check whether a pod with the specified index exists
 	pod, err := r.getPodWithIndex(aerospikeCluster, index)
 	if err != nil {
@@ -604,104 +835,69 @@ func (r *AerospikeClusterReconciler) safeDeletePodWithIndex(aerospikeCluster *ae
 		// no pod with the specified index exists
 		return nil
 	}
-	// check whether the pod is participating in migrations
-	migrations, err := podHasMigrationsInProgress(pod)
+	// check whether the pod is already being deleted
+	if pod.DeletionTimestamp != nil {
+		return aserrors.NewRequeueError(podOperationRequeuePeriod, "waiting for pod %s to terminate", meta.Key(pod))
+	}
+	// make sure that the pod is not participating in migrations
+	if err := r.waitForMigrationsToFinishOnPod(aerospikeCluster, pod); err != nil {
+		return err
+	}
+	// make sure that the readiness probe of the remaining pods does not
+	// expect the pod to be part of the cluster
+	pods, err := r.listClusterPods(aerospikeCluster)
 	if err != nil {
 		return err
 	}
-	// if the pod is participating in migrations, we wait for them to finish and
-	// keep on giving feedback
-	if migrations {
-		done := make(chan bool, 1)
-		go func() {
-			ticker := time.NewTicker(podOperationFeedbackPeriod)
-			defer ticker.Stop()
-			log.WithFields(log.Fields{
-				logfields.AerospikeCluster: pod.Labels[selectors.LabelClusterKey],
-				logfields.Pod:              meta.Key(pod),
-			}).Info("waiting for migrations to finish")
-			r.recorder.Eventf(aerospikeCluster, v1.EventTypeNormal, events.ReasonWaitForMigrationsStarted,
-				"waiting for migrations to finish on pod %s",
-				meta.Key(pod),
-			)
-			for {
-				select {
-				case <-ticker.C:
-					log.WithFields(log.Fields{
-						logfields.AerospikeCluster: pod.Labels[selectors.LabelClusterKey],
-						logfields.Pod:              meta.Key(pod),
-					}).Info("waiting for migrations to finish")
-					r.recorder.Eventf(aerospikeCluster, v1.EventTypeNormal, events.ReasonWaitingForMigrations,
-						"waiting for migrations to finish on pod %s",
-						meta.Key(pod),
-					)
-				case <-done:
-					log.WithFields(log.Fields{
-						logfields.AerospikeCluster: pod.Labels[selectors.LabelClusterKey],
-						logfields.Pod:              meta.Key(pod),
-					}).Info("migrations finished")
-					r.recorder.Eventf(aerospikeCluster, v1.EventTypeNormal, events.ReasonWaitForMigrationsFinished,
-						"migrations finished on pod %s",
-						meta.Key(pod),
-					)
-					return
-				}
-			}
-		}()
-		if err := waitForMigrationsToFinishOnPod(pod); err != nil {
-			log.WithFields(log.Fields{
-				logfields.AerospikeCluster: pod.Labels[selectors.LabelClusterKey],
-				logfields.Pod:              meta.Key(pod),
-			}).Error("failed to wait for migrations to finish")
+	if err := r.setExpectedClusterSize(aerospikeCluster, countExpectedNodes(aerospikeCluster, pods, index), index); err != nil {
+		return err
+	}
+	// record the operation so that it can be resumed once the pod has been
+	// deleted, unless it has already been recorded
+	if op := getPodOperationWithIndex(aerospikeCluster, index); op == nil || op.PodDeleted {
+		if err := r.setPodOperation(aerospikeCluster, &podOperation{Type: operationType, Index: index}); err != nil {
 			return err
 		}
-		close(done)
 	}
 	// delete the pod now that migrations are finished
 	if err := r.deletePod(aerospikeCluster, pod); err != nil {
 		return err
 	}
+	return aserrors.NewRequeueError(podOperationRequeuePeriod, "waiting for pod %s to terminate", meta.Key(pod))
+}
 
-	// get a list of the pods
-	pods, err := r.listClusterPods(aerospikeCluster)
+// ensurePodUnderMaintenance safely deletes the pod with the specified index
+// (if it exists), so that the corresponding node can be maintained.
+func (r *AerospikeClusterReconciler) ensurePodUnderMaintenance(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, index int) error {
+	pod, err := r.getPodWithIndex(aerospikeCluster, index)
 	if err != nil {
 		return err
 	}
-
-	// tip-clear the name of the current pod
-	// and alumni-reset on all pods
-	var wg sync.WaitGroup
-	wg.Add(len(pods))
-	for _, p := range pods {
-		go func(p *v1.Pod) {
-			defer wg.Done()
-			if err := tipClearHostname(p, fmt.Sprintf("%s.%s.%s", pod.Name, aerospikeCluster.Name, aerospikeCluster.Namespace)); err != nil {
-				log.WithFields(log.Fields{
-					logfields.AerospikeCluster: pod.Labels[selectors.LabelClusterKey],
-					logfields.Pod:              meta.Key(pod),
-				}).Errorf("failed tip-clear ip on pod %q", meta.Key(p))
-			}
-			if err := alumniReset(p); err != nil {
-				log.WithFields(log.Fields{
-					logfields.AerospikeCluster: pod.Labels[selectors.LabelClusterKey],
-					logfields.Pod:              meta.Key(pod),
-				}).Errorf("failed alumni-reset on pod %q", meta.Key(p))
-			}
-		}(p)
+	if pod == nil {
+		// the pod has already been deleted
+		return nil
 	}
-	wg.Wait()
-	return nil
+	if pod.DeletionTimestamp == nil {
+		log.WithFields(log.Fields{
+			logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+			logfields.Pod:              meta.Key(pod),
+		}).Info("pod is under maintenance and will be deleted")
+	}
+	return r.safeDeletePodWithIndex(aerospikeCluster, index, podOperationMaintenance)
 }
 
-func (r *AerospikeClusterReconciler) safeRestartPodWithIndex(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, configMap *v1.ConfigMap, index int, upgrade *versioning.VersionUpgrade) (*v1.Pod, error) {
+// safeRestartPodWithIndex safely deletes the pod with the specified index, so
+// that it is re-created as part of the specified operation in a subsequent
+// reconcile loop.
+func (r *AerospikeClusterReconciler) safeRestartPodWithIndex(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, index int, operationType string) error {
 	log.WithFields(log.Fields{
 		logfields.AerospikeCluster: meta.Key(aerospikeCluster),
 	}).Debugf("restarting the pod with index %d", index)
 
-	if err := r.safeDeletePodWithIndex(aerospikeCluster, index); err != nil {
-		return nil, err
+	if err := r.safeDeletePodWithIndex(aerospikeCluster, index, operationType); err != nil {
+		return err
 	}
-	return r.createPodWithIndex(aerospikeCluster, configMap, index, upgrade)
+	return aserrors.NewRequeueError(podOperationRequeuePeriod, "waiting for pod with index %d to be re-created", index)
 }
 
 func (r *AerospikeClusterReconciler) computeMeshHash(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) (string, error) {
@@ -718,37 +914,49 @@ func (r *AerospikeClusterReconciler) computeMeshHash(aerospikeCluster *aerospike
 	return asstrings.HashSlice(addrList), nil
 }
 
+// ensureClusterSize checks whether aerospike is reachable on the specified
+// pod and reports the expected cluster size, provided that every other pod is
+// ready. if it doesn't, the cluster is
+// requeued until waitClusterSizeTimeout has elapsed since the last time any
+// pod was created or became ready, after which the pod is deleted so that it
+// can be re-created.
 func (r *AerospikeClusterReconciler) ensureClusterSize(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, pod *v1.Pod) error {
-	timer := time.NewTimer(waitClusterSizeTimeout)
-	defer timer.Stop()
-	ticker := time.NewTicker(time.Second)
-	defer ticker.Stop()
-	for {
-		select {
-		case <-ticker.C:
-			// get the current list of pods
-			pods, err := r.listClusterPods(aerospikeCluster)
-			if err != nil {
-				return err
-			}
-			// get the cluster size reported by the current node
-			clusterSize, err := asutils.GetClusterSize(pod.Status.PodIP, ServicePort)
-			if err != nil {
-				return err
-			}
-			// if the cluster size is the expected, return
-			if clusterSize == len(pods) {
-				return nil
-			}
-		case <-timer.C:
-			// the clusterSize is different than the expected, hence we delete
-			// the pod so it can be re-created in the next reconcile loop
-			if err := r.safeDeletePodWithIndex(aerospikeCluster, podIndex(pod)); err != nil {
-				return err
-			}
-			return fmt.Errorf("detected incorrect cluster size for pod %q", meta.Key(pod))
+	// get the current list of pods
+	pods, err := r.listClusterPods(aerospikeCluster)
+	if err != nil {
+		return err
+	}
+	// the cluster size can only be verified once every pod that is expected
+	// to be part of the cluster is ready
+	for _, p := range pods {
+		if isExpectedNode(aerospikeCluster, p, -1) && !isPodRunningAndReady(p) {
+			return nil
 		}
 	}
+	// nodes under maintenance are expected to be missing from the cluster,
+	// even if their pods are still being terminated
+	expectedSize := countExpectedNodes(aerospikeCluster, pods, -1)
+	// get the cluster size reported by the current node
+	clusterSize, err := asutils.GetClusterSize(pod.Status.PodIP, asutils.ServicePort)
+	if err != nil {
+		return err
+	}
+	// if the cluster size is the expected, let the readiness probe of every
+	// pod know about it and return
+	if clusterSize == expectedSize {
+		return r.setExpectedClusterSize(aerospikeCluster, expectedSize, -1)
+	}
+	// give the node some time to observe the latest changes to the cluster
+	if time.Since(getLastPodTransitionTime(pods)) < waitClusterSizeTimeout {
+		return aserrors.NewRequeueError(podOperationRequeuePeriod, "waiting for pod %s to report a cluster size of %d (currently %d)", meta.Key(pod), expectedSize, clusterSize)
+	}
+	// the clusterSize is different than the expected, hence we delete the
+	// pod so it can be re-created in a subsequent reconcile loop
+	log.WithFields(log.Fields{
+		logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+		logfields.Pod:              meta.Key(pod),
+	}).Warnf("detected incorrect cluster size (expected %d, got %d)", expectedSize, clusterSize)
+	return r.safeDeletePodWithIndex(aerospikeCluster, podIndex(pod), podOperationRecreate)
 }
 
 // computeCpuRequest computes the amount of cpu to be requested for the aerospike-server container and returns the
@@ -775,7 +983,7 @@ func computeMemoryRequest(aerospikeCluster *aerospikev1alpha2.AerospikeCluster)
 		} else {
 			log.WithFields(log.Fields{
 				logfields.AerospikeCluster: meta.Key(aerospikeCluster),
-			}).Warn("failed to parse memory size for namespace %s: %v", err)
+			}).Warnf("failed to parse memory size for namespace %s: %v", ns.Name, err)
 			// ns.MemorySize has been validated before, so it is highly unlikely
 			// than an error occurs at this point. however, if it does occur, we
 			// must return something, and so we pick the default memory request.
@@ -785,6 +993,91 @@ func computeMemoryRequest(aerospikeCluster *aerospikev1alpha2.AerospikeCluster)
 	return resource.MustParse(fmt.Sprintf("%dGi", sum))
 }
 
+// mergePodSpec merges the overrides specified in podSpec into pod. Labels
+// and annotations set by aerospike-operator take precedence over the ones
+// specified in podSpec, and the affinity specified in podSpec replaces the
+// default one (including the default pod anti-affinity).
+func mergePodSpec(pod *v1.Pod, podSpec *aerospikev1alpha2.AerospikePodSpec) {
+	if podSpec == nil {
+		return
+	}
+	for key, value := range podSpec.Labels {
+		if _, ok := pod.Labels[key]; !ok {
+			pod.Labels[key] = value
+		}
+	}
+	for key, value := range podSpec.Annotations {
+		if _, ok := pod.Annotations[key]; !ok {
+			pod.Annotations[key] = value
+		}
+	}
+	if len(podSpec.NodeSelector) > 0 {
+		pod.Spec.NodeSelector = make(map[string]string, len(podSpec.NodeSelector))
+		for key, value := range podSpec.NodeSelector {
+			pod.Spec.NodeSelector[key] = value
+		}
+	}
+	for _, toleration := range podSpec.Tolerations {
+		pod.Spec.Tolerations = append(pod.Spec.Tolerations, *toleration.DeepCopy())
+	}
+	if podSpec.PriorityClassName != "" {
+		pod.Spec.PriorityClassName = podSpec.PriorityClassName
+	}
+	if podSpec.Affinity != nil {
+		pod.Spec.Affinity = podSpec.Affinity.DeepCopy()
+	}
+}
+
+// computeAerospikeServerResources computes the resource requirements for the aerospike-server container. Requests
+// which have not been specified by the user (and whose limit has not been specified either) are computed based on the
+// value of the memorySize field of each namespace.
+func computeAerospikeServerResources(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) v1.ResourceRequirements {
+	var resources *v1.ResourceRequirements
+	if aerospikeCluster.Spec.Resources != nil {
+		resources = aerospikeCluster.Spec.Resources.AerospikeServer
+	}
+	return withDefaultRequests(resources, computeCpuRequest(aerospikeCluster), computeMemoryRequest(aerospikeCluster))
+}
+
+// computeAspromResources computes the resource requirements for the asprom container.
+func computeAspromResources(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) v1.ResourceRequirements {
+	var resources *v1.ResourceRequirements
+	if aerospikeCluster.Spec.Resources != nil {
+		resources = aerospikeCluster.Spec.Resources.Asprom
+	}
+	return withDefaultRequests(resources, resource.MustParse(aspromCpuRequest), resource.MustParse(aspromMemoryRequest))
+}
+
+// computeInitContainerResources computes the resource requirements for the init container.
+func computeInitContainerResources(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) v1.ResourceRequirements {
+	var resources *v1.ResourceRequirements
+	if aerospikeCluster.Spec.Resources != nil {
+		resources = aerospikeCluster.Spec.Resources.Init
+	}
+	return withDefaultRequests(resources, resource.MustParse(initContainerCpuRequest), resource.MustParse(initContainerMemoryRequest))
+}
+
+// withDefaultRequests returns a copy of resources in which the cpu and memory requests are set to the provided
+// values, unless either the request or the limit for the corresponding resource has been specified. In the latter
+// case, kubernetes defaults the request to the value of the limit.
+func withDefaultRequests(resources *v1.ResourceRequirements, cpu, memory resource.Quantity) v1.ResourceRequirements {
+	res := v1.ResourceRequirements{}
+	if resources != nil {
+		resources.DeepCopyInto(&res)
+	}
+	if res.Requests == nil {
+		res.Requests = v1.ResourceList{}
+	}
+	for name, value := range map[v1.ResourceName]resource.Quantity{v1.ResourceCPU: cpu, v1.ResourceMemory: memory} {
+		_, hasRequest := res.Requests[name]
+		_, hasLimit := res.Limits[name]
+		if !hasRequest && !hasLimit {
+			res.Requests[name] = value
+		}
+	}
+	return res
+}
+
 // computeNodeId computes the value to be used as the id of the aerospike node
 // that corresponds to podName.
 func computeNodeId(podName string) (string, error) {
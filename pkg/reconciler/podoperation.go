@@ -0,0 +1,263 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/api/core/v1"
+
+	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
+	"github.com/travelaudience/aerospike-operator/pkg/logfields"
+	"github.com/travelaudience/aerospike-operator/pkg/meta"
+	"github.com/travelaudience/aerospike-operator/pkg/utils/events"
+	"github.com/travelaudience/aerospike-operator/pkg/versioning"
+)
+
+// podOperation describes an operation that involves deleting and/or
+// (re-)creating the pod with a given index. since such an operation spans
+// several reconcile loops, it is recorded in an annotation of the
+// AerospikeCluster resource so that it can be resumed (e.g. after the
+// operator restarts). there is at most one operation per pod, and several
+// operations may be in-flight at the same time (e.g. when new pods are
+// created in parallel).
+type podOperation struct {
+	// Type is the type of the operation (e.g. "restart").
+	Type string `json:"type"`
+	// Index is the index of the pod on which the operation is performed.
+	Index int `json:"index"`
+	// PodDeleted indicates whether the original pod (if any) has already
+	// been deleted and removed from the aerospike cluster.
+	PodDeleted bool `json:"podDeleted,omitempty"`
+}
+
+// getPodOperations returns the pod operations recorded in the specified
+// AerospikeCluster resource.
+func getPodOperations(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) []*podOperation {
+	value, ok := aerospikeCluster.Annotations[PodOperationAnnotationKey]
+	if !ok {
+		return nil
+	}
+	var res []*podOperation
+	if err := json.Unmarshal([]byte(value), &res); err != nil {
+		// the annotation is for internal use only, so we discard its value if
+		// it is not valid
+		log.WithFields(log.Fields{
+			logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+		}).Warnf("ignoring invalid pod operations %q: %v", value, err)
+		return nil
+	}
+	return res
+}
+
+// getPodOperationWithIndex returns the pod operation recorded in the
+// specified AerospikeCluster resource for the pod with the specified index,
+// or nil if there is none.
+func getPodOperationWithIndex(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, index int) *podOperation {
+	for _, op := range getPodOperations(aerospikeCluster) {
+		if op.Index == index {
+			return op
+		}
+	}
+	return nil
+}
+
+// setPodOperations records the specified pod operations in the specified
+// AerospikeCluster resource, removing the annotation if there are none.
+func (r *AerospikeClusterReconciler) setPodOperations(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, ops []*podOperation) error {
+	// grab a copy of aerospikeCluster in its current state so we can later
+	// create a patch
+	oldCluster := aerospikeCluster.DeepCopy()
+
+	if len(ops) == 0 {
+		removeAerospikeClusterAnnotation(aerospikeCluster, PodOperationAnnotationKey)
+	} else {
+		value, err := json.Marshal(ops)
+		if err != nil {
+			return err
+		}
+		setAerospikeClusterAnnotation(aerospikeCluster, PodOperationAnnotationKey, string(value))
+	}
+
+	return r.patchCluster(oldCluster, aerospikeCluster)
+}
+
+// setPodOperation records the specified pod operation, replacing any
+// operation previously recorded for the same pod.
+func (r *AerospikeClusterReconciler) setPodOperation(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, op *podOperation) error {
+	ops := []*podOperation{op}
+	for _, o := range getPodOperations(aerospikeCluster) {
+		if o.Index != op.Index {
+			ops = append(ops, o)
+		}
+	}
+	if err := r.setPodOperations(aerospikeCluster, ops); err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+		logfields.PodIndex:         op.Index,
+	}).Debugf("recorded pod operation %s", op.Type)
+
+	return nil
+}
+
+// removePodOperation removes the pod operation recorded for the pod with the
+// specified index (if any).
+func (r *AerospikeClusterReconciler) removePodOperation(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, index int) error {
+	var ops []*podOperation
+	for _, o := range getPodOperations(aerospikeCluster) {
+		if o.Index != index {
+			ops = append(ops, o)
+		}
+	}
+	return r.setPodOperations(aerospikeCluster, ops)
+}
+
+// maybeFinishPodDeletion checks whether the pods targeted by the recorded pod
+// operations have been deleted, in which case it removes them from the
+// aerospike cluster and records their deletion. operations that do not
+// involve re-creating the pod are finished at this point.
+func (r *AerospikeClusterReconciler) maybeFinishPodDeletion(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) error {
+	for _, op := range getPodOperations(aerospikeCluster) {
+		if err := r.maybeFinishPodDeletionWithIndex(aerospikeCluster, op); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *AerospikeClusterReconciler) maybeFinishPodDeletionWithIndex(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, op *podOperation) error {
+	// the pod will not be re-created if the spec has changed in the meantime,
+	// so we forget about the operation
+	if op.PodDeleted {
+		if op.Index >= int(aerospikeCluster.Spec.NodeCount) || aerospikeCluster.Spec.IsNodeUnderMaintenance(op.Index) {
+			return r.removePodOperation(aerospikeCluster, op.Index)
+		}
+		return nil
+	}
+	pod, err := r.getPodWithIndex(aerospikeCluster, op.Index)
+	if err != nil {
+		return err
+	}
+	if pod != nil {
+		// the pod has not been deleted yet
+		return nil
+	}
+
+	// tip-clear the name of the deleted pod and alumni-reset on all pods
+	if err := r.removePodFromMesh(aerospikeCluster, fmt.Sprintf("%s-%d", aerospikeCluster.Name, op.Index)); err != nil {
+		return err
+	}
+
+	switch op.Type {
+	case podOperationMaintenance:
+		r.recorder.Eventf(aerospikeCluster, v1.EventTypeNormal, events.ReasonNodeMaintenanceStarted,
+			"pod %s/%s-%d deleted for maintenance", aerospikeCluster.Namespace, aerospikeCluster.Name, op.Index)
+		return r.removePodOperation(aerospikeCluster, op.Index)
+	case podOperationScaleDown:
+		return r.removePodOperation(aerospikeCluster, op.Index)
+	}
+
+	// the pod will be re-created in the current reconcile loop
+	op.PodDeleted = true
+	return r.setPodOperation(aerospikeCluster, op)
+}
+
+// removePodFromMesh makes the remaining nodes of the aerospike cluster forget
+// about the pod with the specified name after it has been deleted.
+func (r *AerospikeClusterReconciler) removePodFromMesh(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, podName string) error {
+	// get a list of the pods
+	pods, err := r.listClusterPods(aerospikeCluster)
+	if err != nil {
+		return err
+	}
+
+	// tip-clear the name of the deleted pod
+	// and alumni-reset on all pods
+	var wg sync.WaitGroup
+	wg.Add(len(pods))
+	for _, p := range pods {
+		go func(p *v1.Pod) {
+			defer wg.Done()
+			if err := tipClearHostname(p, fmt.Sprintf("%s.%s.%s", podName, aerospikeCluster.Name, aerospikeCluster.Namespace)); err != nil {
+				log.WithFields(log.Fields{
+					logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+					logfields.Pod:              fmt.Sprintf("%s/%s", aerospikeCluster.Namespace, podName),
+				}).Errorf("failed tip-clear ip on pod %q", meta.Key(p))
+			}
+			if err := alumniReset(p); err != nil {
+				log.WithFields(log.Fields{
+					logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+					logfields.Pod:              fmt.Sprintf("%s/%s", aerospikeCluster.Namespace, podName),
+				}).Errorf("failed alumni-reset on pod %q", meta.Key(p))
+			}
+		}(p)
+	}
+	wg.Wait()
+	return nil
+}
+
+// maybeFinishPodOperation finishes the recorded pod operation if it targets
+// the specified (running and ready) pod and the pod has been re-created.
+func (r *AerospikeClusterReconciler) maybeFinishPodOperation(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, configMap *v1.ConfigMap, pod *v1.Pod, upgrade *versioning.VersionUpgrade) error {
+	op := getPodOperationWithIndex(aerospikeCluster, podIndex(pod))
+	if op == nil || !op.PodDeleted {
+		return nil
+	}
+
+	switch op.Type {
+	case podOperationUpgrade:
+		if upgrade != nil {
+			if err := r.finishPodUpgrade(aerospikeCluster, pod, upgrade); err != nil {
+				return err
+			}
+		}
+	case podOperationStorageUpdate:
+		if err := r.finishPodStorageUpdate(aerospikeCluster, pod); err != nil {
+			return err
+		}
+	case podOperationRestart:
+		// a rolling restart caused by a change to the replication factor of
+		// existing namespaces must only move on to the next pod once the
+		// restarted pod has been repopulated by migrations
+		if _, ok := aerospikeCluster.Annotations[NamespaceUpdateStatusAnnotationKey]; ok {
+			if err := r.waitForPodToBeRepopulated(aerospikeCluster, pod); err != nil {
+				return err
+			}
+		}
+		// report the progress of the rolling restart if necessary
+		if _, ok := aerospikeCluster.Annotations[RestartStatusAnnotationKey]; ok {
+			if err := r.signalNodeRestarted(aerospikeCluster, configMap, pod); err != nil {
+				return err
+			}
+		}
+	}
+
+	r.recorder.Eventf(aerospikeCluster, v1.EventTypeNormal, events.ReasonNodeStarted,
+		"aerospike started on pod %s", meta.Key(pod))
+	log.WithFields(log.Fields{
+		logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+		logfields.Pod:              meta.Key(pod),
+	}).Infof("aerospike started on pod %s", meta.Key(pod))
+
+	return r.removePodOperation(aerospikeCluster, op.Index)
+}
@@ -17,19 +17,26 @@ limitations under the License.
 package reconciler
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
 	"time"
 
 	as "github.com/aerospike/aerospike-client-go"
+	log "github.com/sirupsen/logrus"
 	"k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apimachinery/pkg/types"
 	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
 
+	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
+	"github.com/travelaudience/aerospike-operator/pkg/asutils"
+	aserrors "github.com/travelaudience/aerospike-operator/pkg/errors"
+	"github.com/travelaudience/aerospike-operator/pkg/logfields"
 	"github.com/travelaudience/aerospike-operator/pkg/meta"
-	"github.com/travelaudience/aerospike-operator/pkg/utils/listoptions"
+	"github.com/travelaudience/aerospike-operator/pkg/utils/events"
 	"github.com/travelaudience/aerospike-operator/pkg/utils/selectors"
+	"github.com/travelaudience/aerospike-operator/pkg/versioning"
 )
 
 type byIndex []*v1.Pod
@@ -56,6 +63,74 @@ func podIndex(pod *v1.Pod) int {
 	return res
 }
 
+// countExpectedNodes returns the number of pods in the specified list that are
+// expected to be part of the aerospike cluster (i.e. that are running and
+// ready, are not being deleted and are not under maintenance), excluding the
+// pod with the specified index (if any). pods that are still starting are not
+// counted, so that the readiness of the remaining pods does not depend on
+// them.
+func countExpectedNodes(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, pods []*v1.Pod, excludedIndex int) int {
+	res := 0
+	for _, pod := range pods {
+		if !isExpectedNode(aerospikeCluster, pod, excludedIndex) || !isPodRunningAndReady(pod) {
+			continue
+		}
+		res++
+	}
+	return res
+}
+
+// isNewNode indicates whether the pod with the specified index corresponds to
+// a new aerospike node (i.e. one that was not part of the aerospike cluster as
+// of the last successful reconcile, and hence holds no data). this is the case
+// for every pod when the cluster is first created, and for the added pods
+// when the cluster is scaled up.
+func isNewNode(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, index int) bool {
+	if index < int(aerospikeCluster.Status.NodeCount) {
+		return false
+	}
+	// pods being re-created as part of another operation are not new
+	op := getPodOperationWithIndex(aerospikeCluster, index)
+	return op == nil || op.Type == podOperationCreate
+}
+
+// isExpectedNode indicates whether the specified pod is expected to
+// eventually be part of the aerospike cluster (i.e. it is not being deleted,
+// is not under maintenance and does not have the specified index).
+func isExpectedNode(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, pod *v1.Pod, excludedIndex int) bool {
+	index := podIndex(pod)
+	return index != excludedIndex && pod.DeletionTimestamp == nil && !aerospikeCluster.Spec.IsNodeUnderMaintenance(index)
+}
+
+// setExpectedClusterSize updates the annotation holding the expected cluster
+// size (which is read by the readiness probe of each aerospike node) in every
+// pod of the aerospike cluster, except for the pod with the specified index
+// (if any). pods that are still starting are expected to observe an
+// additional node (i.e. themselves).
+func (r *AerospikeClusterReconciler) setExpectedClusterSize(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, size int, excludedIndex int) error {
+	pods, err := r.listClusterPods(aerospikeCluster)
+	if err != nil {
+		return err
+	}
+	for _, pod := range pods {
+		if podIndex(pod) == excludedIndex || pod.DeletionTimestamp != nil {
+			continue
+		}
+		expected := size
+		if !isPodRunningAndReady(pod) {
+			expected++
+		}
+		value := strconv.Itoa(expected)
+		if pod.Annotations[expectedClusterSizeAnnotation] == value {
+			continue
+		}
+		if err := r.patchPodAnnotation(pod, expectedClusterSizeAnnotation, &value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func isPodRunningAndReady(pod *v1.Pod) bool {
 	return pod.Status.Phase == v1.PodRunning && podutil.IsPodReady(pod)
 }
@@ -94,34 +169,8 @@ func isImageError(reason string) bool {
 	return reason == ReasonErrImagePull || reason == ReasonImageInspectError || reason == ReasonImagePullBackOff || reason == ReasonRegistryUnavailable
 }
 
-func (r *AerospikeClusterReconciler) waitForPodCondition(pod *v1.Pod, fn watch.ConditionFunc, timeout time.Duration) error {
-	start := time.Now()
-	w, err := r.kubeclientset.CoreV1().Pods(pod.Namespace).Watch(listoptions.ObjectByNameAndVersion(pod.Name, pod.ResourceVersion))
-	if err != nil {
-		return err
-	}
-
-	lastPod := pod
-	last, err := watch.Until(timeout, w, fn)
-	if err != nil {
-		if err == watch.ErrWatchClosed {
-			if t := timeout - time.Since(start); t > 0 {
-				if last != nil {
-					lastPod = last.Object.(*v1.Pod)
-				}
-				return r.waitForPodCondition(lastPod, fn, t)
-			}
-		}
-		return err
-	}
-	if last == nil {
-		return fmt.Errorf("no events received for %s", meta.Key(pod))
-	}
-	return nil
-}
-
 func podHasMigrationsInProgress(pod *v1.Pod) (bool, error) {
-	client, err := as.NewClient(pod.Status.PodIP, ServicePort)
+	client, err := as.NewClient(pod.Status.PodIP, asutils.ServicePort)
 	if err != nil {
 		return false, err
 	}
@@ -136,43 +185,129 @@ func podHasMigrationsInProgress(pod *v1.Pod) (bool, error) {
 	return false, fmt.Errorf("failed to find node %s in the cluster", pod.Annotations[nodeIdAnnotation])
 }
 
-func waitForMigrationsToFinishOnPod(pod *v1.Pod) error {
-	client, err := as.NewClient(pod.Status.PodIP, ServicePort)
+// waitForMigrationsToFinishOnPod checks whether the specified pod is
+// participating in migrations, in which case it requests the cluster to be
+// requeued until migrations finish or waitMigrationsTimeout elapses. the time
+// at which we started waiting is recorded in an annotation of the pod.
+func (r *AerospikeClusterReconciler) waitForMigrationsToFinishOnPod(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, pod *v1.Pod) error {
+	migrations, err := podHasMigrationsInProgress(pod)
 	if err != nil {
 		return err
 	}
-	defer client.Close()
-	// try to find the current node by its id/name
-	for _, node := range client.Cluster().GetNodes() {
-		// node.GetName returns an upper-case string, so we must ignore case
-		if strings.EqualFold(node.GetName(), pod.Annotations[nodeIdAnnotation]) {
-			return node.WaitUntillMigrationIsFinished(waitMigrationsTimeout)
+	since, waiting := pod.Annotations[waitForMigrationsSinceAnnotation]
+
+	if !migrations {
+		if waiting {
+			log.WithFields(log.Fields{
+				logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+				logfields.Pod:              meta.Key(pod),
+			}).Info("migrations finished")
+			r.recorder.Eventf(aerospikeCluster, v1.EventTypeNormal, events.ReasonWaitForMigrationsFinished,
+				"migrations finished on pod %s",
+				meta.Key(pod),
+			)
+			return r.patchPodAnnotation(pod, waitForMigrationsSinceAnnotation, nil)
+		}
+		return nil
+	}
+
+	if !waiting {
+		log.WithFields(log.Fields{
+			logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+			logfields.Pod:              meta.Key(pod),
+		}).Info("waiting for migrations to finish")
+		r.recorder.Eventf(aerospikeCluster, v1.EventTypeNormal, events.ReasonWaitForMigrationsStarted,
+			"waiting for migrations to finish on pod %s",
+			meta.Key(pod),
+		)
+		now := time.Now().Format(time.RFC3339)
+		if err := r.patchPodAnnotation(pod, waitForMigrationsSinceAnnotation, &now); err != nil {
+			return err
+		}
+	} else {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return err
+		}
+		if time.Since(t) > waitMigrationsTimeout {
+			log.WithFields(log.Fields{
+				logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+				logfields.Pod:              meta.Key(pod),
+			}).Error("failed to wait for migrations to finish")
+			return fmt.Errorf("timed out waiting for migrations to finish on pod %s", meta.Key(pod))
+		}
+		log.WithFields(log.Fields{
+			logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+			logfields.Pod:              meta.Key(pod),
+		}).Debug("waiting for migrations to finish")
+	}
+	return aserrors.NewRequeueError(migrationsRequeuePeriod, "waiting for migrations to finish on pod %s", meta.Key(pod))
+}
+
+// patchPodAnnotation sets the annotation with the specified key on the
+// specified pod, or removes it if value is nil.
+func (r *AerospikeClusterReconciler) patchPodAnnotation(pod *v1.Pod, key string, value *string) error {
+	return r.patchPodAnnotations(pod, map[string]*string{key: value})
+}
+
+// patchPodAnnotations sets the values of the specified annotations on pod
+// in a single patch. annotations whose value is nil are removed.
+func (r *AerospikeClusterReconciler) patchPodAnnotations(pod *v1.Pod, annotations map[string]*string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": annotations,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = r.kubeclientset.CoreV1().Pods(pod.Namespace).Patch(pod.Name, types.MergePatchType, patch)
+	return err
+}
+
+// getLastPodTransitionTime returns the most recent time at which any of the
+// specified pods was created or changed its readiness.
+func getLastPodTransitionTime(pods []*v1.Pod) time.Time {
+	var res time.Time
+	for _, pod := range pods {
+		if pod.CreationTimestamp.After(res) {
+			res = pod.CreationTimestamp.Time
+		}
+		if _, condition := podutil.GetPodCondition(&pod.Status, v1.PodReady); condition != nil && condition.LastTransitionTime.After(res) {
+			res = condition.LastTransitionTime.Time
 		}
 	}
-	return fmt.Errorf("failed to find node %s in the cluster", pod.Annotations[nodeIdAnnotation])
+	return res
+}
+
+// isRequeue indicates whether the specified error signals that an operation
+// is still in progress (rather than a failure).
+func isRequeue(err error) bool {
+	_, ok := aserrors.IsRequeue(err)
+	return ok
 }
 
-func runInfoCommandOnPod(pod *v1.Pod, command string) (map[string]string, error) {
-	addr := fmt.Sprintf("%s:%d", pod.Status.PodIP, ServicePort)
+func runInfoCommandOnPod(pod *v1.Pod, commands ...string) (map[string]string, error) {
+	addr := fmt.Sprintf("%s:%d", pod.Status.PodIP, asutils.ServicePort)
 	conn, err := as.NewConnection(addr, aerospikeClientTimeout)
 	if err != nil {
 		return nil, err
 	}
 	defer conn.Close()
-	return as.RequestInfo(conn, command)
+	return as.RequestInfo(conn, commands...)
 }
 
-func getAerospikeServerVersionFromPod(pod *v1.Pod) (string, error) {
+func getAerospikeServerVersionFromPod(pod *v1.Pod) (versioning.Version, error) {
 	res, err := runInfoCommandOnPod(pod, "build")
 	if err != nil {
-		return "", err
+		return versioning.Version{}, err
 	}
 	version, ok := res["build"]
 	if !ok {
-		return "", fmt.Errorf("failed to get aerospike version from pod %v", meta.Key(pod))
+		return versioning.Version{}, fmt.Errorf("failed to get aerospike version from pod %v", meta.Key(pod))
 	}
 
-	return version, nil
+	return versioning.NewVersionFromString(version)
 }
 
 func tipClearHostname(pod *v1.Pod, address string) error {
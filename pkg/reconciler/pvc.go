@@ -19,11 +19,15 @@ package reconciler
 import (
 	"encoding/json"
 	"fmt"
+	"path"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	log "github.com/sirupsen/logrus"
 	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -32,9 +36,11 @@ import (
 	"github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/common"
 	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
 	"github.com/travelaudience/aerospike-operator/pkg/crd"
+	aserrors "github.com/travelaudience/aerospike-operator/pkg/errors"
 	"github.com/travelaudience/aerospike-operator/pkg/logfields"
 	"github.com/travelaudience/aerospike-operator/pkg/meta"
 	"github.com/travelaudience/aerospike-operator/pkg/pointers"
+	"github.com/travelaudience/aerospike-operator/pkg/utils/events"
 	"github.com/travelaudience/aerospike-operator/pkg/utils/selectors"
 	astime "github.com/travelaudience/aerospike-operator/pkg/utils/time"
 )
@@ -60,7 +66,7 @@ func (pvcs fromMostRecent) Less(i, j int) bool {
 	return pvcs[j].CreationTimestamp.Before(&pvcs[i].CreationTimestamp)
 }
 
-func (r *AerospikeClusterReconciler) getPersistentVolumeClaim(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, pod *v1.Pod) (*v1.PersistentVolumeClaim, error) {
+func (r *AerospikeClusterReconciler) getPersistentVolumeClaim(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, pod *v1.Pod, namespace *aerospikev1alpha2.AerospikeNamespaceSpec, volumeIndex int) (*v1.PersistentVolumeClaim, error) {
 	// get all the pvcs owned by the aerospikecluster
 	pvcs, err := r.pvcsLister.PersistentVolumeClaims(aerospikeCluster.Namespace).List(selectors.ResourcesByClusterName(aerospikeCluster.Name))
 	if err != nil {
@@ -70,7 +76,7 @@ func (r *AerospikeClusterReconciler) getPersistentVolumeClaim(aerospikeCluster *
 		return nil, nil
 	}
 
-	// filter the ones associated with the pod
+	// filter the ones associated with the pod and namespace
 	var podPVCs []*v1.PersistentVolumeClaim
 	for _, pvc := range pvcs {
 		// skip pvc if it does not belong to the right pod
@@ -78,6 +84,22 @@ func (r *AerospikeClusterReconciler) getPersistentVolumeClaim(aerospikeCluster *
 		if !ok || podName != pod.Name {
 			continue
 		}
+		// skip pvc if it does not belong to the right namespace
+		if pvc.Labels[selectors.LabelNamespaceKey] != namespace.Name {
+			continue
+		}
+		// skip pvc if it does not correspond to the right volume
+		if getVolumeIndex(pvc) != volumeIndex {
+			continue
+		}
+		// skip pvc if it does not match the namespace's current storage spec
+		if !pvcMatchesStorageSpec(pvc, namespace) {
+			continue
+		}
+		// skip pvc if it has been replaced as part of a storage update
+		if _, ok := pvc.Annotations[ReplacedOnAnnotation]; ok {
+			continue
+		}
 		// retrieve the timestamp of when the pvc was last unmounted.
 		// if not available, skip this pvc.
 		lastUnmountedString, ok := pvc.Annotations[LastUnmountedOnAnnotation]
@@ -118,7 +140,7 @@ func (r *AerospikeClusterReconciler) getPersistentVolumeClaim(aerospikeCluster *
 	return podPVCs[0], nil
 }
 
-func (r *AerospikeClusterReconciler) createPersistentVolumeClaim(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, pod *v1.Pod, namespace *aerospikev1alpha2.AerospikeNamespaceSpec) (*v1.PersistentVolumeClaim, error) {
+func (r *AerospikeClusterReconciler) createPersistentVolumeClaim(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, pod *v1.Pod, namespace *aerospikev1alpha2.AerospikeNamespaceSpec, volumeIndex int) (*v1.PersistentVolumeClaim, error) {
 	storageSize, err := resource.ParseQuantity(namespace.Storage.Size)
 	if err != nil {
 		return nil, err
@@ -152,8 +174,9 @@ func (r *AerospikeClusterReconciler) createPersistentVolumeClaim(aerospikeCluste
 				},
 			},
 			Annotations: map[string]string{
-				PodAnnotation:    pod.Name,
-				PVCTTLAnnotation: persistentVolumeClaimTTL,
+				PodAnnotation:         pod.Name,
+				PVCTTLAnnotation:      persistentVolumeClaimTTL,
+				VolumeIndexAnnotation: strconv.Itoa(volumeIndex),
 			},
 		},
 		Spec: v1.PersistentVolumeClaimSpec{
@@ -190,10 +213,290 @@ func (r *AerospikeClusterReconciler) createPersistentVolumeClaim(aerospikeCluste
 	return pvc, err
 }
 
+// getOutdatedPersistentVolumeClaims returns the list of pvcs mounted by the
+// specified pod that do not match the storage spec of the namespace they
+// belong to.
+func (r *AerospikeClusterReconciler) getOutdatedPersistentVolumeClaims(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, pod *v1.Pod) ([]*v1.PersistentVolumeClaim, error) {
+	var res []*v1.PersistentVolumeClaim
+	for _, volume := range pod.Spec.Volumes {
+		claim := volume.PersistentVolumeClaim
+		if claim == nil {
+			continue
+		}
+		pvc, err := r.pvcsLister.PersistentVolumeClaims(pod.Namespace).Get(claim.ClaimName)
+		if err != nil {
+			// the lister may not know about a recently created pvc yet, in
+			// which case it is certainly not outdated
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		for _, namespace := range aerospikeCluster.Spec.Namespaces {
+			if pvc.Labels[selectors.LabelNamespaceKey] == namespace.Name && !pvcMatchesStorageSpec(pvc, &namespace) {
+				res = append(res, pvc)
+			}
+		}
+	}
+	return res, nil
+}
+
+// pvcMatchesStorageSpec indicates whether the specified pvc can be used to
+// store data for the specified namespace according to its current storage
+// spec.
+func pvcMatchesStorageSpec(pvc *v1.PersistentVolumeClaim, namespace *aerospikev1alpha2.AerospikeNamespaceSpec) bool {
+	if !pvcMatchesStorageTypeAndClass(pvc, namespace) {
+		return false
+	}
+	// make sure that the requested size matches the storage size
+	storageSize, err := resource.ParseQuantity(namespace.Storage.Size)
+	if err != nil {
+		return false
+	}
+	requestedSize := pvc.Spec.Resources.Requests[v1.ResourceStorage]
+	return requestedSize.Cmp(storageSize) == 0
+}
+
+// pvcMatchesStorageTypeAndClass indicates whether the volume mode and storage
+// class of the specified pvc match the storage spec of the specified
+// namespace.
+func pvcMatchesStorageTypeAndClass(pvc *v1.PersistentVolumeClaim, namespace *aerospikev1alpha2.AerospikeNamespaceSpec) bool {
+	// make sure that the volume mode matches the storage type
+	if pvc.Spec.VolumeMode == nil || *pvc.Spec.VolumeMode != volumeModeMap[namespace.Storage.Type] {
+		return false
+	}
+	// make sure that the storage class matches the requested one (if any).
+	// if no storage class has been requested, the default storage class will
+	// have been assigned to the pvc, and as such we cannot compare them.
+	if namespace.Storage.StorageClassName != nil && *namespace.Storage.StorageClassName != "" {
+		if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName != *namespace.Storage.StorageClassName {
+			return false
+		}
+	}
+	return true
+}
+
+// canExpandPersistentVolumeClaim indicates whether the specified pvc can be
+// expanded in place in order to match the storage spec of the specified
+// namespace (i.e. whether only its size must be increased and its storage
+// class allows for volume expansion).
+func (r *AerospikeClusterReconciler) canExpandPersistentVolumeClaim(pvc *v1.PersistentVolumeClaim, namespace *aerospikev1alpha2.AerospikeNamespaceSpec) (bool, error) {
+	if !pvcMatchesStorageTypeAndClass(pvc, namespace) {
+		return false, nil
+	}
+	storageSize, err := resource.ParseQuantity(namespace.Storage.Size)
+	if err != nil {
+		return false, err
+	}
+	requestedSize := pvc.Spec.Resources.Requests[v1.ResourceStorage]
+	if requestedSize.Cmp(storageSize) >= 0 {
+		return false, nil
+	}
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" {
+		return false, nil
+	}
+	sc, err := r.scsLister.Get(*pvc.Spec.StorageClassName)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return sc.AllowVolumeExpansion != nil && *sc.AllowVolumeExpansion, nil
+}
+
+// expandPersistentVolumeClaim patches the storage request of the specified pvc
+// so that it matches the storage size of the specified namespace, and records
+// when the expansion was requested so that the resizing of the underlying
+// persistent volume can be checked on in subsequent reconcile loops. in the
+// case of filesystem volumes, the filesystem itself will only be resized when
+// the pvc is next mounted.
+func (r *AerospikeClusterReconciler) expandPersistentVolumeClaim(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, pvc *v1.PersistentVolumeClaim, namespace *aerospikev1alpha2.AerospikeNamespaceSpec) error {
+	storageSize, err := resource.ParseQuantity(namespace.Storage.Size)
+	if err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		logfields.AerospikeCluster:      meta.Key(aerospikeCluster),
+		logfields.PersistentVolumeClaim: pvc.Name,
+	}).Debugf("expanding persistentvolumeclaim to %s", storageSize.String())
+	r.recorder.Eventf(aerospikeCluster, v1.EventTypeNormal, events.ReasonVolumeExpansionStarted,
+		"expanding persistentvolumeclaim %s to %s", pvc.Name, storageSize.String())
+
+	oldPVC := pvc.DeepCopy()
+	newPVC := pvc.DeepCopy()
+	newPVC.Spec.Resources.Requests[v1.ResourceStorage] = storageSize
+	setPVCAnnotation(newPVC, ExpansionRequestedOnAnnotation, time.Now().Format(time.RFC3339))
+	return r.patchPVC(oldPVC, newPVC)
+}
+
+// waitForPersistentVolumeClaimsToBeExpanded checks whether the persistent
+// volumes bound to the pvcs of the specified pod whose expansion has been
+// requested have been resized, requesting the cluster to be requeued
+// otherwise.
+func (r *AerospikeClusterReconciler) waitForPersistentVolumeClaimsToBeExpanded(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, pod *v1.Pod) error {
+	pvcs, err := r.getPersistentVolumeClaimsWithAnnotation(aerospikeCluster, pod, ExpansionRequestedOnAnnotation)
+	if err != nil {
+		return err
+	}
+
+	var pending []string
+	for _, pvc := range pvcs {
+		storageSize := pvc.Spec.Resources.Requests[v1.ResourceStorage]
+		if isPVCResized(pvc, storageSize) {
+			log.WithFields(log.Fields{
+				logfields.AerospikeCluster:      meta.Key(aerospikeCluster),
+				logfields.PersistentVolumeClaim: pvc.Name,
+			}).Debugf("persistentvolumeclaim expanded to %s", storageSize.String())
+			r.recorder.Eventf(aerospikeCluster, v1.EventTypeNormal, events.ReasonVolumeExpansionFinished,
+				"expanded persistentvolumeclaim %s to %s", pvc.Name, storageSize.String())
+			oldPVC := pvc.DeepCopy()
+			newPVC := pvc.DeepCopy()
+			removePVCAnnotation(newPVC, ExpansionRequestedOnAnnotation)
+			if err := r.patchPVC(oldPVC, newPVC); err != nil {
+				return err
+			}
+			continue
+		}
+		since, err := time.Parse(time.RFC3339, pvc.Annotations[ExpansionRequestedOnAnnotation])
+		if err != nil {
+			return err
+		}
+		if time.Since(since) > waitResizePVCTimeout {
+			r.recorder.Eventf(aerospikeCluster, v1.EventTypeWarning, events.ReasonVolumeExpansionFailed,
+				"failed to expand persistentvolumeclaim %s to %s: timed out", pvc.Name, storageSize.String())
+			return fmt.Errorf("timed out waiting for persistentvolumeclaim %s to be expanded to %s", pvc.Name, storageSize.String())
+		}
+		pending = append(pending, pvc.Name)
+	}
+
+	if len(pending) > 0 {
+		log.WithFields(log.Fields{
+			logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+			logfields.Pod:              meta.Key(pod),
+		}).Debugf("waiting for persistentvolumeclaims %s to be expanded", strings.Join(pending, ", "))
+		return aserrors.NewRequeueError(podOperationRequeuePeriod, "waiting for the persistentvolumeclaims of pod %s to be expanded", meta.Key(pod))
+	}
+	return nil
+}
+
+// isPVCResized indicates whether the persistent volume bound to the specified
+// pvc has been resized to (at least) the specified size, or whether it only
+// awaits for its filesystem to be resized (which only happens when the pvc is
+// next mounted).
+func isPVCResized(pvc *v1.PersistentVolumeClaim, size resource.Quantity) bool {
+	for _, condition := range pvc.Status.Conditions {
+		if condition.Type == v1.PersistentVolumeClaimFileSystemResizePending && condition.Status == v1.ConditionTrue {
+			return true
+		}
+	}
+	capacity := pvc.Status.Capacity[v1.ResourceStorage]
+	return capacity.Cmp(size) >= 0
+}
+
+// getReplacedPersistentVolumeClaims returns the list of pvcs associated with
+// the specified pod that have been marked for replacement as part of a
+// storage update.
+func (r *AerospikeClusterReconciler) getReplacedPersistentVolumeClaims(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, pod *v1.Pod) ([]*v1.PersistentVolumeClaim, error) {
+	return r.getPersistentVolumeClaimsWithAnnotation(aerospikeCluster, pod, ReplacedOnAnnotation)
+}
+
+// getPersistentVolumeClaimsWithAnnotation returns the list of pvcs associated
+// with the specified pod that hold the annotation with the specified key.
+func (r *AerospikeClusterReconciler) getPersistentVolumeClaimsWithAnnotation(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, pod *v1.Pod, key string) ([]*v1.PersistentVolumeClaim, error) {
+	pvcs, err := r.pvcsLister.PersistentVolumeClaims(aerospikeCluster.Namespace).List(selectors.ResourcesByClusterName(aerospikeCluster.Name))
+	if err != nil {
+		return nil, err
+	}
+	var res []*v1.PersistentVolumeClaim
+	for _, pvc := range pvcs {
+		if pvc.Annotations[PodAnnotation] != pod.Name {
+			continue
+		}
+		if _, ok := pvc.Annotations[key]; ok {
+			res = append(res, pvc)
+		}
+	}
+	return res, nil
+}
+
+func (r *AerospikeClusterReconciler) deletePersistentVolumeClaim(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, pvc *v1.PersistentVolumeClaim) error {
+	if err := r.kubeclientset.CoreV1().PersistentVolumeClaims(pvc.Namespace).Delete(pvc.Name, &metav1.DeleteOptions{}); err != nil {
+		log.WithFields(log.Fields{
+			logfields.AerospikeCluster:      meta.Key(aerospikeCluster),
+			logfields.PersistentVolumeClaim: pvc.Name,
+		}).Errorf("error deleting persistentvolumeclaim: %s", err)
+		return err
+	}
+	log.WithFields(log.Fields{
+		logfields.AerospikeCluster:      meta.Key(aerospikeCluster),
+		logfields.PersistentVolumeClaim: pvc.Name,
+	}).Debug("persistentvolumeclaim deleted")
+	return nil
+}
+
 // getIndexBasedDevicePath returns the device path for the namespace
-// with the specified index (e.g. 0 --> /dev/xvda, 1 --> /dev/xvdb, ...).
+// with the specified index (e.g. 0 --> /dev/xvda, 1 --> /dev/xvdb, ...,
+// 25 --> /dev/xvdz, 26 --> /dev/xvdaa, 27 --> /dev/xvdab, ...).
 func getIndexBasedDevicePath(index int) string {
-	return fmt.Sprintf("%s%s", defaultDevicePathPrefix, string('a'+index))
+	suffix := ""
+	for index >= 0 {
+		suffix = string(rune('a'+index%26)) + suffix
+		index = index/26 - 1
+	}
+	return fmt.Sprintf("%s%s", defaultDevicePathPrefix, suffix)
+}
+
+// getDevicePath returns the device path for the volume with the specified
+// index of the namespace with the specified index (e.g. (1, 0) --> /dev/xvdb,
+// (0, 1) --> /dev/xvdag, (1, 1) --> /dev/xvdav, ...). the first volume of each
+// namespace uses the same device path as when a single volume per namespace
+// was supported, and the remaining volumes use the device paths that follow
+// the ones reserved for the first volume of every namespace.
+func getDevicePath(namespaceIndex, volumeIndex int) string {
+	if volumeIndex == 0 {
+		return getIndexBasedDevicePath(namespaceIndex)
+	}
+	return getIndexBasedDevicePath(maxNamespaces + namespaceIndex*(maxVolumesPerNamespace-1) + volumeIndex - 1)
+}
+
+// getFileMountPath returns the path at which the volume with the specified
+// index of the specified namespace is mounted (e.g. (ns, 0) -->
+// /opt/aerospike/data/ns, (ns, 1) --> /opt/aerospike/data-1/ns, ...).
+func getFileMountPath(namespace string, volumeIndex int) string {
+	if volumeIndex == 0 {
+		return fmt.Sprintf("%s%s", defaultFilePath, namespace)
+	}
+	return fmt.Sprintf("%s-%d/%s", strings.TrimSuffix(defaultFilePath, "/"), volumeIndex, namespace)
+}
+
+// getFilePath returns the path to the file in which aerospike stores the data
+// of the specified namespace in the volume with the specified index.
+func getFilePath(namespace string, volumeIndex int) string {
+	return path.Join(getFileMountPath(namespace, volumeIndex), fmt.Sprintf("%s.dat", namespace))
+}
+
+// getVolumeName returns the name of the pod volume corresponding to the
+// volume with the specified index of the specified namespace (e.g. (ns, 0)
+// --> data-ns-ns, (ns, 1) --> data-ns1-ns, ...).
+func getVolumeName(namespace string, volumeIndex int) string {
+	if volumeIndex == 0 {
+		return fmt.Sprintf("%s-%s", namespaceVolumePrefix, namespace)
+	}
+	return fmt.Sprintf("%s%d-%s", namespaceVolumePrefix, volumeIndex, namespace)
+}
+
+// getVolumeIndex returns the index of the specified pvc among the pvcs used
+// by its pod to store data for its namespace. pvcs created before multiple
+// volumes per namespace were supported are the first (and only) ones.
+func getVolumeIndex(pvc *v1.PersistentVolumeClaim) int {
+	if value, ok := pvc.Annotations[VolumeIndexAnnotation]; ok {
+		if index, err := strconv.Atoi(value); err == nil {
+			return index
+		}
+	}
+	return 0
 }
 
 func (r *AerospikeClusterReconciler) signalMounted(pvc *v1.PersistentVolumeClaim) error {
@@ -208,6 +511,15 @@ func (r *AerospikeClusterReconciler) signalUnmounted(pvc *v1.PersistentVolumeCla
 	return r.patchPVC(oldPVC, pvc)
 }
 
+func (r *AerospikeClusterReconciler) signalReplaced(pvc *v1.PersistentVolumeClaim) error {
+	if _, ok := pvc.Annotations[ReplacedOnAnnotation]; ok {
+		return nil
+	}
+	oldPVC := pvc.DeepCopy()
+	setPVCAnnotation(pvc, ReplacedOnAnnotation, time.Now().Format(time.RFC3339))
+	return r.patchPVC(oldPVC, pvc)
+}
+
 // setPVCAnnotation sets an annotation with the specified key and value in the
 // aerospikeCluster object
 func setPVCAnnotation(pvc *v1.PersistentVolumeClaim, key, value string) {
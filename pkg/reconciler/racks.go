@@ -0,0 +1,56 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"strconv"
+
+	"k8s.io/api/core/v1"
+
+	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
+	"github.com/travelaudience/aerospike-operator/pkg/utils/selectors"
+)
+
+// getRackForPodIndex returns the rack to which the pod with the specified
+// index belongs, or nil if no racks have been specified. pods are assigned to
+// racks in a round-robin fashion, so that scaling the cluster up or down
+// (which always adds or removes the pods with the highest indexes) keeps the
+// number of pods in each rack balanced.
+func getRackForPodIndex(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, index int) *aerospikev1alpha2.AerospikeRackSpec {
+	if len(aerospikeCluster.Spec.Racks) == 0 {
+		return nil
+	}
+	return &aerospikeCluster.Spec.Racks[index%len(aerospikeCluster.Spec.Racks)]
+}
+
+// applyRack labels pod with the id of the specified rack and makes sure it
+// is scheduled according to the rack's zone and node selector.
+func applyRack(pod *v1.Pod, rack *aerospikev1alpha2.AerospikeRackSpec) {
+	if rack == nil {
+		return
+	}
+	pod.Labels[selectors.LabelRackKey] = strconv.Itoa(int(rack.ID))
+	if pod.Spec.NodeSelector == nil {
+		pod.Spec.NodeSelector = make(map[string]string)
+	}
+	for key, value := range rack.NodeSelector {
+		pod.Spec.NodeSelector[key] = value
+	}
+	if rack.Zone != "" {
+		pod.Spec.NodeSelector[zoneLabel] = rack.Zone
+	}
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/api/core/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/common"
+	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
+	"github.com/travelaudience/aerospike-operator/pkg/errors"
+	"github.com/travelaudience/aerospike-operator/pkg/logfields"
+	"github.com/travelaudience/aerospike-operator/pkg/meta"
+	"github.com/travelaudience/aerospike-operator/pkg/utils/annotations"
+	"github.com/travelaudience/aerospike-operator/pkg/utils/events"
+	"github.com/travelaudience/aerospike-operator/pkg/versioning"
+)
+
+// getFailedUpgradeVersions returns the version the specified cluster was
+// running before its failed upgrade and the version it failed to be upgraded
+// to.
+func getFailedUpgradeVersions(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) (string, string) {
+	// the version the upgrade failed to is only recorded in an annotation
+	// once the cluster has started rolling back
+	if v, ok := aerospikeCluster.Annotations[annotations.FailedUpgradeVersionAnnotationKey]; ok {
+		return aerospikeCluster.Status.Version, v
+	}
+	return aerospikeCluster.Status.Version, aerospikeCluster.Spec.Version
+}
+
+// recoverCluster performs the recovery action requested in .spec.recovery on
+// the specified cluster, whose upgrade has failed, after making sure that
+// each running aerospike node is either running the source or the target
+// version of the upgrade. it returns whether the action has been performed,
+// in which case the cluster can be reconciled again.
+func (r *AerospikeClusterReconciler) recoverCluster(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) (*aerospikev1alpha2.AerospikeCluster, bool, error) {
+	source, target := getFailedUpgradeVersions(aerospikeCluster)
+
+	// validate the version of aerospike server running on each node
+	nodeVersions, valid, err := r.validateNodeVersions(aerospikeCluster, source, target)
+	if err != nil {
+		return nil, false, err
+	}
+	if !valid {
+		if _, err := r.signalRecoveryFailed(aerospikeCluster, nodeVersions); err != nil {
+			return nil, false, err
+		}
+		return aerospikeCluster, false, nil
+	}
+
+	// grab a copy of aerospikeCluster in its current state so we can later
+	// create a patch
+	oldCluster := aerospikeCluster.DeepCopy()
+
+	var message string
+	switch aerospikeCluster.Spec.Recovery.Action {
+	case common.RecoveryActionResume:
+		// make the pre-upgrade backups again unless they have finished
+		finished, err := r.isUpgradeBackupFinished(aerospikeCluster, source, target)
+		if err != nil && err != errors.ClusterBackupFailed && !k8serrors.IsNotFound(err) {
+			return nil, false, err
+		}
+		if finished {
+			setAerospikeClusterAnnotation(aerospikeCluster, annotations.UpgradeStatusAnnotationKey, annotations.UpgradeStatusStartedAnnotationValue)
+		} else {
+			// wait for the unfinished backups to be gone before making them
+			// again, so that they are not mistaken for the new ones
+			if deleted, err := r.deleteUnfinishedUpgradeBackups(aerospikeCluster, source, target); err != nil {
+				return nil, false, err
+			} else if deleted {
+				return nil, false, errors.NewRequeueError(backupDeletionRequeuePeriod, "waiting for the unfinished pre-upgrade backups of cluster %s to be deleted", meta.Key(aerospikeCluster))
+			}
+			removeAerospikeClusterAnnotation(aerospikeCluster, annotations.UpgradeStatusAnnotationKey)
+		}
+		removeAerospikeClusterAnnotation(aerospikeCluster, annotations.FailedUpgradeVersionAnnotationKey)
+		aerospikeCluster.Spec.Version = target
+		message = fmt.Sprintf("resumed upgrade from version %s to %s (%s)", source, target, nodeVersions)
+	case common.RecoveryActionAbort:
+		// roll the cluster back to the source version
+		setAerospikeClusterAnnotation(aerospikeCluster, annotations.UpgradeStatusAnnotationKey, annotations.UpgradeStatusRollbackAnnotationValue)
+		setAerospikeClusterAnnotation(aerospikeCluster, annotations.FailedUpgradeVersionAnnotationKey, target)
+		aerospikeCluster.Spec.Version = source
+		message = fmt.Sprintf("aborted upgrade from version %s to %s (%s)", source, target, nodeVersions)
+	default:
+		if _, err := r.signalRecoveryFailed(aerospikeCluster, fmt.Sprintf("unsupported recovery action %q", aerospikeCluster.Spec.Recovery.Action)); err != nil {
+			return nil, false, err
+		}
+		return aerospikeCluster, false, nil
+	}
+
+	appendCondition(aerospikeCluster, apiextensions.CustomResourceDefinitionCondition{
+		Type:               common.ConditionRecoveryFinished,
+		Status:             apiextensions.ConditionTrue,
+		Reason:             events.ReasonClusterRecoveryFinished,
+		Message:            message,
+		LastTransitionTime: metav1.NewTime(time.Now()),
+	})
+	aerospikeCluster.Spec.Recovery = nil
+
+	if err := r.patchCluster(oldCluster, aerospikeCluster); err != nil {
+		return nil, false, err
+	}
+
+	r.recorder.Eventf(aerospikeCluster, v1.EventTypeNormal, events.ReasonClusterRecoveryFinished, "%s", message)
+
+	log.WithFields(log.Fields{
+		logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+	}).Info(message)
+
+	return aerospikeCluster, true, nil
+}
+
+// validateNodeVersions checks whether every running aerospike node of the
+// specified cluster is running either the source or the target version. it
+// returns a summary of the version running on each node or, if the check
+// fails, a description of the offending node.
+func (r *AerospikeClusterReconciler) validateNodeVersions(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, source, target string) (string, bool, error) {
+	sourceVersion, err := versioning.NewVersionFromString(source)
+	if err != nil {
+		return "", false, err
+	}
+	targetVersion, err := versioning.NewVersionFromString(target)
+	if err != nil {
+		return "", false, err
+	}
+
+	pods, err := r.listClusterPods(aerospikeCluster)
+	if err != nil {
+		return "", false, err
+	}
+	res := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		// pods that are not running will be re-created
+		if !isPodRunningAndReady(pod) {
+			res = append(res, fmt.Sprintf("%s: not running", pod.Name))
+			continue
+		}
+		version, err := getAerospikeServerVersionFromPod(pod)
+		if err != nil {
+			return "", false, err
+		}
+		if !version.Equals(sourceVersion) && !version.Equals(targetVersion) {
+			return fmt.Sprintf("pod %s is running version %s, which is neither %s nor %s", meta.Key(pod), version, source, target), false, nil
+		}
+		res = append(res, fmt.Sprintf("%s: %s", pod.Name, version))
+	}
+	return strings.Join(res, ", "), true, nil
+}
+
+func (r *AerospikeClusterReconciler) signalRecoveryFailed(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, reason string) (*aerospikev1alpha2.AerospikeCluster, error) {
+	// grab a copy of aerospikeCluster in its current state so we can later
+	// create a patch
+	oldCluster := aerospikeCluster.DeepCopy()
+
+	action := aerospikeCluster.Spec.Recovery.Action
+	appendCondition(aerospikeCluster, apiextensions.CustomResourceDefinitionCondition{
+		Type:               common.ConditionRecoveryFailed,
+		Status:             apiextensions.ConditionTrue,
+		Reason:             events.ReasonClusterRecoveryFailed,
+		Message:            fmt.Sprintf("recovery action %s failed: %s", action, reason),
+		LastTransitionTime: metav1.NewTime(time.Now()),
+	})
+	// the recovery action is discarded so that it can be requested again
+	aerospikeCluster.Spec.Recovery = nil
+
+	if err := r.patchCluster(oldCluster, aerospikeCluster); err != nil {
+		return nil, err
+	}
+
+	r.recorder.Eventf(aerospikeCluster, v1.EventTypeWarning, events.ReasonClusterRecoveryFailed,
+		"recovery action %s failed: %s", action, reason)
+
+	log.WithFields(log.Fields{
+		logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+	}).Warnf("recovery action %s failed: %s", action, reason)
+
+	return aerospikeCluster, nil
+}
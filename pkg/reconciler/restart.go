@@ -0,0 +1,140 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/api/core/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/common"
+	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
+	"github.com/travelaudience/aerospike-operator/pkg/logfields"
+	"github.com/travelaudience/aerospike-operator/pkg/meta"
+	"github.com/travelaudience/aerospike-operator/pkg/utils/events"
+)
+
+// isRestartRequested indicates whether a rolling restart of the specified
+// Aerospike cluster has been requested (i.e. whether restartedAt has changed
+// since the cluster was last successfully reconciled).
+func isRestartRequested(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) bool {
+	// a newly created cluster does not need to be restarted
+	if aerospikeCluster.Status.Version == "" {
+		return false
+	}
+	spec, status := aerospikeCluster.Spec.RestartedAt, aerospikeCluster.Status.RestartedAt
+	if spec == nil || status == nil {
+		return spec != status
+	}
+	return !spec.Equal(status)
+}
+
+func (r *AerospikeClusterReconciler) signalRestartStarted(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) (*aerospikev1alpha2.AerospikeCluster, error) {
+	// grab a copy of aerospikeCluster in its current state so we can later
+	// create a patch
+	oldCluster := aerospikeCluster.DeepCopy()
+
+	aerospikeCluster.Status.RestartGeneration++
+	aerospikeCluster.Status.RestartedNodes = 0
+	appendCondition(aerospikeCluster, apiextensions.CustomResourceDefinitionCondition{
+		Type:               common.ConditionClusterRestartStarted,
+		Status:             apiextensions.ConditionTrue,
+		Reason:             events.ReasonClusterRestartStarted,
+		Message:            fmt.Sprintf("rolling restart %d started", aerospikeCluster.Status.RestartGeneration),
+		LastTransitionTime: metav1.NewTime(time.Now()),
+	})
+	setAerospikeClusterAnnotation(aerospikeCluster, RestartStatusAnnotationKey, RestartStatusStartedAnnotationValue)
+
+	if err := r.patchCluster(oldCluster, aerospikeCluster); err != nil {
+		return nil, err
+	}
+
+	r.recorder.Eventf(aerospikeCluster, v1.EventTypeNormal, events.ReasonClusterRestartStarted,
+		"rolling restart %d started", aerospikeCluster.Status.RestartGeneration)
+
+	log.WithFields(log.Fields{
+		logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+	}).Infof("rolling restart %d started", aerospikeCluster.Status.RestartGeneration)
+
+	return aerospikeCluster, nil
+}
+
+func (r *AerospikeClusterReconciler) signalNodeRestarted(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, configMap *v1.ConfigMap, pod *v1.Pod) error {
+	// grab a copy of aerospikeCluster in its current state so we can later
+	// create a patch
+	oldCluster := aerospikeCluster.DeepCopy()
+
+	// count the pods that have already been restarted with the current
+	// configmap hash
+	pods, err := r.listClusterPods(aerospikeCluster)
+	if err != nil {
+		return err
+	}
+	restartedNodes := int32(0)
+	for _, p := range pods {
+		if p.Annotations[configMapHashAnnotation] == configMap.Annotations[configMapHashAnnotation] {
+			restartedNodes++
+		}
+	}
+	aerospikeCluster.Status.RestartedNodes = restartedNodes
+
+	if err := r.patchCluster(oldCluster, aerospikeCluster); err != nil {
+		return err
+	}
+
+	r.recorder.Eventf(aerospikeCluster, v1.EventTypeNormal, events.ReasonNodeRestarted,
+		"pod %s restarted (%d/%d)", meta.Key(pod), restartedNodes, aerospikeCluster.Spec.NodeCount)
+
+	log.WithFields(log.Fields{
+		logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+		logfields.Pod:              meta.Key(pod),
+	}).Debugf("pod restarted (%d/%d)", restartedNodes, aerospikeCluster.Spec.NodeCount)
+
+	return nil
+}
+
+func (r *AerospikeClusterReconciler) signalRestartFinished(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) (*aerospikev1alpha2.AerospikeCluster, error) {
+	// grab a copy of aerospikeCluster in its current state so we can later
+	// create a patch
+	oldCluster := aerospikeCluster.DeepCopy()
+
+	appendCondition(aerospikeCluster, apiextensions.CustomResourceDefinitionCondition{
+		Type:               common.ConditionClusterRestartFinished,
+		Status:             apiextensions.ConditionTrue,
+		Reason:             events.ReasonClusterRestartFinished,
+		Message:            fmt.Sprintf("rolling restart %d finished", aerospikeCluster.Status.RestartGeneration),
+		LastTransitionTime: metav1.NewTime(time.Now()),
+	})
+	removeAerospikeClusterAnnotation(aerospikeCluster, RestartStatusAnnotationKey)
+
+	if err := r.patchCluster(oldCluster, aerospikeCluster); err != nil {
+		return nil, err
+	}
+
+	r.recorder.Eventf(aerospikeCluster, v1.EventTypeNormal, events.ReasonClusterRestartFinished,
+		"rolling restart %d finished", aerospikeCluster.Status.RestartGeneration)
+
+	log.WithFields(log.Fields{
+		logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+	}).Infof("rolling restart %d finished", aerospikeCluster.Status.RestartGeneration)
+
+	return aerospikeCluster, nil
+}
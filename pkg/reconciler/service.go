@@ -24,6 +24,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
+	"github.com/travelaudience/aerospike-operator/pkg/asutils"
 	"github.com/travelaudience/aerospike-operator/pkg/crd"
 	"github.com/travelaudience/aerospike-operator/pkg/logfields"
 	"github.com/travelaudience/aerospike-operator/pkg/meta"
@@ -59,7 +60,7 @@ func (r *AerospikeClusterReconciler) ensureService(aerospikeCluster *aerospikev1
 			Ports: []v1.ServicePort{
 				{
 					Name:       servicePortName,
-					Port:       ServicePort,
+					Port:       asutils.ServicePort,
 					TargetPort: intstr.IntOrString{StrVal: servicePortName},
 				},
 				{
@@ -19,15 +19,20 @@ package reconciler
 import (
 	"encoding/json"
 	"reflect"
+	"strconv"
+	"time"
 
 	log "github.com/sirupsen/logrus"
+	"k8s.io/api/core/v1"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
 
 	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
+	"github.com/travelaudience/aerospike-operator/pkg/asutils"
 	"github.com/travelaudience/aerospike-operator/pkg/logfields"
 	"github.com/travelaudience/aerospike-operator/pkg/meta"
+	"github.com/travelaudience/aerospike-operator/pkg/utils/selectors"
 )
 
 // updateStatus updates the status of aerospikeCluster to match the spec.
@@ -36,9 +41,124 @@ func (r *AerospikeClusterReconciler) updateStatus(aerospikeCluster *aerospikev1a
 	// update status to match the spec - the correctness of this is ensured by
 	// the reconcile loop
 	aerospikeCluster.Status.BackupSpec = aerospikeCluster.Spec.BackupSpec
+	aerospikeCluster.Status.AerospikeConfig = aerospikeCluster.Spec.AerospikeConfig
 	aerospikeCluster.Status.Namespaces = aerospikeCluster.Spec.Namespaces
 	aerospikeCluster.Status.NodeCount = aerospikeCluster.Spec.NodeCount
 	aerospikeCluster.Status.Version = aerospikeCluster.Spec.Version
+	aerospikeCluster.Status.Resources = aerospikeCluster.Spec.Resources
+	aerospikeCluster.Status.PodSpec = aerospikeCluster.Spec.PodSpec
+	aerospikeCluster.Status.Racks = aerospikeCluster.Spec.Racks
+	aerospikeCluster.Status.Images = aerospikeCluster.Spec.Images
+	aerospikeCluster.Status.ExternalAccess = aerospikeCluster.Spec.ExternalAccess
+	aerospikeCluster.Status.Edition = aerospikeCluster.Spec.Edition
+	aerospikeCluster.Status.FeatureKeySecret = aerospikeCluster.Spec.FeatureKeySecret
+	aerospikeCluster.Status.Paused = aerospikeCluster.Spec.Paused
+	aerospikeCluster.Status.NodesUnderMaintenance = aerospikeCluster.Spec.NodesUnderMaintenance
+	aerospikeCluster.Status.RestartedAt = aerospikeCluster.Spec.RestartedAt
+	aerospikeCluster.Status.MaxSurge = aerospikeCluster.Spec.MaxSurge
+	aerospikeCluster.Status.OnUpgradeFailure = aerospikeCluster.Spec.OnUpgradeFailure
+	aerospikeCluster.Status.Recovery = aerospikeCluster.Spec.Recovery
+	// report the generation that has just been reconciled, as well as the
+	// selector used by the scale subresource
+	aerospikeCluster.Status.ObservedGeneration = aerospikeCluster.Generation
+	aerospikeCluster.Status.Selector = selectors.ResourcesByClusterName(aerospikeCluster.Name).String()
+}
+
+// updateNodeStatus updates the status of aerospikeCluster to reflect the
+// observed state of each of its Aerospike nodes. Unlike updateStatus, this
+// method may be called at any time.
+func (r *AerospikeClusterReconciler) updateNodeStatus(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) error {
+	pods, err := r.listClusterPods(aerospikeCluster)
+	if err != nil {
+		return err
+	}
+	nodes := make([]aerospikev1alpha2.AerospikeNodeStatus, 0, len(pods))
+	readyNodes := int32(0)
+	for _, pod := range pods {
+		node := getNodeStatus(pod)
+		if node.Ready {
+			readyNodes++
+		}
+		nodes = append(nodes, node)
+	}
+
+	// every patch to the cluster triggers a new reconcile, so if only the
+	// statistics of the aerospike nodes have changed (as is the case during
+	// migrations) we report them at most once every nodeStatisticsReportPeriod
+	key := meta.Key(aerospikeCluster)
+	r.nodeStatisticsMutex.Lock()
+	defer r.nodeStatisticsMutex.Unlock()
+	if reflect.DeepEqual(withoutStatistics(aerospikeCluster.Status.Nodes), withoutStatistics(nodes)) && time.Since(r.nodeStatisticsReportedAt[key]) < nodeStatisticsReportPeriod {
+		return nil
+	}
+	r.nodeStatisticsReportedAt[key] = time.Now()
+
+	aerospikeCluster.Status.Nodes = nodes
+	aerospikeCluster.Status.TotalNodes = int32(len(pods))
+	aerospikeCluster.Status.ReadyNodes = readyNodes
+	return nil
+}
+
+// reportNodeStatus patches aerospikeCluster so that its status reflects the
+// observed state of each of its Aerospike nodes.
+func (r *AerospikeClusterReconciler) reportNodeStatus(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) error {
+	oldCluster := aerospikeCluster.DeepCopy()
+	if err := r.updateNodeStatus(aerospikeCluster); err != nil {
+		return err
+	}
+	return r.patchCluster(oldCluster, aerospikeCluster)
+}
+
+// withoutStatistics returns a copy of nodes from which the statistics that
+// change frequently have been cleared. only whether migrations are in
+// progress on each node is kept.
+func withoutStatistics(nodes []aerospikev1alpha2.AerospikeNodeStatus) []aerospikev1alpha2.AerospikeNodeStatus {
+	res := make([]aerospikev1alpha2.AerospikeNodeStatus, 0, len(nodes))
+	for _, node := range nodes {
+		node.ClusterKey = ""
+		if node.MigratePartitionsRemaining > 0 {
+			node.MigratePartitionsRemaining = 1
+		}
+		res = append(res, node)
+	}
+	return res
+}
+
+// getNodeStatus builds the status of the Aerospike node running in the
+// specified pod. If the pod is running and ready, the Aerospike node is
+// queried for its version and statistics.
+func getNodeStatus(pod *v1.Pod) aerospikev1alpha2.AerospikeNodeStatus {
+	node := aerospikev1alpha2.AerospikeNodeStatus{
+		PodName: pod.Name,
+		NodeID:  pod.Annotations[nodeIdAnnotation],
+		PodIP:   pod.Status.PodIP,
+		Ready:   isPodRunningAndReady(pod),
+	}
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim != nil {
+			node.PersistentVolumeClaims = append(node.PersistentVolumeClaims, volume.PersistentVolumeClaim.ClaimName)
+		}
+	}
+	if !node.Ready {
+		return node
+	}
+	res, err := runInfoCommandOnPod(pod, "build", "statistics")
+	if err != nil {
+		log.WithFields(log.Fields{
+			logfields.Pod: meta.Key(pod),
+		}).Warnf("failed to get node status: %v", err)
+		return node
+	}
+	node.Version = res["build"]
+	stats := asutils.ParseStatistics(res["statistics"])
+	if v, err := strconv.ParseInt(stats["cluster_size"], 10, 32); err == nil {
+		node.ClusterSize = int32(v)
+	}
+	node.ClusterKey = stats["cluster_key"]
+	if v, err := strconv.ParseInt(stats["migrate_partitions_remaining"], 10, 64); err == nil {
+		node.MigratePartitionsRemaining = v
+	}
+	return node
 }
 
 // patchCluster updates the aerospikecluster resource.
@@ -89,6 +209,24 @@ func appendCondition(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, condi
 	aerospikeCluster.Status.Conditions = append(aerospikeCluster.Status.Conditions, condition)
 }
 
+// setCondition sets the specified condition in the aerospikeCluster object,
+// replacing any existing condition of the same type. Unlike appendCondition,
+// it is meant for conditions that reflect the current state of the resource.
+func setCondition(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, condition apiextensions.CustomResourceDefinitionCondition) {
+	for i, c := range aerospikeCluster.Status.Conditions {
+		if c.Type != condition.Type {
+			continue
+		}
+		// keep the original transition time if the status did not change
+		if c.Status == condition.Status {
+			condition.LastTransitionTime = c.LastTransitionTime
+		}
+		aerospikeCluster.Status.Conditions[i] = condition
+		return
+	}
+	appendCondition(aerospikeCluster, condition)
+}
+
 // setAerospikeClusterAnnotation sets an annotation with the specified key and value in the
 // aerospikecluster object
 func setAerospikeClusterAnnotation(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, key, value string) {
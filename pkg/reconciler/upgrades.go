@@ -27,55 +27,53 @@ import (
 
 	"github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/common"
 	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
+	"github.com/travelaudience/aerospike-operator/pkg/errors"
 	"github.com/travelaudience/aerospike-operator/pkg/logfields"
 	"github.com/travelaudience/aerospike-operator/pkg/meta"
+	"github.com/travelaudience/aerospike-operator/pkg/utils/annotations"
 	"github.com/travelaudience/aerospike-operator/pkg/utils/events"
 	"github.com/travelaudience/aerospike-operator/pkg/versioning"
 )
 
-func (r *AerospikeClusterReconciler) maybeUpgradePodWithIndex(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, configMap *v1.ConfigMap, index int, upgrade *versioning.VersionUpgrade) (*v1.Pod, error) {
-	// check whether a pod with the specified index exists
-	pod, err := r.getPodWithIndex(aerospikeCluster, index)
-	if err != nil {
-		// we've failed to get the pod with the specified index
-		return nil, err
-	}
-	if pod == nil {
-		// no pod with the specified index exists, so we return
-		return nil, nil
-	}
+// maybeUpgradePodWithIndex safely deletes the specified pod if it is not
+// running the target version, so that it is re-created with the target
+// version in a subsequent reconcile loop.
+func (r *AerospikeClusterReconciler) maybeUpgradePodWithIndex(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, pod *v1.Pod, upgrade *versioning.VersionUpgrade) error {
 	// get the version of aerospike server running on the pod
 	version, err := getAerospikeServerVersionFromPod(pod)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	// skip the upgrade if the pod is already running the target version
-	if version == aerospikeCluster.Spec.Version {
-		return pod, nil
+	if version.Equals(upgrade.Target) {
+		return nil
 	}
 
-	log.WithFields(log.Fields{
-		logfields.AerospikeCluster: meta.Key(aerospikeCluster),
-	}).Debugf("upgrading pod %s to version %s", meta.Key(pod), aerospikeCluster.Spec.Version)
-	r.recorder.Eventf(aerospikeCluster, v1.EventTypeNormal, events.ReasonNodeUpgradeStarted,
-		"upgrading pod %s to version %s",
-		meta.Key(pod), aerospikeCluster.Spec.Version)
+	// signal the start of the upgrade unless the pod is already being
+	// upgraded (e.g. it is waiting for migrations to finish)
+	if getPodOperationWithIndex(aerospikeCluster, podIndex(pod)) == nil {
+		log.WithFields(log.Fields{
+			logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+		}).Debugf("upgrading pod %s to version %s", meta.Key(pod), aerospikeCluster.Spec.Version)
+		r.recorder.Eventf(aerospikeCluster, v1.EventTypeNormal, events.ReasonNodeUpgradeStarted,
+			"upgrading pod %s to version %s",
+			meta.Key(pod), aerospikeCluster.Spec.Version)
+	}
 
 	// restart the target pod
-	newPod, err := r.safeRestartPodWithIndex(aerospikeCluster, configMap, index, upgrade)
-	if err != nil {
-		return nil, err
-	}
+	return r.safeRestartPodWithIndex(aerospikeCluster, podIndex(pod), podOperationUpgrade)
+}
+
+// finishPodUpgrade ensures that the specified pod, which has been re-created
+// as part of an upgrade, is running the target version.
+func (r *AerospikeClusterReconciler) finishPodUpgrade(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, pod *v1.Pod, upgrade *versioning.VersionUpgrade) error {
 	// ensure the pod has the target version
-	version, err = getAerospikeServerVersionFromPod(newPod)
+	version, err := getAerospikeServerVersionFromPod(pod)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	if version != aerospikeCluster.Spec.Version {
-		r.recorder.Eventf(aerospikeCluster, v1.EventTypeNormal, events.ReasonNodeUpgradeFailed,
-			"failed to upgrade pod %s to version %s",
-			meta.Key(pod), aerospikeCluster.Spec.Version)
-		return nil, fmt.Errorf("failed to upgrade pod %s to version %s", meta.Key(newPod), aerospikeCluster.Spec.Version)
+	if !version.Equals(upgrade.Target) {
+		return r.failPodUpgrade(aerospikeCluster, pod)
 	}
 
 	log.WithFields(log.Fields{
@@ -85,7 +83,73 @@ func (r *AerospikeClusterReconciler) maybeUpgradePodWithIndex(aerospikeCluster *
 		"upgraded pod %s to version %s",
 		meta.Key(pod), aerospikeCluster.Spec.Version)
 
-	return newPod, nil
+	return nil
+}
+
+// isPodUpgradeFailing indicates whether the specified pod has been re-created
+// as part of the specified upgrade, in which case failing to start it means
+// that the upgrade has failed.
+func isPodUpgradeFailing(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, pod *v1.Pod, upgrade *versioning.VersionUpgrade) bool {
+	if upgrade == nil {
+		return false
+	}
+	op := getPodOperationWithIndex(aerospikeCluster, podIndex(pod))
+	return op != nil && op.Type == podOperationUpgrade && op.PodDeleted
+}
+
+// failPodUpgrade reports the failure to upgrade the specified pod and deletes
+// it, recording an upgrade operation so that it is re-created following the
+// upgrade strategy once the cluster is rolled back. it always returns
+// errors.PodUpgradeFailed.
+func (r *AerospikeClusterReconciler) failPodUpgrade(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, pod *v1.Pod) error {
+	r.recorder.Eventf(aerospikeCluster, v1.EventTypeWarning, events.ReasonNodeUpgradeFailed,
+		"failed to upgrade pod %s to version %s",
+		meta.Key(pod), aerospikeCluster.Spec.Version)
+	log.WithFields(log.Fields{
+		logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+		logfields.Pod:              meta.Key(pod),
+	}).Warnf("failed to upgrade pod %s to version %s", meta.Key(pod), aerospikeCluster.Spec.Version)
+
+	if err := r.setPodOperation(aerospikeCluster, &podOperation{Type: podOperationUpgrade, Index: podIndex(pod)}); err != nil {
+		return err
+	}
+	if err := r.deletePod(aerospikeCluster, pod); err != nil {
+		return err
+	}
+	return errors.PodUpgradeFailed
+}
+
+// isRollingBack indicates whether the specified AerospikeCluster resource is
+// being rolled back to the version it was running before a failed upgrade.
+func isRollingBack(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) bool {
+	return aerospikeCluster.Annotations[annotations.UpgradeStatusAnnotationKey] == annotations.UpgradeStatusRollbackAnnotationValue
+}
+
+// getRollback returns the version upgrade that reverts the failed upgrade
+// recorded in the specified AerospikeCluster resource. the returned object is
+// not a valid upgrade, and so getUpgradeStrategy must be used to get the
+// corresponding strategy.
+func getRollback(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) (*versioning.VersionUpgrade, error) {
+	source, err := versioning.NewVersionFromString(aerospikeCluster.Annotations[annotations.FailedUpgradeVersionAnnotationKey])
+	if err != nil {
+		return nil, err
+	}
+	target, err := versioning.NewVersionFromString(aerospikeCluster.Spec.Version)
+	if err != nil {
+		return nil, err
+	}
+	return &versioning.VersionUpgrade{Source: source, Target: target}, nil
+}
+
+// getUpgradeStrategy returns the strategy to follow when re-creating pods as
+// part of the specified upgrade. rolling back an upgrade follows the strategy
+// of the upgrade being reverted, so that persistent volume claims are
+// re-created whenever the data format differs between both versions.
+func getUpgradeStrategy(upgrade *versioning.VersionUpgrade) (*versioning.UpgradeStrategy, error) {
+	if upgrade.IsValid() {
+		return upgrade.GetStrategy()
+	}
+	return versioning.VersionUpgrade{Source: upgrade.Target, Target: upgrade.Source}.GetStrategy()
 }
 
 func (r *AerospikeClusterReconciler) signalBackupStarted(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) (*aerospikev1alpha2.AerospikeCluster, error) {
@@ -100,7 +164,7 @@ func (r *AerospikeClusterReconciler) signalBackupStarted(aerospikeCluster *aeros
 		Message:            "cluster backup started",
 		LastTransitionTime: metav1.NewTime(time.Now()),
 	})
-	setAerospikeClusterAnnotation(aerospikeCluster, UpgradeStatusAnnotationKey, UpgradeStatusBackupAnnotationValue)
+	setAerospikeClusterAnnotation(aerospikeCluster, annotations.UpgradeStatusAnnotationKey, annotations.UpgradeStatusBackupAnnotationValue)
 
 	if err := r.patchCluster(oldCluster, aerospikeCluster); err != nil {
 		return nil, err
@@ -182,7 +246,7 @@ func (r *AerospikeClusterReconciler) signalUpgradeStarted(aerospikeCluster *aero
 		Message:            fmt.Sprintf("upgrade from version %s to %s started", upgrade.Source, upgrade.Target),
 		LastTransitionTime: metav1.NewTime(time.Now()),
 	})
-	setAerospikeClusterAnnotation(aerospikeCluster, UpgradeStatusAnnotationKey, UpgradeStatusStartedAnnotationValue)
+	setAerospikeClusterAnnotation(aerospikeCluster, annotations.UpgradeStatusAnnotationKey, annotations.UpgradeStatusStartedAnnotationValue)
 
 	if err := r.patchCluster(oldCluster, aerospikeCluster); err != nil {
 		return nil, err
@@ -210,7 +274,19 @@ func (r *AerospikeClusterReconciler) signalUpgradeFailed(aerospikeCluster *aeros
 		Message:            fmt.Sprintf("upgrade from version %s to %s failed", upgrade.Source, upgrade.Target),
 		LastTransitionTime: metav1.NewTime(time.Now()),
 	})
-	setAerospikeClusterAnnotation(aerospikeCluster, UpgradeStatusAnnotationKey, UpgradeStatusFailedAnnotationValue)
+	setAerospikeClusterAnnotation(aerospikeCluster, annotations.UpgradeStatusAnnotationKey, annotations.UpgradeStatusFailedAnnotationValue)
+	aerospikeCluster.Status.Phase = common.ClusterPhaseFailed
+	// record that the cluster will not be managed anymore unless the policy
+	// specifies otherwise
+	if aerospikeCluster.Spec.GetOnUpgradeFailure() == common.UpgradeFailurePolicyHalt {
+		appendCondition(aerospikeCluster, apiextensions.CustomResourceDefinitionCondition{
+			Type:               common.ConditionUpgradeHalted,
+			Status:             apiextensions.ConditionTrue,
+			Reason:             events.ReasonClusterUpgradeHalted,
+			Message:            fmt.Sprintf("cluster halted after failing to upgrade from version %s to %s", upgrade.Source, upgrade.Target),
+			LastTransitionTime: metav1.NewTime(time.Now()),
+		})
+	}
 
 	if err := r.patchCluster(oldCluster, aerospikeCluster); err != nil {
 		return nil, err
@@ -239,7 +315,7 @@ func (r *AerospikeClusterReconciler) signalUpgradeFinished(aerospikeCluster *aer
 		Message:            fmt.Sprintf("finished upgrade from version %s to %s", upgrade.Source, upgrade.Target),
 		LastTransitionTime: metav1.NewTime(time.Now()),
 	})
-	removeAerospikeClusterAnnotation(aerospikeCluster, UpgradeStatusAnnotationKey)
+	removeAerospikeClusterAnnotation(aerospikeCluster, annotations.UpgradeStatusAnnotationKey)
 
 	if err := r.patchCluster(oldCluster, aerospikeCluster); err != nil {
 		return nil, err
@@ -254,3 +330,192 @@ func (r *AerospikeClusterReconciler) signalUpgradeFinished(aerospikeCluster *aer
 
 	return aerospikeCluster, nil
 }
+
+func (r *AerospikeClusterReconciler) signalRollbackStarted(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) (*aerospikev1alpha2.AerospikeCluster, error) {
+	// grab a copy of aerospikeCluster in its current state so we can later
+	// create a patch
+	oldCluster := aerospikeCluster.DeepCopy()
+
+	// revert .spec.version to the version the cluster was running before the
+	// upgrade, and remember the version to which the upgrade failed
+	source, target := aerospikeCluster.Status.Version, aerospikeCluster.Spec.Version
+	appendCondition(aerospikeCluster, apiextensions.CustomResourceDefinitionCondition{
+		Type:               common.ConditionUpgradeRollbackStarted,
+		Status:             apiextensions.ConditionTrue,
+		Reason:             events.ReasonClusterUpgradeRollbackStarted,
+		Message:            fmt.Sprintf("rollback from version %s to %s started", target, source),
+		LastTransitionTime: metav1.NewTime(time.Now()),
+	})
+	setAerospikeClusterAnnotation(aerospikeCluster, annotations.UpgradeStatusAnnotationKey, annotations.UpgradeStatusRollbackAnnotationValue)
+	setAerospikeClusterAnnotation(aerospikeCluster, annotations.FailedUpgradeVersionAnnotationKey, target)
+	aerospikeCluster.Spec.Version = source
+
+	if err := r.patchCluster(oldCluster, aerospikeCluster); err != nil {
+		return nil, err
+	}
+
+	r.recorder.Eventf(aerospikeCluster, v1.EventTypeNormal, events.ReasonClusterUpgradeRollbackStarted,
+		"rollback from version %s to %s started", target, source)
+
+	log.WithFields(log.Fields{
+		logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+	}).Debugf("rollback from version %s to %s started", target, source)
+
+	return aerospikeCluster, nil
+}
+
+func (r *AerospikeClusterReconciler) signalRollbackFailed(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, upgrade *versioning.VersionUpgrade) (*aerospikev1alpha2.AerospikeCluster, error) {
+	// grab a copy of aerospikeCluster in its current state so we can later
+	// create a patch
+	oldCluster := aerospikeCluster.DeepCopy()
+
+	appendCondition(aerospikeCluster, apiextensions.CustomResourceDefinitionCondition{
+		Type:               common.ConditionUpgradeRollbackFailed,
+		Status:             apiextensions.ConditionTrue,
+		Reason:             events.ReasonClusterUpgradeRollbackFailed,
+		Message:            fmt.Sprintf("rollback from version %s to %s failed", upgrade.Source, upgrade.Target),
+		LastTransitionTime: metav1.NewTime(time.Now()),
+	})
+	appendCondition(aerospikeCluster, apiextensions.CustomResourceDefinitionCondition{
+		Type:               common.ConditionUpgradeHalted,
+		Status:             apiextensions.ConditionTrue,
+		Reason:             events.ReasonClusterUpgradeHalted,
+		Message:            fmt.Sprintf("cluster halted after failing to roll back from version %s to %s", upgrade.Source, upgrade.Target),
+		LastTransitionTime: metav1.NewTime(time.Now()),
+	})
+	setAerospikeClusterAnnotation(aerospikeCluster, annotations.UpgradeStatusAnnotationKey, annotations.UpgradeStatusHaltedAnnotationValue)
+	aerospikeCluster.Status.Phase = common.ClusterPhaseFailed
+
+	if err := r.patchCluster(oldCluster, aerospikeCluster); err != nil {
+		return nil, err
+	}
+
+	r.recorder.Eventf(aerospikeCluster, v1.EventTypeWarning, events.ReasonClusterUpgradeRollbackFailed,
+		"rollback from version %s to %s failed", upgrade.Source, upgrade.Target)
+
+	log.WithFields(log.Fields{
+		logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+	}).Debugf("rollback from version %s to %s failed", upgrade.Source, upgrade.Target)
+
+	return aerospikeCluster, nil
+}
+
+// signalRollbackFinished records the end of the rollback of the specified
+// cluster. if the policy is "restore", it additionally records whether the
+// restore of its namespaces has started, in which case the annotations are
+// kept until it finishes.
+func (r *AerospikeClusterReconciler) signalRollbackFinished(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, upgrade *versioning.VersionUpgrade, restoreStarted bool) (*aerospikev1alpha2.AerospikeCluster, error) {
+	// grab a copy of aerospikeCluster in its current state so we can later
+	// create a patch
+	oldCluster := aerospikeCluster.DeepCopy()
+
+	appendCondition(aerospikeCluster, apiextensions.CustomResourceDefinitionCondition{
+		Type:               common.ConditionUpgradeRollbackFinished,
+		Status:             apiextensions.ConditionTrue,
+		Reason:             events.ReasonClusterUpgradeRollbackFinished,
+		Message:            fmt.Sprintf("finished rollback from version %s to %s", upgrade.Source, upgrade.Target),
+		LastTransitionTime: metav1.NewTime(time.Now()),
+	})
+	restore := aerospikeCluster.Spec.GetOnUpgradeFailure() == common.UpgradeFailurePolicyRestore
+	switch {
+	case restore && restoreStarted:
+		appendCondition(aerospikeCluster, apiextensions.CustomResourceDefinitionCondition{
+			Type:               common.ConditionAutoRestoreStarted,
+			Status:             apiextensions.ConditionTrue,
+			Reason:             events.ReasonClusterAutoRestoreStarted,
+			Message:            "cluster restore started",
+			LastTransitionTime: metav1.NewTime(time.Now()),
+		})
+		setAerospikeClusterAnnotation(aerospikeCluster, annotations.UpgradeStatusAnnotationKey, annotations.UpgradeStatusRestoreAnnotationValue)
+	case restore:
+		appendCondition(aerospikeCluster, apiextensions.CustomResourceDefinitionCondition{
+			Type:               common.ConditionAutoRestoreFailed,
+			Status:             apiextensions.ConditionTrue,
+			Reason:             events.ReasonClusterAutoRestoreFailed,
+			Message:            "cluster restore failed: the pre-upgrade backups are not available",
+			LastTransitionTime: metav1.NewTime(time.Now()),
+		})
+		fallthrough
+	default:
+		removeAerospikeClusterAnnotation(aerospikeCluster, annotations.UpgradeStatusAnnotationKey)
+		removeAerospikeClusterAnnotation(aerospikeCluster, annotations.FailedUpgradeVersionAnnotationKey)
+	}
+
+	if err := r.patchCluster(oldCluster, aerospikeCluster); err != nil {
+		return nil, err
+	}
+
+	r.recorder.Eventf(aerospikeCluster, v1.EventTypeNormal, events.ReasonClusterUpgradeRollbackFinished,
+		"finished rollback from version %s to %s", upgrade.Source, upgrade.Target)
+	if restore && restoreStarted {
+		r.recorder.Eventf(aerospikeCluster, v1.EventTypeNormal, events.ReasonClusterAutoRestoreStarted,
+			"cluster restore started")
+	} else if restore {
+		r.recorder.Eventf(aerospikeCluster, v1.EventTypeWarning, events.ReasonClusterAutoRestoreFailed,
+			"cluster restore failed: the pre-upgrade backups are not available")
+	}
+
+	log.WithFields(log.Fields{
+		logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+	}).Debugf("finished rollback from version %s to %s", upgrade.Source, upgrade.Target)
+
+	return aerospikeCluster, nil
+}
+
+func (r *AerospikeClusterReconciler) signalAutoRestoreFinished(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) (*aerospikev1alpha2.AerospikeCluster, error) {
+	// grab a copy of aerospikeCluster in its current state so we can later
+	// create a patch
+	oldCluster := aerospikeCluster.DeepCopy()
+
+	appendCondition(aerospikeCluster, apiextensions.CustomResourceDefinitionCondition{
+		Type:               common.ConditionAutoRestoreFinished,
+		Status:             apiextensions.ConditionTrue,
+		Reason:             events.ReasonClusterAutoRestoreFinished,
+		Message:            "cluster restore finished",
+		LastTransitionTime: metav1.NewTime(time.Now()),
+	})
+	removeAerospikeClusterAnnotation(aerospikeCluster, annotations.UpgradeStatusAnnotationKey)
+	removeAerospikeClusterAnnotation(aerospikeCluster, annotations.FailedUpgradeVersionAnnotationKey)
+
+	if err := r.patchCluster(oldCluster, aerospikeCluster); err != nil {
+		return nil, err
+	}
+
+	r.recorder.Eventf(aerospikeCluster, v1.EventTypeNormal, events.ReasonClusterAutoRestoreFinished,
+		"cluster restore finished")
+
+	log.WithFields(log.Fields{
+		logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+	}).Debugf("cluster restore finished")
+
+	return aerospikeCluster, nil
+}
+
+func (r *AerospikeClusterReconciler) signalAutoRestoreFailed(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) (*aerospikev1alpha2.AerospikeCluster, error) {
+	// grab a copy of aerospikeCluster in its current state so we can later
+	// create a patch
+	oldCluster := aerospikeCluster.DeepCopy()
+
+	appendCondition(aerospikeCluster, apiextensions.CustomResourceDefinitionCondition{
+		Type:               common.ConditionAutoRestoreFailed,
+		Status:             apiextensions.ConditionTrue,
+		Reason:             events.ReasonClusterAutoRestoreFailed,
+		Message:            "cluster restore failed",
+		LastTransitionTime: metav1.NewTime(time.Now()),
+	})
+	removeAerospikeClusterAnnotation(aerospikeCluster, annotations.UpgradeStatusAnnotationKey)
+	removeAerospikeClusterAnnotation(aerospikeCluster, annotations.FailedUpgradeVersionAnnotationKey)
+
+	if err := r.patchCluster(oldCluster, aerospikeCluster); err != nil {
+		return nil, err
+	}
+
+	r.recorder.Eventf(aerospikeCluster, v1.EventTypeWarning, events.ReasonClusterAutoRestoreFailed,
+		"cluster restore failed")
+
+	log.WithFields(log.Fields{
+		logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+	}).Debugf("cluster restore failed")
+
+	return aerospikeCluster, nil
+}
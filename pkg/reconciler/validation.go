@@ -17,58 +17,113 @@ limitations under the License.
 package reconciler
 
 import (
-	"k8s.io/api/core/v1"
+	"fmt"
+	"time"
 
+	log "github.com/sirupsen/logrus"
+	"k8s.io/api/core/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/common"
 	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
+	"github.com/travelaudience/aerospike-operator/pkg/logfields"
+	"github.com/travelaudience/aerospike-operator/pkg/meta"
 	"github.com/travelaudience/aerospike-operator/pkg/utils/events"
 )
 
+// validate validates the fields of aerospikeCluster that cannot be validated
+// statically. If validation fails, the reason is reflected in the status of
+// the resource (as well as through an event) and false is returned.
 func (r *AerospikeClusterReconciler) validate(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) (bool, error) {
-	if !r.validateReplicationFactor(aerospikeCluster) {
-		return false, nil
+	validationErr := r.validateReplicationFactor(aerospikeCluster)
+	if validationErr == nil {
+		validationErr = r.validateStorageClass(aerospikeCluster)
 	}
-	if !r.validateStorageClass(aerospikeCluster) {
+	if validationErr != nil {
+		if _, err := r.signalInvalid(aerospikeCluster, validationErr); err != nil {
+			return false, err
+		}
 		return false, nil
 	}
+	if _, err := r.signalValid(aerospikeCluster); err != nil {
+		return false, err
+	}
 	return true, nil
 }
 
-func (r *AerospikeClusterReconciler) validateReplicationFactor(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) bool {
+func (r *AerospikeClusterReconciler) validateReplicationFactor(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) error {
 	for _, ns := range aerospikeCluster.Spec.Namespaces {
 		if ns.ReplicationFactor != nil && *ns.ReplicationFactor > aerospikeCluster.Spec.NodeCount {
-			r.recorder.Eventf(aerospikeCluster, v1.EventTypeWarning, events.ReasonValidationError,
-				"replication factor of %d requested for namespace %s but the cluster has only %d nodes",
-				ns.ReplicationFactor,
+			return fmt.Errorf("replication factor of %d requested for namespace %s but the cluster has only %d nodes",
+				*ns.ReplicationFactor,
 				ns.Name,
 				aerospikeCluster.Spec.NodeCount,
 			)
-			return false
 		}
 	}
-	return true
+	return nil
 }
 
-func (r *AerospikeClusterReconciler) validateStorageClass(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) bool {
+func (r *AerospikeClusterReconciler) validateStorageClass(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) error {
 	for _, ns := range aerospikeCluster.Spec.Namespaces {
 		if ns.Storage.StorageClassName != nil && *ns.Storage.StorageClassName != "" {
 			if _, err := r.scsLister.Get(*ns.Storage.StorageClassName); err != nil {
 				if errors.IsNotFound(err) {
-					r.recorder.Eventf(aerospikeCluster, v1.EventTypeWarning, events.ReasonValidationError,
-						"storage class %q does not exist",
-						ns.Storage.StorageClassName,
-					)
-				} else {
-					r.recorder.Eventf(aerospikeCluster, v1.EventTypeWarning, events.ReasonValidationError,
-						"failed to get storage class %q: %v",
-						ns.Storage.StorageClassName,
-						err,
-					)
+					return fmt.Errorf("storage class %q does not exist", *ns.Storage.StorageClassName)
 				}
-				return false
+				return fmt.Errorf("failed to get storage class %q: %v", *ns.Storage.StorageClassName, err)
 			}
 		}
 	}
-	return true
+	return nil
+}
+
+func (r *AerospikeClusterReconciler) signalValid(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) (*aerospikev1alpha2.AerospikeCluster, error) {
+	// grab a copy of aerospikeCluster in its current state so we can later
+	// create a patch
+	oldCluster := aerospikeCluster.DeepCopy()
+
+	setCondition(aerospikeCluster, apiextensions.CustomResourceDefinitionCondition{
+		Type:               common.ConditionClusterValid,
+		Status:             apiextensions.ConditionTrue,
+		Reason:             events.ReasonValidationSucceeded,
+		Message:            "spec is valid",
+		LastTransitionTime: metav1.NewTime(time.Now()),
+	})
+
+	if err := r.patchCluster(oldCluster, aerospikeCluster); err != nil {
+		return nil, err
+	}
+
+	return aerospikeCluster, nil
+}
+
+func (r *AerospikeClusterReconciler) signalInvalid(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, validationErr error) (*aerospikev1alpha2.AerospikeCluster, error) {
+	// grab a copy of aerospikeCluster in its current state so we can later
+	// create a patch
+	oldCluster := aerospikeCluster.DeepCopy()
+
+	aerospikeCluster.Status.Phase = common.ClusterPhaseInvalid
+	aerospikeCluster.Status.ObservedGeneration = aerospikeCluster.Generation
+	setCondition(aerospikeCluster, apiextensions.CustomResourceDefinitionCondition{
+		Type:               common.ConditionClusterValid,
+		Status:             apiextensions.ConditionFalse,
+		Reason:             events.ReasonValidationError,
+		Message:            validationErr.Error(),
+		LastTransitionTime: metav1.NewTime(time.Now()),
+	})
+
+	if err := r.patchCluster(oldCluster, aerospikeCluster); err != nil {
+		return nil, err
+	}
+
+	r.recorder.Event(aerospikeCluster, v1.EventTypeWarning, events.ReasonValidationError, validationErr.Error())
+
+	log.WithFields(log.Fields{
+		logfields.AerospikeCluster: meta.Key(aerospikeCluster),
+	}).Warnf("validation failed: %v", validationErr)
+
+	return aerospikeCluster, nil
 }
@@ -0,0 +1,238 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secondaryindex
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/api/core/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelistersv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/common"
+	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
+	"github.com/travelaudience/aerospike-operator/pkg/asutils"
+	aerospikeclientset "github.com/travelaudience/aerospike-operator/pkg/client/clientset/versioned"
+	aerospikelisters "github.com/travelaudience/aerospike-operator/pkg/client/listers/aerospike/v1alpha2"
+	aserrors "github.com/travelaudience/aerospike-operator/pkg/errors"
+	"github.com/travelaudience/aerospike-operator/pkg/logfields"
+	"github.com/travelaudience/aerospike-operator/pkg/meta"
+	"github.com/travelaudience/aerospike-operator/pkg/utils/events"
+	"github.com/travelaudience/aerospike-operator/pkg/utils/handlers"
+)
+
+const (
+	// Finalizer is the finalizer added to every AerospikeSecondaryIndex
+	// resource so that the secondary index can be deleted from the target
+	// cluster before the resource itself is deleted.
+	Finalizer = "aerospike.travelaudience.com/secondary-index"
+	// fullBuildProgress is the build progress reported by a node once the
+	// secondary index has been fully built.
+	fullBuildProgress = 100
+	// deletionRequeuePeriod is the period after which the deletion of a
+	// secondary index is retried when the target cluster has no ready nodes.
+	deletionRequeuePeriod = 10 * time.Second
+)
+
+type AerospikeSecondaryIndexHandler struct {
+	aerospikeclientset      aerospikeclientset.Interface
+	aerospikeClustersLister aerospikelisters.AerospikeClusterLister
+	podsLister              corelistersv1.PodLister
+	recorder                record.EventRecorder
+}
+
+func New(aerospikeclientset aerospikeclientset.Interface,
+	aerospikeClustersLister aerospikelisters.AerospikeClusterLister,
+	podsLister corelistersv1.PodLister,
+	recorder record.EventRecorder) *AerospikeSecondaryIndexHandler {
+	return &AerospikeSecondaryIndexHandler{
+		aerospikeclientset:      aerospikeclientset,
+		aerospikeClustersLister: aerospikeClustersLister,
+		podsLister:              podsLister,
+		recorder:                recorder,
+	}
+}
+
+// Handle makes sure that the secondary index represented by obj exists in the
+// target cluster (re-creating it if necessary, such as after the cluster has
+// been re-created), reports its build progress, and deletes it from the target
+// cluster when obj is deleted.
+func (h *AerospikeSecondaryIndexHandler) Handle(obj *aerospikev1alpha2.AerospikeSecondaryIndex) error {
+	log.WithFields(log.Fields{
+		logfields.AerospikeSecondaryIndex: meta.Key(obj),
+	}).Debug("checking whether action is needed")
+
+	// delete the secondary index from the target cluster if the resource is
+	// being deleted
+	if obj.DeletionTimestamp != nil {
+		return h.handleDeletion(obj)
+	}
+
+	// make sure the resource has our finalizer before we create anything in
+	// the target cluster
+	if !handlers.HasFinalizer(obj, Finalizer) {
+		obj.Finalizers = append(obj.Finalizers, Finalizer)
+		res, err := h.aerospikeclientset.AerospikeV1alpha2().AerospikeSecondaryIndexes(obj.Namespace).Update(obj)
+		if err != nil {
+			return err
+		}
+		obj = res
+	}
+
+	// grab the pods of the target cluster that are running and ready
+	pods, err := handlers.GetReadyPods(h.aerospikeClustersLister, h.podsLister, obj.Namespace, obj.Spec.Target.Cluster)
+	if err != nil {
+		return err
+	}
+	if len(pods) == 0 {
+		// the target cluster does not exist or is not ready yet, in which
+		// case we will be called again once it is
+		log.WithFields(log.Fields{
+			logfields.AerospikeSecondaryIndex: meta.Key(obj),
+		}).Debugf("no ready nodes found in cluster %s", obj.Spec.Target.Cluster)
+		return h.updateStatus(obj, 0)
+	}
+
+	// compute the build progress of the secondary index across all nodes,
+	// taking note of whether any node knows about it
+	progress, found, err := getBuildProgress(obj, pods)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		// no node knows about the secondary index, meaning it has never been
+		// created or that the cluster has been re-created, so (re-)create it
+		if err := asutils.CreateSecondaryIndex(pods[0].Status.PodIP, asutils.ServicePort, obj.Spec.Target.Namespace, obj.Spec.Set, obj.Name, obj.Spec.Bin, obj.Spec.Type); err != nil {
+			return err
+		}
+		log.WithFields(log.Fields{
+			logfields.AerospikeSecondaryIndex: meta.Key(obj),
+		}).Infof("secondary index created in cluster %s", obj.Spec.Target.Cluster)
+		h.recorder.Eventf(obj, v1.EventTypeNormal, events.ReasonSecondaryIndexCreated,
+			"secondary index created in cluster %s", obj.Spec.Target.Cluster)
+		appendCondition(obj, common.ConditionSecondaryIndexCreated, fmt.Sprintf("secondary index created in cluster %s", obj.Spec.Target.Cluster))
+		return h.updateStatus(obj, 0)
+	}
+
+	if progress == fullBuildProgress && lastConditionType(obj) != common.ConditionSecondaryIndexBuilt {
+		log.WithFields(log.Fields{
+			logfields.AerospikeSecondaryIndex: meta.Key(obj),
+		}).Infof("secondary index built in cluster %s", obj.Spec.Target.Cluster)
+		h.recorder.Eventf(obj, v1.EventTypeNormal, events.ReasonSecondaryIndexBuilt,
+			"secondary index built in cluster %s", obj.Spec.Target.Cluster)
+		appendCondition(obj, common.ConditionSecondaryIndexBuilt, fmt.Sprintf("secondary index built in cluster %s", obj.Spec.Target.Cluster))
+	}
+	return h.updateStatus(obj, progress)
+}
+
+// handleDeletion deletes the secondary index from the target cluster (if it
+// still exists) and removes our finalizer from obj. if the target cluster
+// exists but has no ready nodes, the deletion is retried later so that the
+// secondary index is not leaked.
+func (h *AerospikeSecondaryIndexHandler) handleDeletion(obj *aerospikev1alpha2.AerospikeSecondaryIndex) error {
+	if !handlers.HasFinalizer(obj, Finalizer) {
+		return nil
+	}
+	_, err := h.aerospikeClustersLister.AerospikeClusters(obj.Namespace).Get(obj.Spec.Target.Cluster)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	if err == nil {
+		pods, err := handlers.GetReadyPods(h.aerospikeClustersLister, h.podsLister, obj.Namespace, obj.Spec.Target.Cluster)
+		if err != nil {
+			return err
+		}
+		if len(pods) == 0 {
+			return aserrors.NewRequeueError(deletionRequeuePeriod, "no ready nodes found in cluster %s", obj.Spec.Target.Cluster)
+		}
+		if err := asutils.DeleteSecondaryIndex(pods[0].Status.PodIP, asutils.ServicePort, obj.Spec.Target.Namespace, obj.Name); err != nil {
+			return err
+		}
+		log.WithFields(log.Fields{
+			logfields.AerospikeSecondaryIndex: meta.Key(obj),
+		}).Infof("secondary index deleted from cluster %s", obj.Spec.Target.Cluster)
+		h.recorder.Eventf(obj, v1.EventTypeNormal, events.ReasonSecondaryIndexDeleted,
+			"secondary index deleted from cluster %s", obj.Spec.Target.Cluster)
+	}
+	// remove our finalizer so that the resource can be deleted
+	handlers.RemoveFinalizer(obj, Finalizer)
+	_, err = h.aerospikeclientset.AerospikeV1alpha2().AerospikeSecondaryIndexes(obj.Namespace).Update(obj)
+	return err
+}
+
+// getBuildProgress returns the lowest build progress of the secondary index
+// across the specified pods, and whether at least one of them knows about the
+// secondary index. nodes which do not know about the secondary index (such as
+// nodes which have just joined the cluster) are considered to have made no
+// progress.
+func getBuildProgress(obj *aerospikev1alpha2.AerospikeSecondaryIndex, pods []*v1.Pod) (int32, bool, error) {
+	progress := int32(fullBuildProgress)
+	found := false
+	for _, pod := range pods {
+		pct, err := asutils.GetSecondaryIndexLoadPercentage(pod.Status.PodIP, asutils.ServicePort, obj.Spec.Target.Namespace, obj.Name)
+		if err == asutils.ErrSecondaryIndexNotFound {
+			progress = 0
+			continue
+		}
+		if err != nil {
+			return 0, false, err
+		}
+		found = true
+		if int32(pct) < progress {
+			progress = int32(pct)
+		}
+	}
+	return progress, found, nil
+}
+
+// updateStatus syncs .status with .spec and sets the build progress, updating
+// obj only if its status has changed.
+func (h *AerospikeSecondaryIndexHandler) updateStatus(obj *aerospikev1alpha2.AerospikeSecondaryIndex, progress int32) error {
+	oldStatus := obj.Status.DeepCopy()
+	obj.Status.AerospikeSecondaryIndexSpec = obj.Spec
+	obj.Status.BuildProgress = progress
+	if reflect.DeepEqual(oldStatus, &obj.Status) {
+		return nil
+	}
+	_, err := h.aerospikeclientset.AerospikeV1alpha2().AerospikeSecondaryIndexes(obj.Namespace).UpdateStatus(obj)
+	return err
+}
+
+func appendCondition(obj *aerospikev1alpha2.AerospikeSecondaryIndex, conditionType apiextensions.CustomResourceDefinitionConditionType, message string) {
+	obj.Status.Conditions = append(obj.Status.Conditions, apiextensions.CustomResourceDefinitionCondition{
+		LastTransitionTime: metav1.NewTime(time.Now()),
+		Type:               conditionType,
+		Status:             apiextensions.ConditionTrue,
+		Message:            message,
+	})
+}
+
+// lastConditionType returns the type of the last condition appended to the
+// status of obj, or an empty string if there are no conditions.
+func lastConditionType(obj *aerospikev1alpha2.AerospikeSecondaryIndex) apiextensions.CustomResourceDefinitionConditionType {
+	if len(obj.Status.Conditions) == 0 {
+		return ""
+	}
+	return obj.Status.Conditions[len(obj.Status.Conditions)-1].Type
+}
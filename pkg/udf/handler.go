@@ -0,0 +1,233 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package udf
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/api/core/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelistersv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/common"
+	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
+	"github.com/travelaudience/aerospike-operator/pkg/asutils"
+	aerospikeclientset "github.com/travelaudience/aerospike-operator/pkg/client/clientset/versioned"
+	aerospikelisters "github.com/travelaudience/aerospike-operator/pkg/client/listers/aerospike/v1alpha2"
+	aserrors "github.com/travelaudience/aerospike-operator/pkg/errors"
+	"github.com/travelaudience/aerospike-operator/pkg/logfields"
+	"github.com/travelaudience/aerospike-operator/pkg/meta"
+	"github.com/travelaudience/aerospike-operator/pkg/utils/events"
+	"github.com/travelaudience/aerospike-operator/pkg/utils/handlers"
+)
+
+const (
+	// Finalizer is the finalizer added to every AerospikeUDF resource so that
+	// the UDF module can be removed from the target cluster before the
+	// resource itself is deleted.
+	Finalizer = "aerospike.travelaudience.com/udf"
+	// moduleExtension is the extension appended to the name of an AerospikeUDF
+	// resource in order to obtain the filename of the UDF module.
+	moduleExtension = ".lua"
+	// deletionRequeuePeriod is the period after which the removal of a UDF
+	// module is retried when the target cluster has no ready nodes.
+	deletionRequeuePeriod = 10 * time.Second
+)
+
+type AerospikeUDFHandler struct {
+	aerospikeclientset      aerospikeclientset.Interface
+	aerospikeClustersLister aerospikelisters.AerospikeClusterLister
+	podsLister              corelistersv1.PodLister
+	configMapsLister        corelistersv1.ConfigMapLister
+	recorder                record.EventRecorder
+}
+
+func New(aerospikeclientset aerospikeclientset.Interface,
+	aerospikeClustersLister aerospikelisters.AerospikeClusterLister,
+	podsLister corelistersv1.PodLister,
+	configMapsLister corelistersv1.ConfigMapLister,
+	recorder record.EventRecorder) *AerospikeUDFHandler {
+	return &AerospikeUDFHandler{
+		aerospikeclientset:      aerospikeclientset,
+		aerospikeClustersLister: aerospikeClustersLister,
+		podsLister:              podsLister,
+		configMapsLister:        configMapsLister,
+		recorder:                recorder,
+	}
+}
+
+// ModuleName returns the filename under which the UDF module represented by
+// obj is registered in the target cluster.
+func ModuleName(obj *aerospikev1alpha2.AerospikeUDF) string {
+	return obj.Name + moduleExtension
+}
+
+// Handle makes sure that the UDF module represented by obj is registered with
+// the expected content in every node of the target cluster (re-registering it
+// if necessary, such as after the cluster has been re-created or the source
+// has changed), and removes it from the target cluster when obj is deleted.
+func (h *AerospikeUDFHandler) Handle(obj *aerospikev1alpha2.AerospikeUDF) error {
+	log.WithFields(log.Fields{
+		logfields.AerospikeUDF: meta.Key(obj),
+	}).Debug("checking whether action is needed")
+
+	// remove the udf module from the target cluster if the resource is being
+	// deleted
+	if obj.DeletionTimestamp != nil {
+		return h.handleDeletion(obj)
+	}
+
+	// make sure the resource has our finalizer before we register anything in
+	// the target cluster
+	if !handlers.HasFinalizer(obj, Finalizer) {
+		obj.Finalizers = append(obj.Finalizers, Finalizer)
+		res, err := h.aerospikeclientset.AerospikeV1alpha2().AerospikeUDFs(obj.Namespace).Update(obj)
+		if err != nil {
+			return err
+		}
+		obj = res
+	}
+
+	// read the lua code of the udf module and compute the hash aerospike is
+	// expected to report for it
+	content, err := h.getSource(obj)
+	if err != nil {
+		return err
+	}
+	hash := asutils.UDFHash(content)
+
+	// grab the pods of the target cluster that are running and ready
+	pods, err := handlers.GetReadyPods(h.aerospikeClustersLister, h.podsLister, obj.Namespace, obj.Spec.Target.Cluster)
+	if err != nil {
+		return err
+	}
+	if len(pods) == 0 {
+		// the target cluster does not exist or is not ready yet, in which
+		// case we will be called again once it is
+		log.WithFields(log.Fields{
+			logfields.AerospikeUDF: meta.Key(obj),
+		}).Debugf("no ready nodes found in cluster %s", obj.Spec.Target.Cluster)
+		return nil
+	}
+
+	// check whether every node reports the expected hash for the udf module
+	registered, err := isRegistered(obj, pods, hash)
+	if err != nil {
+		return err
+	}
+	if !registered {
+		// at least one node does not know about the udf module or reports a
+		// different hash for it, so (re-)register it. aerospike propagates the
+		// udf module to every node asynchronously, so subsequent runs check
+		// that every node reports the expected hash.
+		if err := asutils.RegisterUDF(pods[0].Status.PodIP, asutils.ServicePort, ModuleName(obj), content); err != nil {
+			return err
+		}
+		log.WithFields(log.Fields{
+			logfields.AerospikeUDF: meta.Key(obj),
+		}).Infof("udf module %s registered in cluster %s", ModuleName(obj), obj.Spec.Target.Cluster)
+		h.recorder.Eventf(obj, v1.EventTypeNormal, events.ReasonUDFRegistered,
+			"udf module %s registered in cluster %s", ModuleName(obj), obj.Spec.Target.Cluster)
+	}
+
+	// record the hash of the registered udf module
+	if obj.Status.Hash == hash {
+		return nil
+	}
+	obj.Status.Hash = hash
+	obj.Status.Conditions = append(obj.Status.Conditions, apiextensions.CustomResourceDefinitionCondition{
+		LastTransitionTime: metav1.NewTime(time.Now()),
+		Type:               common.ConditionUDFRegistered,
+		Status:             apiextensions.ConditionTrue,
+		Message:            fmt.Sprintf("udf module %s with hash %s registered in cluster %s", ModuleName(obj), hash, obj.Spec.Target.Cluster),
+	})
+	_, err = h.aerospikeclientset.AerospikeV1alpha2().AerospikeUDFs(obj.Namespace).UpdateStatus(obj)
+	return err
+}
+
+// handleDeletion removes the udf module from the target cluster (if it still
+// exists) and removes our finalizer from obj. if the target cluster exists but
+// has no ready nodes, the removal is retried later so that the udf module is
+// not leaked.
+func (h *AerospikeUDFHandler) handleDeletion(obj *aerospikev1alpha2.AerospikeUDF) error {
+	if !handlers.HasFinalizer(obj, Finalizer) {
+		return nil
+	}
+	_, err := h.aerospikeClustersLister.AerospikeClusters(obj.Namespace).Get(obj.Spec.Target.Cluster)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	if err == nil {
+		pods, err := handlers.GetReadyPods(h.aerospikeClustersLister, h.podsLister, obj.Namespace, obj.Spec.Target.Cluster)
+		if err != nil {
+			return err
+		}
+		if len(pods) == 0 {
+			return aserrors.NewRequeueError(deletionRequeuePeriod, "no ready nodes found in cluster %s", obj.Spec.Target.Cluster)
+		}
+		if err := asutils.RemoveUDF(pods[0].Status.PodIP, asutils.ServicePort, ModuleName(obj)); err != nil {
+			return err
+		}
+		log.WithFields(log.Fields{
+			logfields.AerospikeUDF: meta.Key(obj),
+		}).Infof("udf module %s removed from cluster %s", ModuleName(obj), obj.Spec.Target.Cluster)
+		h.recorder.Eventf(obj, v1.EventTypeNormal, events.ReasonUDFRemoved,
+			"udf module %s removed from cluster %s", ModuleName(obj), obj.Spec.Target.Cluster)
+	}
+	// remove our finalizer so that the resource can be deleted
+	handlers.RemoveFinalizer(obj, Finalizer)
+	_, err = h.aerospikeclientset.AerospikeV1alpha2().AerospikeUDFs(obj.Namespace).Update(obj)
+	return err
+}
+
+// getSource returns the lua code of the udf module represented by obj, reading
+// it from the referenced configmap if necessary.
+func (h *AerospikeUDFHandler) getSource(obj *aerospikev1alpha2.AerospikeUDF) (string, error) {
+	ref := obj.Spec.Source.ConfigMapKeyRef
+	if ref == nil {
+		return obj.Spec.Source.Inline, nil
+	}
+	cm, err := h.configMapsLister.ConfigMaps(obj.Namespace).Get(ref.Name)
+	if err != nil {
+		return "", err
+	}
+	content, ok := cm.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("configmap %s does not contain a key named %s", ref.Name, ref.Key)
+	}
+	return content, nil
+}
+
+// isRegistered indicates whether every one of the specified pods reports the
+// specified hash for the udf module represented by obj.
+func isRegistered(obj *aerospikev1alpha2.AerospikeUDF, pods []*v1.Pod, hash string) (bool, error) {
+	for _, pod := range pods {
+		udfs, err := asutils.ListUDFs(pod.Status.PodIP, asutils.ServicePort)
+		if err != nil {
+			return false, err
+		}
+		if udfs[ModuleName(obj)] != hash {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package annotations
+
+// the annotations below are used by aerospike-operator to keep track of the
+// version upgrade of AerospikeCluster resources. they are shared by the
+// reconciler, which sets them, and by the admission webhook, which relies on
+// them to validate rollbacks and recovery actions.
+const (
+	// UpgradeStatusAnnotationKey is the name of the annotation added to
+	// AerospikeCluster resources that are being upgraded.
+	UpgradeStatusAnnotationKey = "aerospike.travelaudience.com/upgrade-status"
+	// UpgradeStatusStartedAnnotationValue is the value of the annotation added
+	// to AerospikeCluster resources that are being upgrade.
+	UpgradeStatusStartedAnnotationValue = "started"
+	// UpgradeStatusFailedAnnotationValue is the value of the annotation added
+	// to AerospikeCluster resources that have not been successfully upgraded.
+	UpgradeStatusFailedAnnotationValue = "failed"
+	// UpgradeStatusBackupAnnotationValue is the value of the annotation added
+	// to AerospikeCluster resources that are undergoing a pre-upgrade backup.
+	UpgradeStatusBackupAnnotationValue = "backup"
+	// UpgradeStatusRollbackAnnotationValue is the value of the annotation
+	// added to AerospikeCluster resources whose failed upgrade is being
+	// rolled back.
+	UpgradeStatusRollbackAnnotationValue = "rollback"
+	// UpgradeStatusRestoreAnnotationValue is the value of the annotation
+	// added to AerospikeCluster resources whose namespaces are being restored
+	// from the backups made before a failed upgrade.
+	UpgradeStatusRestoreAnnotationValue = "restore"
+	// UpgradeStatusHaltedAnnotationValue is the value of the annotation added
+	// to AerospikeCluster resources whose failed upgrade could not be rolled
+	// back.
+	UpgradeStatusHaltedAnnotationValue = "halted"
+	// FailedUpgradeVersionAnnotationKey is the name of the annotation that
+	// holds the version to which an AerospikeCluster resource failed to be
+	// upgraded while the upgrade is being rolled back.
+	FailedUpgradeVersionAnnotationKey = "aerospike.travelaudience.com/failed-upgrade-version"
+)
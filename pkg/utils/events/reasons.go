@@ -21,6 +21,18 @@ const (
 	// validation errors.
 	ReasonValidationError = "ValidationError"
 
+	// ReasonValidationSucceeded is the reason used in status conditions indicating that the
+	// spec of a resource has been validated successfully.
+	ReasonValidationSucceeded = "ValidationSucceeded"
+
+	// ReasonNodesReady is the reason used in status conditions indicating that every expected
+	// node of a cluster is running and ready.
+	ReasonNodesReady = "NodesReady"
+
+	// ReasonNodesNotReady is the reason used in status conditions indicating that some of the
+	// expected nodes of a cluster are missing or not ready.
+	ReasonNodesNotReady = "NodesNotReady"
+
 	// ReasonNodeStarting is the reason used in corev1.Event objects created when waiting
 	// for pods to be running and ready.
 	ReasonNodeStarting = "NodeStarting"
@@ -69,10 +81,50 @@ const (
 	// restore job has been created
 	ReasonJobCreated = "JobCreated"
 
+	// ReasonSecondaryIndexCreated is the reason used in corev1.Event objects indicating that a
+	// secondary index has been created
+	ReasonSecondaryIndexCreated = "SecondaryIndexCreated"
+
+	// ReasonSecondaryIndexBuilt is the reason used in corev1.Event objects indicating that a
+	// secondary index has been built in every node of the target cluster
+	ReasonSecondaryIndexBuilt = "SecondaryIndexBuilt"
+
+	// ReasonSecondaryIndexDeleted is the reason used in corev1.Event objects indicating that a
+	// secondary index has been deleted
+	ReasonSecondaryIndexDeleted = "SecondaryIndexDeleted"
+
+	// ReasonUDFRegistered is the reason used in corev1.Event objects indicating that a UDF
+	// module has been registered
+	ReasonUDFRegistered = "UDFRegistered"
+
+	// ReasonUDFRemoved is the reason used in corev1.Event objects indicating that a UDF
+	// module has been removed
+	ReasonUDFRemoved = "UDFRemoved"
+
 	// ReasonClusterUpgradeStarted is the reason used in corev1.Event objects indicating that a
 	// cluster upgrade has started
 	ReasonClusterUpgradeStarted = "ClusterUpgradeStarted"
 
+	// ReasonClusterPaused is the reason used in corev1.Event objects indicating that the
+	// reconciliation of a cluster has been paused
+	ReasonClusterPaused = "ClusterPaused"
+
+	// ReasonClusterResumed is the reason used in corev1.Event objects indicating that the
+	// reconciliation of a cluster has been resumed
+	ReasonClusterResumed = "ClusterResumed"
+
+	// ReasonClusterRestartStarted is the reason used in corev1.Event objects indicating that a
+	// rolling restart of a cluster has started
+	ReasonClusterRestartStarted = "ClusterRestartStarted"
+
+	// ReasonClusterRestartFinished is the reason used in corev1.Event objects indicating that a
+	// rolling restart of a cluster has finished
+	ReasonClusterRestartFinished = "ClusterRestartFinished"
+
+	// ReasonNodeRestarted is the reason used in corev1.Event objects indicating that a node
+	// has been restarted as part of a rolling restart of a cluster
+	ReasonNodeRestarted = "NodeRestarted"
+
 	// ReasonClusterUpgradeFailed is the reason used in corev1.Event objects indicating that a
 	// cluster upgrade has failed
 	ReasonClusterUpgradeFailed = "ClusterUpgradeFailed"
@@ -92,4 +144,84 @@ const (
 	// ReasonClusterAutoBackupFailed is the reason used in corev1.Event objects indicating that a
 	// cluster backup has failed
 	ReasonClusterAutoBackupFailed = "ClusterAutoBackupFailed"
+
+	// ReasonClusterUpgradeHalted is the reason used in corev1.Event objects indicating that a
+	// cluster is no longer managed after a failed upgrade
+	ReasonClusterUpgradeHalted = "ClusterUpgradeHalted"
+
+	// ReasonClusterUpgradeRollbackStarted is the reason used in corev1.Event objects indicating
+	// that the rollback of a failed cluster upgrade has started
+	ReasonClusterUpgradeRollbackStarted = "ClusterUpgradeRollbackStarted"
+
+	// ReasonClusterUpgradeRollbackFinished is the reason used in corev1.Event objects indicating
+	// that the rollback of a failed cluster upgrade has finished
+	ReasonClusterUpgradeRollbackFinished = "ClusterUpgradeRollbackFinished"
+
+	// ReasonClusterUpgradeRollbackFailed is the reason used in corev1.Event objects indicating
+	// that the rollback of a failed cluster upgrade has failed
+	ReasonClusterUpgradeRollbackFailed = "ClusterUpgradeRollbackFailed"
+
+	// ReasonClusterAutoRestoreStarted is the reason used in corev1.Event objects indicating that
+	// the restore of a pre-upgrade cluster backup has started
+	ReasonClusterAutoRestoreStarted = "ClusterAutoRestoreStarted"
+
+	// ReasonClusterAutoRestoreFinished is the reason used in corev1.Event objects indicating that
+	// the restore of a pre-upgrade cluster backup has finished
+	ReasonClusterAutoRestoreFinished = "ClusterAutoRestoreFinished"
+
+	// ReasonClusterAutoRestoreFailed is the reason used in corev1.Event objects indicating that
+	// the restore of a pre-upgrade cluster backup has failed
+	ReasonClusterAutoRestoreFailed = "ClusterAutoRestoreFailed"
+
+	// ReasonClusterRecoveryFinished is the reason used in corev1.Event objects indicating that
+	// the recovery of a cluster whose upgrade has failed has been performed
+	ReasonClusterRecoveryFinished = "ClusterRecoveryFinished"
+
+	// ReasonClusterRecoveryFailed is the reason used in corev1.Event objects indicating that the
+	// recovery of a cluster whose upgrade has failed could not be performed
+	ReasonClusterRecoveryFailed = "ClusterRecoveryFailed"
+
+	// ReasonNamespaceUpdateStarted is the reason used in corev1.Event objects indicating that an
+	// update to existing namespaces has started
+	ReasonNamespaceUpdateStarted = "NamespaceUpdateStarted"
+
+	// ReasonNamespaceUpdateFinished is the reason used in corev1.Event objects indicating that an
+	// update to existing namespaces has finished
+	ReasonNamespaceUpdateFinished = "NamespaceUpdateFinished"
+
+	// ReasonNodeStorageUpdateStarted is the reason used in corev1.Event objects created when the
+	// persistent volumes of a pod start being replaced.
+	ReasonNodeStorageUpdateStarted = "NodeStorageUpdateStarted"
+
+	// ReasonNodeStorageUpdateFinished is the reason used in corev1.Event objects created when the
+	// persistent volumes of a pod have been replaced.
+	ReasonNodeStorageUpdateFinished = "NodeStorageUpdateFinished"
+
+	// ReasonNodeMaintenanceStarted is the reason used in corev1.Event objects created when a pod
+	// has been deleted because the corresponding node has been put under maintenance.
+	ReasonNodeMaintenanceStarted = "NodeMaintenanceStarted"
+
+	// ReasonNodeMaintenanceFinished is the reason used in corev1.Event objects created when a pod
+	// has been re-created because the corresponding node is no longer under maintenance.
+	ReasonNodeMaintenanceFinished = "NodeMaintenanceFinished"
+
+	// ReasonVolumeExpansionStarted is the reason used in corev1.Event objects created when the
+	// expansion of a persistent volume claim starts.
+	ReasonVolumeExpansionStarted = "VolumeExpansionStarted"
+
+	// ReasonVolumeExpansionFailed is the reason used in corev1.Event objects created when the
+	// expansion of a persistent volume claim fails.
+	ReasonVolumeExpansionFailed = "VolumeExpansionFailed"
+
+	// ReasonVolumeExpansionFinished is the reason used in corev1.Event objects created when the
+	// expansion of a persistent volume claim finishes.
+	ReasonVolumeExpansionFinished = "VolumeExpansionFinished"
+
+	// ReasonNodeConfigUpdated is the reason used in corev1.Event objects created when dynamic
+	// configuration changes are applied to a node without restarting it.
+	ReasonNodeConfigUpdated = "NodeConfigUpdated"
+
+	// ReasonNodeConfigUpdateFailed is the reason used in corev1.Event objects created when
+	// dynamic configuration changes cannot be applied to a node, which is then restarted.
+	ReasonNodeConfigUpdateFailed = "NodeConfigUpdateFailed"
 )
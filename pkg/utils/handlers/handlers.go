@@ -0,0 +1,77 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"sort"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelistersv1 "k8s.io/client-go/listers/core/v1"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
+
+	aerospikelisters "github.com/travelaudience/aerospike-operator/pkg/client/listers/aerospike/v1alpha2"
+	"github.com/travelaudience/aerospike-operator/pkg/utils/selectors"
+)
+
+// GetReadyPods returns the pods of the specified cluster that are running and
+// ready, sorted by name. if the cluster does not exist, an empty list is
+// returned.
+func GetReadyPods(aerospikeClustersLister aerospikelisters.AerospikeClusterLister, podsLister corelistersv1.PodLister, namespace, clusterName string) ([]*v1.Pod, error) {
+	if _, err := aerospikeClustersLister.AerospikeClusters(namespace).Get(clusterName); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	pods, err := podsLister.Pods(namespace).List(selectors.ResourcesByClusterName(clusterName))
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*v1.Pod, 0, len(pods))
+	for _, pod := range pods {
+		if pod.Status.Phase == v1.PodRunning && podutil.IsPodReady(pod) {
+			res = append(res, pod)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+	return res, nil
+}
+
+// HasFinalizer indicates whether obj has the specified finalizer.
+func HasFinalizer(obj metav1.Object, finalizer string) bool {
+	for _, f := range obj.GetFinalizers() {
+		if f == finalizer {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveFinalizer removes the specified finalizer from obj.
+func RemoveFinalizer(obj metav1.Object, finalizer string) {
+	finalizers := make([]string, 0, len(obj.GetFinalizers()))
+	for _, f := range obj.GetFinalizers() {
+		if f != finalizer {
+			finalizers = append(finalizers, f)
+		}
+	}
+	obj.SetFinalizers(finalizers)
+}
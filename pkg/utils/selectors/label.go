@@ -29,6 +29,10 @@ const (
 	LabelAppVal = "aerospike"
 	// LabelClusterKey respresents the name of the "cluster" label added to every pod.
 	LabelClusterKey = "cluster"
+	// LabelRackKey represents the name of the "rack" label added to every pod belonging to a rack.
+	LabelRackKey = "rack"
+	// LabelPodKey represents the name of the "pod" label added to every pod exposed by a per-pod service.
+	LabelPodKey = "pod"
 	// LabelNamespaceKey represents the name of the "namespace" label added to every persistent volume claim.
 	LabelNamespaceKey = "namespace"
 )
@@ -17,6 +17,7 @@ limitations under the License.
 package time
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -32,7 +33,7 @@ func ParseDuration(s string) (time.Duration, error) {
 		}
 		x := strings.Replace(s, "d", "h", 1)
 		d, err := time.ParseDuration(x)
-		err = fmt.Errorf(strings.Replace(err.Error(), x, s, -1))
+		err = errors.New(strings.Replace(err.Error(), x, s, -1))
 		return d, err
 	}
 	return time.ParseDuration(s)
@@ -20,6 +20,17 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+
+	"github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/common"
+)
+
+const (
+	// communityEditionSuffix is the suffix used to explicitly identify a
+	// version of Aerospike Community Edition (e.g. "4.3.0.10-ce").
+	communityEditionSuffix = "-ce"
+	// enterpriseEditionSuffix is the suffix used to explicitly identify a
+	// version of Aerospike Enterprise Edition (e.g. "4.3.0.10-ee").
+	enterpriseEditionSuffix = "-ee"
 )
 
 // Version represents a version of Aerospike.
@@ -31,8 +42,12 @@ type Version struct {
 }
 
 // NewVersionFromString parses the specified version string into the
-// corresponding Version struct.
+// corresponding Version struct. The version string may be suffixed with
+// "-ce" or "-ee" in order to identify the edition of Aerospike.
 func NewVersionFromString(versionString string) (Version, error) {
+	// strip the edition suffix (if any)
+	versionString = strings.TrimSuffix(versionString, communityEditionSuffix)
+	versionString = strings.TrimSuffix(versionString, enterpriseEditionSuffix)
 	// split versionString by "."
 	s := strings.Split(versionString, ".")
 	if len(s) < 3 || len(s) > 4 {
@@ -51,17 +66,54 @@ func NewVersionFromString(versionString string) (Version, error) {
 	return Version{parts[0], parts[1], parts[2], parts[3]}, nil
 }
 
-// String returns the string representation of the current struct.
+// String returns the string representation of the current struct. The edition
+// is not included, so that the result can be used as an image tag.
 func (v Version) String() string {
 	return fmt.Sprintf("%d.%d.%d.%d", v.Major, v.Minor, v.Patch, v.Revision)
 }
 
+// Equals indicates whether the current struct and other represent the same
+// version of Aerospike, regardless of edition.
+func (v Version) Equals(other Version) bool {
+	return v == other
+}
+
+// GetEdition returns the edition of Aerospike explicitly identified by the
+// specified version string, or the empty string if none is.
+func GetEdition(versionString string) string {
+	switch {
+	case strings.HasSuffix(versionString, communityEditionSuffix):
+		return common.EditionCommunity
+	case strings.HasSuffix(versionString, enterpriseEditionSuffix):
+		return common.EditionEnterprise
+	default:
+		return ""
+	}
+}
+
 // IsSupported indicated whether the version of Aerospike represented by the
 // current struct is supported by the operator.
 func (v Version) IsSupported() bool {
 	return contains(AerospikeServerSupportedVersions, v.String())
 }
 
+// IsSupportedForEdition indicates whether the specified version string is
+// supported by the operator for the specified edition. The version string must
+// not explicitly identify a different edition.
+func IsSupportedForEdition(versionString, edition string) bool {
+	if edition != common.EditionCommunity && edition != common.EditionEnterprise {
+		return false
+	}
+	if e := GetEdition(versionString); e != "" && e != edition {
+		return false
+	}
+	v, err := NewVersionFromString(versionString)
+	if err != nil {
+		return false
+	}
+	return v.IsSupported()
+}
+
 // contains returns a boolean indicating whether e is contained in the s slice.
 func contains(s []string, e string) bool {
 	for _, a := range s {
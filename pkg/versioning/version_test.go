@@ -20,6 +20,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/common"
 )
 
 func TestNewVersionFromString(t *testing.T) {
@@ -32,6 +34,10 @@ func TestNewVersionFromString(t *testing.T) {
 		{"1.2.3.4.5", true},
 		{"4.1.0", false},
 		{"4.1.0.1", false},
+		{"4.1.0.1-ee", false},
+		{"4.1.0.1-ce", false},
+		{"4.1.0.1-xe", true},
+		{"4.1-ee", true},
 	}
 	for _, test := range tests {
 		_, err := NewVersionFromString(test.versionString)
@@ -55,3 +61,40 @@ func TestString(t *testing.T) {
 		assert.Equal(t, test.version.String(), test.versionString)
 	}
 }
+
+func TestEdition(t *testing.T) {
+	tests := []struct {
+		versionString string
+		edition       string
+	}{
+		{"4.3.0.10", ""},
+		{"4.3.0.10-ce", common.EditionCommunity},
+		{"4.3.0.10-ee", common.EditionEnterprise},
+	}
+	for _, test := range tests {
+		version, err := NewVersionFromString(test.versionString)
+		assert.NoError(t, err)
+		assert.Equal(t, test.edition, GetEdition(test.versionString))
+		assert.Equal(t, "4.3.0.10", version.String())
+	}
+}
+
+func TestIsSupportedForEdition(t *testing.T) {
+	tests := []struct {
+		versionString string
+		edition       string
+		result        bool
+	}{
+		{"4.3.0.10", common.EditionCommunity, true},
+		{"4.3.0.10", common.EditionEnterprise, true},
+		{"4.3.0.10-ee", common.EditionEnterprise, true},
+		{"4.3.0.10-ee", common.EditionCommunity, false},
+		{"4.3.0.10-ce", common.EditionEnterprise, false},
+		{"4.3.0.10", "professional", false},
+		{"1.2.3.4-ee", common.EditionEnterprise, false},
+		{"invalid-ee", common.EditionEnterprise, false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.result, IsSupportedForEdition(test.versionString, test.edition))
+	}
+}
@@ -18,18 +18,22 @@ package cluster
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	. "github.com/onsi/gomega"
 	"k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/travelaudience/aerospike-operator/pkg/admission"
+	"github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/common"
 	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
 	"github.com/travelaudience/aerospike-operator/pkg/asutils"
 	"github.com/travelaudience/aerospike-operator/pkg/pointers"
 	"github.com/travelaudience/aerospike-operator/pkg/utils/listoptions"
+	"github.com/travelaudience/aerospike-operator/pkg/utils/selectors"
 	"github.com/travelaudience/aerospike-operator/test/e2e/framework"
 )
 
@@ -87,18 +91,133 @@ func testCreateAerospikeClusterWithZeroNamespaces(tf *framework.TestFramework, n
 	aerospikeCluster.Spec.Namespaces = []aerospikev1alpha2.AerospikeNamespaceSpec{}
 	_, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
 	Expect(err).To(HaveOccurred())
-	Expect(tf.ErrorCauses(err)).To(ContainElement(MatchRegexp("the number of namespaces in the cluster must be exactly one")))
+	Expect(tf.ErrorCauses(err)).To(ContainElement(MatchRegexp("the number of namespaces in the cluster must be between 1 and 2")))
 }
 
-func testCreateAerospikeClusterWithTwoNamespaces(tf *framework.TestFramework, ns *v1.Namespace) {
+func testCreateAerospikeClusterWithThreeNamespaces(tf *framework.TestFramework, ns *v1.Namespace) {
 	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
 	aerospikeCluster.Spec.Namespaces = []aerospikev1alpha2.AerospikeNamespaceSpec{
 		tf.NewAerospikeNamespaceWithFileStorage("aerospike-namespace-0", 1, 1, 0, 1),
 		tf.NewAerospikeNamespaceWithFileStorage("aerospike-namespace-1", 1, 1, 0, 1),
+		tf.NewAerospikeNamespaceWithFileStorage("aerospike-namespace-2", 1, 1, 0, 1),
+	}
+	_, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).To(HaveOccurred())
+	Expect(tf.ErrorCauses(err)).To(ContainElement(MatchRegexp("the number of namespaces in the cluster must be between 1 and 2")))
+}
+
+func testCreateAerospikeClusterWithDuplicateNamespaces(tf *framework.TestFramework, ns *v1.Namespace) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	aerospikeCluster.Spec.Namespaces = []aerospikev1alpha2.AerospikeNamespaceSpec{
+		tf.NewAerospikeNamespaceWithFileStorage("aerospike-namespace-0", 1, 1, 0, 1),
+		tf.NewAerospikeNamespaceWithFileStorage("aerospike-namespace-0", 1, 1, 0, 1),
+	}
+	_, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).To(HaveOccurred())
+	Expect(tf.ErrorCauses(err)).To(ContainElement(MatchRegexp("namespace names must be unique")))
+}
+
+func testCreateAerospikeClusterWithUnsupportedConfig(tf *framework.TestFramework, ns *v1.Namespace) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	aerospikeCluster.Spec.AerospikeConfig = &aerospikev1alpha2.AerospikeConfigSpec{
+		Service: map[string]string{
+			"cluster-name": "foo",
+		},
+	}
+	_, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).To(HaveOccurred())
+	Expect(tf.ErrorCauses(err)).To(ContainElement(MatchRegexp("unsupported property \"cluster-name\" in the service stanza")))
+}
+
+func testCreateAerospikeClusterWithConfig(tf *framework.TestFramework, ns *v1.Namespace) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	aerospikeCluster.Spec.AerospikeConfig = &aerospikev1alpha2.AerospikeConfigSpec{
+		Service: map[string]string{
+			"migrate-threads": "2",
+		},
+	}
+	aerospikeCluster.Spec.Namespaces[0].AerospikeConfig = map[string]string{
+		"high-water-memory-pct": "70",
+		"write-block-size":      "128K",
+	}
+	res, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.WaitForClusterNodeCount(res, res.Spec.NodeCount)
+	Expect(err).NotTo(HaveOccurred())
+
+	// make sure that the overrides have been rendered in aerospike.conf
+	configMap, err := tf.KubeClient.CoreV1().ConfigMaps(ns.Name).Get(res.Name, metav1.GetOptions{})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(configMap.Data["aerospike.conf"]).To(MatchRegexp(`(?m)^\s*migrate-threads 2$`))
+	Expect(configMap.Data["aerospike.conf"]).To(MatchRegexp(`(?m)^\s*high-water-memory-pct 70$`))
+	Expect(configMap.Data["aerospike.conf"]).To(MatchRegexp(`(?m)^\s*write-block-size 128K$`))
+
+	// make sure that aerospike is actually using the overridden values
+	pods, err := tf.KubeClient.CoreV1().Pods(ns.Name).List(listoptions.ResourcesByClusterName(res.Name))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(pods.Items).To(HaveLen(int(res.Spec.NodeCount)))
+	for _, pod := range pods.Items {
+		service, err := asutils.GetConfig(pod.Status.PodIP, 3000, "service")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(service).To(HaveKeyWithValue("migrate-threads", "2"))
+		namespace, err := asutils.GetConfig(pod.Status.PodIP, 3000, fmt.Sprintf("namespace;id=%s", res.Spec.Namespaces[0].Name))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(namespace).To(HaveKeyWithValue("high-water-memory-pct", "70"))
+		Expect(namespace).To(HaveKeyWithValue("storage-engine.write-block-size", "131072"))
+	}
+}
+
+func testCreateAerospikeClusterWithUnsupportedNamespaceConfig(tf *framework.TestFramework, ns *v1.Namespace) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	aerospikeCluster.Spec.Namespaces[0].AerospikeConfig = map[string]string{
+		"replication-factor": "3",
 	}
 	_, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
 	Expect(err).To(HaveOccurred())
-	Expect(tf.ErrorCauses(err)).To(ContainElement(MatchRegexp("the number of namespaces in the cluster must be exactly one")))
+	Expect(tf.ErrorCauses(err)).To(ContainElement(MatchRegexp("unsupported property \"replication-factor\" in the configuration of namespace")))
+}
+
+func testCreateAerospikeClusterWithTwoNamespaces(tf *framework.TestFramework, ns *v1.Namespace) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	aerospikeCluster.Spec.Namespaces = []aerospikev1alpha2.AerospikeNamespaceSpec{
+		tf.NewAerospikeNamespaceWithFileStorage("aerospike-namespace-0", 1, 1, 0, 1),
+		tf.NewAerospikeNamespaceWithDeviceStorage("aerospike-namespace-1", 1, 1, 0, 1),
+	}
+	res, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.WaitForClusterNodeCount(res, res.Spec.NodeCount)
+	Expect(err).NotTo(HaveOccurred())
+
+	// make sure that each pod has a dedicated persistent volume claim for each namespace
+	pods, err := tf.KubeClient.CoreV1().Pods(ns.Name).List(listoptions.ResourcesByClusterName(res.Name))
+	Expect(err).NotTo(HaveOccurred())
+	for _, pod := range pods.Items {
+		claims := make(map[string]string)
+		for _, volume := range pod.Spec.Volumes {
+			if c := volume.VolumeSource.PersistentVolumeClaim; c != nil {
+				claim, err := tf.KubeClient.CoreV1().PersistentVolumeClaims(ns.Name).Get(c.ClaimName, metav1.GetOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				claims[claim.Labels[selectors.LabelNamespaceKey]] = claim.Name
+			}
+		}
+		Expect(claims).To(HaveLen(len(res.Spec.Namespaces)))
+		for _, namespace := range res.Spec.Namespaces {
+			Expect(claims).To(HaveKey(namespace.Name))
+		}
+	}
+
+	// make sure that data can be written to and read from every namespace
+	c, err := framework.NewAerospikeClient(res)
+	Expect(err).NotTo(HaveOccurred())
+	defer c.Close()
+	for _, namespace := range res.Spec.Namespaces {
+		err = c.WriteSequentialIntegers(namespace.Name, 1000)
+		Expect(err).NotTo(HaveOccurred())
+		err = c.ReadSequentialIntegers(namespace.Name, 1000)
+		Expect(err).NotTo(HaveOccurred())
+	}
 }
 
 func testCreateAerospikeClusterWithInvalidReplicationFactor(tf *framework.TestFramework, ns *v1.Namespace) {
@@ -132,6 +251,261 @@ func testCreateAerospikeClusterWithNodeCount(tf *framework.TestFramework, ns *v1
 	Expect(int32(clusterSize)).To(Equal(nodeCount))
 }
 
+func testCreateAerospikeClusterWithResources(tf *framework.TestFramework, ns *v1.Namespace) {
+	serverResources := v1.ResourceRequirements{
+		Requests: v1.ResourceList{
+			v1.ResourceCPU:    resource.MustParse("500m"),
+			v1.ResourceMemory: resource.MustParse("2Gi"),
+		},
+		Limits: v1.ResourceList{
+			v1.ResourceCPU:    resource.MustParse("500m"),
+			v1.ResourceMemory: resource.MustParse("2Gi"),
+		},
+	}
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	sidecarResources := v1.ResourceRequirements{
+		Requests: v1.ResourceList{
+			v1.ResourceCPU:    resource.MustParse("10m"),
+			v1.ResourceMemory: resource.MustParse("32Mi"),
+		},
+		Limits: v1.ResourceList{
+			v1.ResourceCPU:    resource.MustParse("10m"),
+			v1.ResourceMemory: resource.MustParse("32Mi"),
+		},
+	}
+	aerospikeCluster.Spec.Resources = &aerospikev1alpha2.AerospikeClusterResourcesSpec{
+		AerospikeServer: &serverResources,
+		Asprom:          &sidecarResources,
+		Init:            &sidecarResources,
+	}
+	res, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.WaitForClusterNodeCount(res, res.Spec.NodeCount)
+	Expect(err).NotTo(HaveOccurred())
+
+	pods, err := tf.KubeClient.CoreV1().Pods(ns.Name).List(listoptions.ResourcesByClusterName(res.Name))
+	Expect(err).NotTo(HaveOccurred())
+	for _, pod := range pods.Items {
+		for _, container := range pod.Spec.Containers {
+			if container.Name != "aerospike-server" {
+				continue
+			}
+			Expect(container.Resources.Requests.Cpu().Cmp(resource.MustParse("500m"))).To(Equal(0))
+			Expect(container.Resources.Requests.Memory().Cmp(resource.MustParse("2Gi"))).To(Equal(0))
+			Expect(container.Resources.Limits.Cpu().Cmp(resource.MustParse("500m"))).To(Equal(0))
+			Expect(container.Resources.Limits.Memory().Cmp(resource.MustParse("2Gi"))).To(Equal(0))
+		}
+		// requests match limits for every container, so the pod must have the guaranteed qos class
+		Expect(pod.Status.QOSClass).To(Equal(v1.PodQOSGuaranteed))
+	}
+}
+
+func testCreateAerospikeClusterWithRequestExceedingLimit(tf *framework.TestFramework, ns *v1.Namespace) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	aerospikeCluster.Spec.Resources = &aerospikev1alpha2.AerospikeClusterResourcesSpec{
+		Asprom: &v1.ResourceRequirements{
+			Requests: v1.ResourceList{
+				v1.ResourceMemory: resource.MustParse("64Mi"),
+			},
+			Limits: v1.ResourceList{
+				v1.ResourceMemory: resource.MustParse("32Mi"),
+			},
+		},
+	}
+	_, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).To(HaveOccurred())
+	Expect(tf.ErrorCauses(err)).To(ContainElement(MatchRegexp("the memory request for the asprom container cannot exceed its limit")))
+}
+
+func testCreateAerospikeClusterWithPodSpec(tf *framework.TestFramework, ns *v1.Namespace) {
+	toleration := v1.Toleration{
+		Key:      "dedicated",
+		Operator: v1.TolerationOpEqual,
+		Value:    "aerospike",
+		Effect:   v1.TaintEffectNoSchedule,
+	}
+	// replace the default (required) pod anti-affinity with a preferred one
+	affinity := &v1.Affinity{
+		PodAntiAffinity: &v1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []v1.WeightedPodAffinityTerm{
+				{
+					Weight: 100,
+					PodAffinityTerm: v1.PodAffinityTerm{
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"team": "data",
+							},
+						},
+						TopologyKey: "kubernetes.io/hostname",
+					},
+				},
+			},
+		},
+	}
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	aerospikeCluster.Spec.PodSpec = &aerospikev1alpha2.AerospikePodSpec{
+		Labels: map[string]string{
+			"team": "data",
+		},
+		Annotations: map[string]string{
+			"example.com/scrape": "true",
+		},
+		Tolerations: []v1.Toleration{toleration},
+		Affinity:    affinity,
+	}
+	res, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.WaitForClusterNodeCount(res, res.Spec.NodeCount)
+	Expect(err).NotTo(HaveOccurred())
+
+	pods, err := tf.KubeClient.CoreV1().Pods(ns.Name).List(listoptions.ResourcesByClusterName(res.Name))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(int32(len(pods.Items))).To(Equal(res.Spec.NodeCount))
+	for _, pod := range pods.Items {
+		Expect(pod.Labels).To(HaveKeyWithValue("team", "data"))
+		Expect(pod.Labels).To(HaveKeyWithValue(selectors.LabelClusterKey, res.Name))
+		Expect(pod.Annotations).To(HaveKeyWithValue("example.com/scrape", "true"))
+		Expect(pod.Spec.Tolerations).To(ContainElement(toleration))
+		Expect(pod.Spec.Affinity).To(Equal(affinity))
+	}
+}
+
+func testCreateAerospikeClusterWithReservedPodLabel(tf *framework.TestFramework, ns *v1.Namespace) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	aerospikeCluster.Spec.PodSpec = &aerospikev1alpha2.AerospikePodSpec{
+		Labels: map[string]string{
+			selectors.LabelClusterKey: "foo",
+		},
+	}
+	_, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).To(HaveOccurred())
+	Expect(tf.ErrorCauses(err)).To(ContainElement(MatchRegexp("label \"cluster\" is reserved")))
+}
+
+// newRacks returns a list of racks with the specified ids, all of which can
+// be scheduled on any linux node.
+func newRacks(ids ...int32) []aerospikev1alpha2.AerospikeRackSpec {
+	racks := make([]aerospikev1alpha2.AerospikeRackSpec, 0, len(ids))
+	for _, id := range ids {
+		racks = append(racks, aerospikev1alpha2.AerospikeRackSpec{
+			ID: id,
+			NodeSelector: map[string]string{
+				"beta.kubernetes.io/os": "linux",
+			},
+		})
+	}
+	return racks
+}
+
+func testCreateAerospikeClusterWithRacks(tf *framework.TestFramework, ns *v1.Namespace) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	aerospikeCluster.Spec.NodeCount = 3
+	aerospikeCluster.Spec.Racks = newRacks(1, 2)
+	res, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.WaitForClusterNodeCount(res, res.Spec.NodeCount)
+	Expect(err).NotTo(HaveOccurred())
+
+	// pods must be assigned to racks in a round-robin fashion
+	for i, rack := range []string{"1", "2", "1"} {
+		pod, err := tf.KubeClient.CoreV1().Pods(ns.Name).Get(fmt.Sprintf("%s-%d", res.Name, i), metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pod.Labels).To(HaveKeyWithValue(selectors.LabelRackKey, rack))
+		Expect(pod.Spec.NodeSelector).To(HaveKeyWithValue("beta.kubernetes.io/os", "linux"))
+		// the rack id must have reached the running namespace configuration
+		config, err := asutils.GetConfig(pod.Status.PodIP, 3000, fmt.Sprintf("namespace;id=%s", res.Spec.Namespaces[0].Name))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(config).To(HaveKeyWithValue("rack-id", rack))
+	}
+}
+
+func testCreateAerospikeClusterWithDuplicateRacks(tf *framework.TestFramework, ns *v1.Namespace) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	aerospikeCluster.Spec.Racks = newRacks(1, 1)
+	_, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).To(HaveOccurred())
+	Expect(tf.ErrorCauses(err)).To(ContainElement(MatchRegexp("rack ids must be unique")))
+}
+
+func testChangeAerospikeClusterRacks(tf *framework.TestFramework, ns *v1.Namespace) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	aerospikeCluster.Spec.Racks = newRacks(1)
+	res, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	res.Spec.Racks = newRacks(1, 2)
+	_, err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Update(res)
+	Expect(err).To(HaveOccurred())
+	Expect(tf.ErrorCauses(err)).To(ContainElement(MatchRegexp("the racks of an existing cluster cannot be changed")))
+}
+
+func testCreateAerospikeClusterWithNodePortExternalAccess(tf *framework.TestFramework, ns *v1.Namespace) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	aerospikeCluster.Spec.NodeCount = 2
+	aerospikeCluster.Spec.ExternalAccess = &aerospikev1alpha2.AerospikeExternalAccessSpec{
+		Type: common.ExternalAccessTypeNodePort,
+	}
+	res, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.WaitForClusterNodeCount(res, res.Spec.NodeCount)
+	Expect(err).NotTo(HaveOccurred())
+
+	// every pod must be targeted by its own nodeport service
+	for i := 0; i < int(res.Spec.NodeCount); i++ {
+		podName := fmt.Sprintf("%s-%d", res.Name, i)
+		pod, err := tf.KubeClient.CoreV1().Pods(ns.Name).Get(podName, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pod.Labels).To(HaveKeyWithValue(selectors.LabelPodKey, podName))
+		service, err := tf.KubeClient.CoreV1().Services(ns.Name).Get(fmt.Sprintf("%s-external", podName), metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(service.Spec.Type).To(Equal(v1.ServiceTypeNodePort))
+		Expect(service.Spec.Selector).To(HaveKeyWithValue(selectors.LabelPodKey, podName))
+		Expect(service.Spec.Ports[0].NodePort).NotTo(BeZero())
+
+		// aerospike must advertise the external ip of the kubernetes node
+		// (or its internal ip, if it has none) and the allocated node port
+		node, err := tf.KubeClient.CoreV1().Nodes().Get(pod.Spec.NodeName, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		config, err := asutils.GetConfig(pod.Status.PodIP, 3000, "network")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(config).To(HaveKeyWithValue("service.alternate-access-address", nodeAddress(node)))
+		Expect(config).To(HaveKeyWithValue("service.alternate-access-port", strconv.Itoa(int(service.Spec.Ports[0].NodePort))))
+	}
+}
+
+// nodeAddress returns the external ip of the specified kubernetes node, or
+// its internal ip if it has no external ip.
+func nodeAddress(node *v1.Node) string {
+	for _, addressType := range []v1.NodeAddressType{v1.NodeExternalIP, v1.NodeInternalIP} {
+		for _, address := range node.Status.Addresses {
+			if address.Type == addressType {
+				return address.Address
+			}
+		}
+	}
+	return ""
+}
+
+func testCreateEnterpriseAerospikeClusterWithoutFeatureKeySecret(tf *framework.TestFramework, ns *v1.Namespace) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	aerospikeCluster.Spec.Edition = common.EditionEnterprise
+	_, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).To(HaveOccurred())
+	Expect(tf.ErrorCauses(err)).To(ContainElement(MatchRegexp("a feature key secret must be specified for the enterprise edition")))
+}
+
+func testCreateAerospikeClusterWithMismatchingEdition(tf *framework.TestFramework, ns *v1.Namespace) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	aerospikeCluster.Spec.Version = aerospikeCluster.Spec.Version + "-ee"
+	_, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).To(HaveOccurred())
+	Expect(tf.ErrorCauses(err)).To(ContainElement(MatchRegexp("is not supported for the community edition")))
+}
+
 func testConnectToAerospikeCluster(tf *framework.TestFramework, ns *v1.Namespace) {
 	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
 	res, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
@@ -0,0 +1,89 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
+	"github.com/travelaudience/aerospike-operator/pkg/asutils"
+	"github.com/travelaudience/aerospike-operator/pkg/pointers"
+	"github.com/travelaudience/aerospike-operator/pkg/utils/selectors"
+	"github.com/travelaudience/aerospike-operator/test/e2e/framework"
+)
+
+func testDynamicConfigUpdate(tf *framework.TestFramework, ns *v1.Namespace, nodeCount int32) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	aerospikeCluster.Spec.NodeCount = nodeCount
+	// memory-size can only be changed dynamically when the memory of the
+	// aerospike-server container is set explicitly
+	aerospikeCluster.Spec.Resources = &aerospikev1alpha2.AerospikeClusterResourcesSpec{
+		AerospikeServer: &v1.ResourceRequirements{
+			Requests: v1.ResourceList{
+				v1.ResourceMemory: resource.MustParse("1Gi"),
+			},
+		},
+	}
+	asc, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.WaitForClusterNodeCount(asc, nodeCount)
+	Expect(err).NotTo(HaveOccurred())
+
+	listOptions := metav1.ListOptions{LabelSelector: selectors.ResourcesByClusterName(asc.Name).String()}
+	pods, err := tf.KubeClient.CoreV1().Pods(ns.Name).List(listOptions)
+	Expect(err).NotTo(HaveOccurred())
+	oldUIDs := make(map[string]bool, len(pods.Items))
+	for _, pod := range pods.Items {
+		oldUIDs[string(pod.UID)] = true
+	}
+
+	// change only dynamic properties of the namespace
+	asc, err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Get(asc.Name, metav1.GetOptions{})
+	Expect(err).NotTo(HaveOccurred())
+	asc.Spec.Namespaces[0].DefaultTTL = pointers.NewString("3600s")
+	asc.Spec.Namespaces[0].MemorySize = pointers.NewString("2G")
+	asc.Spec.Namespaces[0].AerospikeConfig = map[string]string{"high-water-memory-pct": "70"}
+	asc, err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Update(asc)
+	Expect(err).NotTo(HaveOccurred())
+
+	// wait for every node to report the new values
+	context := fmt.Sprintf("namespace;id=%s", asc.Spec.Namespaces[0].Name)
+	for _, pod := range pods.Items {
+		Eventually(func() (map[string]string, error) {
+			return asutils.GetConfig(pod.Status.PodIP, 3000, context)
+		}, 5*time.Minute, 5*time.Second).Should(And(
+			HaveKeyWithValue("default-ttl", "3600"),
+			HaveKeyWithValue("memory-size", "2147483648"),
+			HaveKeyWithValue("high-water-memory-pct", "70"),
+		))
+	}
+
+	// make sure no pod has been re-created
+	pods, err = tf.KubeClient.CoreV1().Pods(ns.Name).List(listOptions)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(pods.Items).To(HaveLen(int(nodeCount)))
+	for _, pod := range pods.Items {
+		Expect(oldUIDs).To(HaveKey(string(pod.UID)))
+	}
+}
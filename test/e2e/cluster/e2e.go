@@ -21,6 +21,7 @@ import (
 	. "github.com/onsi/gomega"
 	"k8s.io/api/core/v1"
 
+	"github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/common"
 	"github.com/travelaudience/aerospike-operator/test/e2e/framework"
 )
 
@@ -64,15 +65,60 @@ var _ = Describe("AerospikeCluster", func() {
 		It("cannot be created with len(spec.namespaces)==0", func() {
 			testCreateAerospikeClusterWithZeroNamespaces(tf, ns)
 		})
-		It("cannot be created with len(spec.namespaces)==2", func() {
+		It("cannot be created with len(spec.namespaces)==3", func() {
+			testCreateAerospikeClusterWithThreeNamespaces(tf, ns)
+		})
+		It("cannot be created with duplicate spec.namespaces[*].name", func() {
+			testCreateAerospikeClusterWithDuplicateNamespaces(tf, ns)
+		})
+		It("is created with len(spec.namespaces)==2 and a persistent volume per namespace", func() {
 			testCreateAerospikeClusterWithTwoNamespaces(tf, ns)
 		})
+		It("applies the overrides specified in spec.aerospikeConfig and spec.namespaces[*].aerospikeConfig", func() {
+			testCreateAerospikeClusterWithConfig(tf, ns)
+		})
+		It("cannot be created with unsupported properties in spec.aerospikeConfig", func() {
+			testCreateAerospikeClusterWithUnsupportedConfig(tf, ns)
+		})
+		It("cannot be created with unsupported properties in spec.namespaces[*].aerospikeConfig", func() {
+			testCreateAerospikeClusterWithUnsupportedNamespaceConfig(tf, ns)
+		})
 		It("cannot be created if spec.namespaces.replicationFactor[*] > spec.nodeCount", func() {
 			testCreateAerospikeClusterWithInvalidReplicationFactor(tf, ns)
 		})
 		It("is created with the provided spec.nodeCount", func() {
 			testCreateAerospikeClusterWithNodeCount(tf, ns, 2)
 		})
+		It("applies the resources specified in spec.resources to its containers", func() {
+			testCreateAerospikeClusterWithResources(tf, ns)
+		})
+		It("cannot be created if a request in spec.resources exceeds its limit", func() {
+			testCreateAerospikeClusterWithRequestExceedingLimit(tf, ns)
+		})
+		It("applies the overrides specified in spec.podSpec to its pods", func() {
+			testCreateAerospikeClusterWithPodSpec(tf, ns)
+		})
+		It("cannot be created with reserved labels in spec.podSpec.labels", func() {
+			testCreateAerospikeClusterWithReservedPodLabel(tf, ns)
+		})
+		It("distributes its pods across the racks specified in spec.racks", func() {
+			testCreateAerospikeClusterWithRacks(tf, ns)
+		})
+		It("cannot be created with duplicate spec.racks[*].id", func() {
+			testCreateAerospikeClusterWithDuplicateRacks(tf, ns)
+		})
+		It("cannot change spec.racks", func() {
+			testChangeAerospikeClusterRacks(tf, ns)
+		})
+		It("creates a NodePort service per pod when spec.externalAccess.type==NodePort", func() {
+			testCreateAerospikeClusterWithNodePortExternalAccess(tf, ns)
+		})
+		It("cannot be created with spec.edition==enterprise and no spec.featureKeySecret", func() {
+			testCreateEnterpriseAerospikeClusterWithoutFeatureKeySecret(tf, ns)
+		})
+		It("cannot be created with an enterprise spec.version and no spec.edition", func() {
+			testCreateAerospikeClusterWithMismatchingEdition(tf, ns)
+		})
 		It("accepts connections on the service port", func() {
 			testConnectToAerospikeCluster(tf, ns)
 		})
@@ -82,15 +128,84 @@ var _ = Describe("AerospikeCluster", func() {
 		It("supports file storage", func() {
 			testFileStorage(tf, ns, 1, 2)
 		})
+		It("supports memory storage", func() {
+			testMemoryStorage(tf, ns, 2)
+		})
+		It("cannot be scaled down with memory storage and spec.namespaces[*].replicationFactor==1", func() {
+			testScaleDownMemoryStorageWithReplicationFactorOne(tf, ns)
+		})
+		It("supports changing spec.namespaces[*].memorySize with memory storage", func() {
+			testMemoryStorageMemorySizeChange(tf, ns, 2)
+		})
+		It("supports striping data across multiple files", func() {
+			testMultipleVolumes(tf, ns, common.StorageTypeFile, 2, 2)
+		})
+		It("supports striping data across multiple devices", func() {
+			testMultipleVolumes(tf, ns, common.StorageTypeDevice, 2, 3)
+		})
+		It("cannot change spec.namespaces[*].storage.volumeCount", func() {
+			testVolumeCountChange(tf, ns)
+		})
 		It("reuses the persistent volume of a deleted pod", func() {
 			testVolumeIsReused(tf, ns, 2)
 		})
+		It("replaces persistent volumes and does not lose data in a namespace after changing spec.namespaces[*].storage.size", func() {
+			testStorageSizeChange(tf, ns, 2, 10000)
+		})
+		It("cannot change spec.namespaces[*].storage if spec.namespaces[*].replicationFactor==1", func() {
+			testStorageChangeWithReplicationFactorOne(tf, ns)
+		})
+		It("expands persistent volumes in place after increasing spec.namespaces[*].storage.size", func() {
+			testStorageSizeExpansion(tf, ns, 2, 10000)
+		})
+		It("cannot decrease spec.namespaces[*].storage.size", func() {
+			testStorageSizeDecrease(tf, ns)
+		})
 		It("has the correct number of nodes after scaling up", func() {
 			testNodeCountAfterScaling(tf, ns, 1, 3)
 		})
 		It("has the correct number of nodes after scaling down", func() {
 			testNodeCountAfterScaling(tf, ns, 3, 1)
 		})
+		It("does not scale while spec.paused==true and scales after being resumed", func() {
+			testPauseAndResume(tf, ns, 1, 2)
+		})
+		It("safely deletes a node under maintenance and re-creates it after maintenance", func() {
+			testNodeMaintenance(tf, ns, 2, 1)
+		})
+		It("rejects nodes under maintenance with an invalid index", func() {
+			testNodeMaintenanceWithInvalidIndex(tf, ns, 2)
+		})
+		It("rejects as many nodes under maintenance as the replication factor", func() {
+			testNodeMaintenanceWithReplicationFactorTooLow(tf, ns, 3)
+		})
+		It("uses info-based probes that expect the current cluster size after scaling", func() {
+			testProbesAfterScaling(tf, ns, 1, 3)
+		})
+		It("reports the phase and the observed generation", func() {
+			testPhaseAndObservedGeneration(tf, ns, 2)
+		})
+		It("reports an invalid phase when the storage class does not exist", func() {
+			testPhaseWithInvalidStorageClass(tf, ns)
+		})
+		It("reports the status of each node", func() {
+			testNodeStatus(tf, ns, 2)
+		})
+		It("restarts every node when spec.restartedAt changes", func() {
+			testRollingRestart(tf, ns, 2)
+		})
+		It("keeps on reconciling other clusters while pods are pending", func() {
+			testReconcileWithPendingClusters(tf, ns, 8)
+		})
+		It("resumes a rolling restart after aerospike-operator restarts", func() {
+			testResumeRollingRestartAfterOperatorRestart(tf, ns, 3)
+		})
+		It("creates new pods in parallel up to maxSurge", func() {
+			testParallelPodCreation(tf, ns, 4, 3)
+		})
+		It("applies dynamic configuration changes without restarting nodes", func() {
+			testDynamicConfigUpdate(tf, ns, 2)
+		})
 		It("has the same number of nodes after rolling restart", func() {
 			testNodeCountAfterRestart(tf, ns, 2)
 		})
@@ -130,6 +245,33 @@ var _ = Describe("AerospikeCluster", func() {
 		It("makes pre-upgrade backups, re-uses persistent volumes, and does not lose data in a namespace after an upgrade from 4.2.0.10 to 4.3.0.10", func() {
 			testReusePVCsAndNoDataLossOnAerospikeUpgrade(tf, ns, 2, 10000, "4.2.0.10", "4.3.0.10")
 		})
+		It("rolls back to the previous version when an upgrade fails and spec.onUpgradeFailure==rollback", func() {
+			testRollbackOnFailedUpgrade(tf, ns, 2, common.UpgradeFailurePolicyRollback, "4.0.0.4", "4.0.0.6")
+		})
+		It("rolls back to the previous version and reports the missing backups when an upgrade fails and spec.onUpgradeFailure==restore", func() {
+			testRollbackOnFailedUpgrade(tf, ns, 2, common.UpgradeFailurePolicyRestore, "4.0.0.4", "4.0.0.6")
+		})
+		It("rolls back to the previous version without losing data when a pod fails on the target version and spec.onUpgradeFailure==rollback", func() {
+			testRollbackOnFailedPodUpgrade(tf, ns, 2, 10000, common.UpgradeFailurePolicyRollback, "4.0.0.4", "4.0.0.6")
+		})
+		It("rolls back to the previous version and restores the pre-upgrade backups when a pod fails on the target version and spec.onUpgradeFailure==restore", func() {
+			testRollbackOnFailedPodUpgrade(tf, ns, 2, 10000, common.UpgradeFailurePolicyRestore, "4.0.0.4", "4.0.0.6")
+		})
+		It("resumes a failed upgrade when spec.recovery.action==resume", func() {
+			testRecoveryAfterFailedUpgrade(tf, ns, 2, common.RecoveryActionResume, "4.0.0.4", "4.0.0.6")
+		})
+		It("rolls back a failed upgrade when spec.recovery.action==abort", func() {
+			testRecoveryAfterFailedUpgrade(tf, ns, 2, common.RecoveryActionAbort, "4.0.0.4", "4.0.0.6")
+		})
+		It("resumes an upgrade that failed after some pods were upgraded when spec.recovery.action==resume", func() {
+			testRecoveryAfterPartialUpgrade(tf, ns, 3, 10000, "4.0.0.4", "4.0.0.6")
+		})
+		It("refuses spec.recovery when a pod is running neither the source nor the target version", func() {
+			testRecoveryFailsWithUnexpectedVersion(tf, ns, 2, "4.0.0.4", "4.0.0.6", "4.0.0.5")
+		})
+		It("rejects spec.recovery if no upgrade has failed", func() {
+			testRecoveryWithoutFailedUpgrade(tf, ns, 1)
+		})
 		It("node IDs are kept after restart", func() {
 			testNodeIDsAfterRestart(tf, ns, 2)
 		})
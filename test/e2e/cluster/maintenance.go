@@ -0,0 +1,91 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/travelaudience/aerospike-operator/pkg/pointers"
+	"github.com/travelaudience/aerospike-operator/test/e2e/framework"
+)
+
+func testNodeMaintenance(tf *framework.TestFramework, ns *v1.Namespace, nodeCount int32, index int32) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	aerospikeCluster.Spec.NodeCount = nodeCount
+	aerospikeCluster.Spec.Namespaces[0].ReplicationFactor = &nodeCount
+	asc, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.WaitForClusterNodeCount(asc, nodeCount)
+	Expect(err).NotTo(HaveOccurred())
+
+	podName := fmt.Sprintf("%s-%d", asc.Name, index)
+	podExists := func() (bool, error) {
+		_, err := tf.KubeClient.CoreV1().Pods(ns.Name).Get(podName, metav1.GetOptions{})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		return true, nil
+	}
+
+	// put the node under maintenance and make sure its pod is deleted and not re-created
+	asc, err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Get(asc.Name, metav1.GetOptions{})
+	Expect(err).NotTo(HaveOccurred())
+	asc.Spec.NodesUnderMaintenance = []int32{index}
+	asc, err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Update(asc)
+	Expect(err).NotTo(HaveOccurred())
+	Eventually(podExists, 5*time.Minute, 5*time.Second).Should(BeFalse())
+	Consistently(podExists, time.Minute, 5*time.Second).Should(BeFalse())
+
+	// end the maintenance and make sure the pod is re-created
+	asc, err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Get(asc.Name, metav1.GetOptions{})
+	Expect(err).NotTo(HaveOccurred())
+	asc.Spec.NodesUnderMaintenance = nil
+	asc, err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Update(asc)
+	Expect(err).NotTo(HaveOccurred())
+	err = tf.WaitForClusterNodeCount(asc, nodeCount)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(podExists()).To(BeTrue())
+}
+
+func testNodeMaintenanceWithInvalidIndex(tf *framework.TestFramework, ns *v1.Namespace, nodeCount int32) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	aerospikeCluster.Spec.NodeCount = nodeCount
+	aerospikeCluster.Spec.NodesUnderMaintenance = []int32{nodeCount}
+	_, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).To(HaveOccurred())
+	Expect(tf.ErrorCauses(err)).To(ContainElement(MatchRegexp("node index %d under maintenance must be between 0 and %d", nodeCount, nodeCount-1)))
+}
+
+func testNodeMaintenanceWithReplicationFactorTooLow(tf *framework.TestFramework, ns *v1.Namespace, nodeCount int32) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	aerospikeCluster.Spec.NodeCount = nodeCount
+	aerospikeCluster.Spec.Namespaces[0].ReplicationFactor = pointers.NewInt32(2)
+	aerospikeCluster.Spec.NodesUnderMaintenance = []int32{0, 1}
+	_, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).To(HaveOccurred())
+	Expect(tf.ErrorCauses(err)).To(ContainElement(MatchRegexp("the number of nodes under maintenance must be lower than the replication factor of namespace aerospike-namespace-0")))
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"time"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/travelaudience/aerospike-operator/test/e2e/framework"
+)
+
+func testNodeStatus(tf *framework.TestFramework, ns *v1.Namespace, nodeCount int32) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	aerospikeCluster.Spec.NodeCount = nodeCount
+	asc, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.WaitForClusterNodeCount(asc, nodeCount)
+	Expect(err).NotTo(HaveOccurred())
+
+	// the status of each node is refreshed periodically, so we allow for
+	// some time until every node is reported as ready
+	Eventually(func() (int32, error) {
+		asc, err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Get(asc.Name, metav1.GetOptions{})
+		if err != nil {
+			return 0, err
+		}
+		return asc.Status.ReadyNodes, nil
+	}, 2*time.Minute, 5*time.Second).Should(Equal(nodeCount))
+	Expect(asc.Status.TotalNodes).To(Equal(nodeCount))
+	Expect(asc.Status.Nodes).To(HaveLen(int(nodeCount)))
+
+	clusterKey := asc.Status.Nodes[0].ClusterKey
+	Expect(clusterKey).NotTo(BeEmpty())
+	for _, node := range asc.Status.Nodes {
+		Expect(node.Ready).To(BeTrue())
+		Expect(node.NodeID).NotTo(BeEmpty())
+		Expect(node.PodIP).NotTo(BeEmpty())
+		Expect(node.Version).To(Equal(asc.Spec.Version))
+		Expect(node.ClusterSize).To(Equal(nodeCount))
+		Expect(node.ClusterKey).To(Equal(clusterKey))
+		Expect(node.PersistentVolumeClaims).To(HaveLen(len(asc.Spec.Namespaces)))
+	}
+}
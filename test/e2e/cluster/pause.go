@@ -0,0 +1,71 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"time"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/api/core/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/common"
+	"github.com/travelaudience/aerospike-operator/pkg/utils/selectors"
+	"github.com/travelaudience/aerospike-operator/test/e2e/framework"
+)
+
+func testPauseAndResume(tf *framework.TestFramework, ns *v1.Namespace, initialNodeCount, finalNodeCount int32) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	aerospikeCluster.Spec.NodeCount = initialNodeCount
+	asc, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.WaitForClusterNodeCount(asc, initialNodeCount)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.SetClusterPausedAndWait(asc, true)
+	Expect(err).NotTo(HaveOccurred())
+
+	// scale the cluster and make sure the change is not applied while paused
+	asc, err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Get(asc.Name, metav1.GetOptions{})
+	Expect(err).NotTo(HaveOccurred())
+	asc.Spec.NodeCount = finalNodeCount
+	asc, err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Update(asc)
+	Expect(err).NotTo(HaveOccurred())
+	Consistently(func() (int, error) {
+		pods, err := tf.KubeClient.CoreV1().Pods(ns.Name).List(metav1.ListOptions{LabelSelector: selectors.ResourcesByClusterName(asc.Name).String()})
+		if err != nil {
+			return 0, err
+		}
+		return len(pods.Items), nil
+	}, time.Minute, 5*time.Second).Should(BeEquivalentTo(initialNodeCount))
+
+	err = tf.SetClusterPausedAndWait(asc, false)
+	Expect(err).NotTo(HaveOccurred())
+	err = tf.WaitForClusterNodeCount(asc, finalNodeCount)
+	Expect(err).NotTo(HaveOccurred())
+
+	asc, err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Get(asc.Name, metav1.GetOptions{})
+	Expect(err).NotTo(HaveOccurred())
+	var conditionTypes []apiextensions.CustomResourceDefinitionConditionType
+	for _, c := range asc.Status.Conditions {
+		conditionTypes = append(conditionTypes, c.Type)
+	}
+	Expect(conditionTypes).To(ContainElement(common.ConditionClusterPaused))
+	Expect(conditionTypes).To(ContainElement(common.ConditionClusterResumed))
+}
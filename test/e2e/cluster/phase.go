@@ -0,0 +1,83 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"time"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/api/core/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/common"
+	"github.com/travelaudience/aerospike-operator/pkg/pointers"
+	"github.com/travelaudience/aerospike-operator/pkg/utils/selectors"
+	"github.com/travelaudience/aerospike-operator/test/e2e/framework"
+)
+
+func testPhaseAndObservedGeneration(tf *framework.TestFramework, ns *v1.Namespace, nodeCount int32) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	aerospikeCluster.Spec.NodeCount = nodeCount
+	asc, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.WaitForClusterNodeCount(asc, nodeCount)
+	Expect(err).NotTo(HaveOccurred())
+
+	// the phase is computed from the status of each node, which is refreshed
+	// periodically, so we allow for some time until it is reported as running
+	Eventually(func() (string, error) {
+		asc, err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Get(asc.Name, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		return asc.Status.Phase, nil
+	}, 2*time.Minute, 5*time.Second).Should(Equal(common.ClusterPhaseRunning))
+	Expect(asc.Status.ObservedGeneration).To(Equal(asc.Generation))
+	Expect(asc.Status.Selector).To(Equal(selectors.ResourcesByClusterName(asc.Name).String()))
+	Expect(getCondition(asc.Status.Conditions, common.ConditionClusterValid)).To(Equal(apiextensions.ConditionTrue))
+	Expect(getCondition(asc.Status.Conditions, common.ConditionClusterReady)).To(Equal(apiextensions.ConditionTrue))
+}
+
+func testPhaseWithInvalidStorageClass(tf *framework.TestFramework, ns *v1.Namespace) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	aerospikeCluster.Spec.Namespaces[0].Storage.StorageClassName = pointers.NewString("non-existing-storage-class")
+	asc, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	Eventually(func() (string, error) {
+		asc, err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Get(asc.Name, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		return asc.Status.Phase, nil
+	}, 2*time.Minute, 5*time.Second).Should(Equal(common.ClusterPhaseInvalid))
+	Expect(asc.Status.ObservedGeneration).To(Equal(asc.Generation))
+	Expect(getCondition(asc.Status.Conditions, common.ConditionClusterValid)).To(Equal(apiextensions.ConditionFalse))
+}
+
+// getCondition returns the status of the condition with the specified type,
+// or an empty string if no such condition exists.
+func getCondition(conditions []apiextensions.CustomResourceDefinitionCondition, conditionType apiextensions.CustomResourceDefinitionConditionType) apiextensions.ConditionStatus {
+	for _, c := range conditions {
+		if c.Type == conditionType {
+			return c.Status
+		}
+	}
+	return ""
+}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/travelaudience/aerospike-operator/pkg/asutils"
 	"github.com/travelaudience/aerospike-operator/pkg/pointers"
+	"github.com/travelaudience/aerospike-operator/pkg/utils/selectors"
 	"github.com/travelaudience/aerospike-operator/test/e2e/framework"
 )
 
@@ -160,3 +161,41 @@ func testNodeIDsAfterRestart(tf *framework.TestFramework, ns *v1.Namespace, node
 		Expect(found).To(Equal(true))
 	}
 }
+
+func testRollingRestart(tf *framework.TestFramework, ns *v1.Namespace, nodeCount int32) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	aerospikeCluster.Spec.NodeCount = nodeCount
+	asc, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.WaitForClusterNodeCount(asc, nodeCount)
+	Expect(err).NotTo(HaveOccurred())
+
+	listOptions := metav1.ListOptions{LabelSelector: selectors.ResourcesByClusterName(asc.Name).String()}
+	pods, err := tf.KubeClient.CoreV1().Pods(ns.Name).List(listOptions)
+	Expect(err).NotTo(HaveOccurred())
+	oldUIDs := make(map[string]bool, len(pods.Items))
+	for _, pod := range pods.Items {
+		oldUIDs[string(pod.UID)] = true
+	}
+
+	err = tf.RestartClusterAndWait(asc)
+	Expect(err).NotTo(HaveOccurred())
+
+	// make sure every pod has been re-created
+	pods, err = tf.KubeClient.CoreV1().Pods(ns.Name).List(listOptions)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(pods.Items).To(HaveLen(int(nodeCount)))
+	for _, pod := range pods.Items {
+		Expect(oldUIDs).NotTo(HaveKey(string(pod.UID)))
+	}
+
+	asc, err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(asc.Namespace).Get(asc.Name, metav1.GetOptions{})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(asc.Status.RestartGeneration).To(BeEquivalentTo(1))
+	Expect(asc.Status.RestartedNodes).To(Equal(nodeCount))
+
+	clusterSize, err := asutils.GetClusterSize(fmt.Sprintf("%s.%s", asc.Name, asc.Namespace), 3000)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(int32(clusterSize)).To(Equal(nodeCount))
+}
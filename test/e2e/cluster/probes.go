@@ -0,0 +1,77 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"strconv"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/travelaudience/aerospike-operator/pkg/utils/selectors"
+	"github.com/travelaudience/aerospike-operator/test/e2e/framework"
+)
+
+const (
+	expectedClusterSizeAnnotation = "aerospike.travelaudience.com/expected-cluster-size"
+)
+
+func testProbesAfterScaling(tf *framework.TestFramework, ns *v1.Namespace, initialNodeCount, finalNodeCount int32) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	aerospikeCluster.Spec.NodeCount = initialNodeCount
+	asc, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.WaitForClusterNodeCount(asc, initialNodeCount)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.ScaleCluster(asc, finalNodeCount)
+	Expect(err).NotTo(HaveOccurred())
+
+	// every pod must use the info-based probes and expect the final cluster size
+	listOptions := metav1.ListOptions{LabelSelector: selectors.ResourcesByClusterName(asc.Name).String()}
+	pods, err := tf.KubeClient.CoreV1().Pods(ns.Name).List(listOptions)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(pods.Items).To(HaveLen(int(finalNodeCount)))
+	for _, pod := range pods.Items {
+		Expect(pod.Annotations[expectedClusterSizeAnnotation]).To(Equal(strconv.Itoa(int(finalNodeCount))))
+		container := pod.Spec.Containers[0]
+		Expect(container.ReadinessProbe).NotTo(BeNil())
+		Expect(container.ReadinessProbe.Exec).NotTo(BeNil())
+		Expect(container.LivenessProbe).NotTo(BeNil())
+		Expect(container.LivenessProbe.Exec).NotTo(BeNil())
+	}
+
+	// every pod must eventually be ready
+	Eventually(func() (int, error) {
+		pods, err := tf.KubeClient.CoreV1().Pods(ns.Name).List(listOptions)
+		if err != nil {
+			return 0, err
+		}
+		ready := 0
+		for _, pod := range pods.Items {
+			for _, c := range pod.Status.Conditions {
+				if c.Type == v1.PodReady && c.Status == v1.ConditionTrue {
+					ready++
+				}
+			}
+		}
+		return ready, nil
+	}, 2*time.Minute, 5*time.Second).Should(BeEquivalentTo(finalNodeCount))
+}
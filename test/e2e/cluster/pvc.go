@@ -19,13 +19,17 @@ package cluster
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	. "github.com/onsi/gomega"
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/common"
 	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
+	"github.com/travelaudience/aerospike-operator/pkg/asutils"
+	"github.com/travelaudience/aerospike-operator/pkg/pointers"
 	"github.com/travelaudience/aerospike-operator/pkg/utils/listoptions"
 	"github.com/travelaudience/aerospike-operator/pkg/utils/selectors"
 	"github.com/travelaudience/aerospike-operator/test/e2e/framework"
@@ -111,6 +115,162 @@ func testFileStorage(tf *framework.TestFramework, ns *v1.Namespace, nodeCount in
 	}
 }
 
+func testMemoryStorage(tf *framework.TestFramework, ns *v1.Namespace, nodeCount int32) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	aerospikeCluster.Spec.NodeCount = nodeCount
+	ns1 := tf.NewAerospikeNamespaceWithMemoryStorage("aerospike-namespace-0", 2, 1, 0)
+	aerospikeCluster.Spec.Namespaces = []aerospikev1alpha2.AerospikeNamespaceSpec{ns1}
+	res, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.WaitForClusterNodeCount(res, nodeCount)
+	Expect(err).NotTo(HaveOccurred())
+
+	pods, err := tf.KubeClient.CoreV1().Pods(ns.Name).List(listoptions.ResourcesByClusterName(res.Name))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(int32(len(pods.Items))).To(Equal(nodeCount))
+
+	// no persistent volume claims must have been created
+	for _, pod := range pods.Items {
+		for _, volume := range pod.Spec.Volumes {
+			Expect(volume.VolumeSource.PersistentVolumeClaim).To(BeNil())
+		}
+	}
+	pvcs, err := tf.KubeClient.CoreV1().PersistentVolumeClaims(ns.Name).List(listoptions.ResourcesByClusterName(res.Name))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(pvcs.Items).To(BeEmpty())
+
+	c, err := framework.NewAerospikeClient(res)
+	Expect(err).NotTo(HaveOccurred())
+	t, err := c.GetNamespaceStorageEngine(ns1.Name)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(t).To(Equal(common.StorageTypeMemory))
+}
+
+func testScaleDownMemoryStorageWithReplicationFactorOne(tf *framework.TestFramework, ns *v1.Namespace) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	aerospikeCluster.Spec.NodeCount = 2
+	ns1 := tf.NewAerospikeNamespaceWithMemoryStorage("aerospike-namespace-0", 1, 1, 0)
+	aerospikeCluster.Spec.Namespaces = []aerospikev1alpha2.AerospikeNamespaceSpec{ns1}
+	res, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.ScaleCluster(res, 1)
+	Expect(err).To(HaveOccurred())
+	Expect(tf.ErrorCauses(err)).To(ContainElement(MatchRegexp("cannot scale down a cluster with namespace aerospike-namespace-0")))
+}
+
+func testMemoryStorageMemorySizeChange(tf *framework.TestFramework, ns *v1.Namespace, nodeCount int32) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	aerospikeCluster.Spec.NodeCount = nodeCount
+	ns1 := tf.NewAerospikeNamespaceWithMemoryStorage("aerospike-namespace-0", 2, 1, 0)
+	aerospikeCluster.Spec.Namespaces = []aerospikev1alpha2.AerospikeNamespaceSpec{ns1}
+	res, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.WaitForClusterNodeCount(res, nodeCount)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.ChangeNamespaceMemorySizeAndScaleClusterAndWait(res, 2, nodeCount)
+	Expect(err).NotTo(HaveOccurred())
+
+	// wait for every node to report the new memory size
+	context := fmt.Sprintf("namespace;id=%s", ns1.Name)
+	Eventually(func() (int, error) {
+		pods, err := tf.KubeClient.CoreV1().Pods(ns.Name).List(listoptions.ResourcesByClusterName(res.Name))
+		if err != nil {
+			return 0, err
+		}
+		count := 0
+		for _, pod := range pods.Items {
+			config, err := asutils.GetConfig(pod.Status.PodIP, 3000, context)
+			if err != nil {
+				return 0, err
+			}
+			if config["memory-size"] == "2147483648" {
+				count++
+			}
+		}
+		return count, nil
+	}, 10*time.Minute, 5*time.Second).Should(Equal(int(nodeCount)))
+
+	// the namespace must still be backed by memory only
+	pvcs, err := tf.KubeClient.CoreV1().PersistentVolumeClaims(ns.Name).List(listoptions.ResourcesByClusterName(res.Name))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(pvcs.Items).To(BeEmpty())
+
+	c, err := framework.NewAerospikeClient(res)
+	Expect(err).NotTo(HaveOccurred())
+	t, err := c.GetNamespaceStorageEngine(ns1.Name)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(t).To(Equal(common.StorageTypeMemory))
+}
+
+func testMultipleVolumes(tf *framework.TestFramework, ns *v1.Namespace, storageType string, nodeCount int32, volumeCount int32) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	aerospikeCluster.Spec.NodeCount = nodeCount
+	var ns1 aerospikev1alpha2.AerospikeNamespaceSpec
+	switch storageType {
+	case common.StorageTypeDevice:
+		ns1 = tf.NewAerospikeNamespaceWithDeviceStorage("aerospike-namespace-0", 1, 1, 0, 1)
+	default:
+		ns1 = tf.NewAerospikeNamespaceWithFileStorage("aerospike-namespace-0", 1, 1, 0, 1)
+	}
+	ns1.Storage.VolumeCount = &volumeCount
+	aerospikeCluster.Spec.Namespaces = []aerospikev1alpha2.AerospikeNamespaceSpec{ns1}
+	res, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.WaitForClusterNodeCount(res, nodeCount)
+	Expect(err).NotTo(HaveOccurred())
+
+	pods, err := tf.KubeClient.CoreV1().Pods(ns.Name).List(listoptions.ResourcesByClusterName(res.Name))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(int32(len(pods.Items))).To(Equal(nodeCount))
+
+	// every pod must mount one distinct pvc per volume
+	for _, pod := range pods.Items {
+		claims := make(map[string]bool)
+		for _, volume := range pod.Spec.Volumes {
+			if volume.VolumeSource.PersistentVolumeClaim != nil {
+				claims[volume.VolumeSource.PersistentVolumeClaim.ClaimName] = true
+			}
+		}
+		Expect(int32(len(claims))).To(Equal(volumeCount))
+	}
+	pvcs, err := tf.KubeClient.CoreV1().PersistentVolumeClaims(ns.Name).List(listoptions.ResourcesByClusterName(res.Name))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(int32(len(pvcs.Items))).To(Equal(nodeCount * volumeCount))
+
+	c, err := framework.NewAerospikeClient(res)
+	Expect(err).NotTo(HaveOccurred())
+	t, err := c.GetNamespaceStorageEngine(ns1.Name)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(t).To(Equal(storageType))
+
+	// aerospike must be striping data across every volume
+	n, err := c.GetNamespaceStorageTargetCount(ns1.Name)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(int32(n)).To(Equal(volumeCount))
+}
+
+func testVolumeCountChange(tf *framework.TestFramework, ns *v1.Namespace) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	aerospikeCluster.Spec.NodeCount = 1
+	res, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.WaitForClusterNodeCount(res, 1)
+	Expect(err).NotTo(HaveOccurred())
+
+	res, err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(res.Namespace).Get(res.Name, metav1.GetOptions{})
+	Expect(err).NotTo(HaveOccurred())
+	res.Spec.Namespaces[0].Storage.VolumeCount = pointers.NewInt32(2)
+	_, err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(res.Namespace).Update(res)
+	Expect(err).To(HaveOccurred())
+	Expect(tf.ErrorCauses(err)).To(ContainElement(MatchRegexp("cannot change the number of volumes for namespace")))
+}
+
 func testVolumeIsReused(tf *framework.TestFramework, ns *v1.Namespace, nodeCount int32) {
 	Expect(nodeCount).To(BeNumerically(">", 1))
 	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
@@ -151,3 +311,135 @@ func testVolumeIsReused(tf *framework.TestFramework, ns *v1.Namespace, nodeCount
 		}
 	}
 }
+
+func testStorageSizeChange(tf *framework.TestFramework, ns *v1.Namespace, nodeCount int32, nRecords int) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	aerospikeCluster.Spec.NodeCount = nodeCount
+	aerospikeCluster.Spec.Namespaces[0].ReplicationFactor = pointers.NewInt32(2)
+	res, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.WaitForClusterNodeCount(res, nodeCount)
+	Expect(err).NotTo(HaveOccurred())
+
+	c1, err := framework.NewAerospikeClient(res)
+	Expect(err).NotTo(HaveOccurred())
+	err = c1.WriteSequentialIntegers(aerospikeCluster.Spec.Namespaces[0].Name, nRecords)
+	Expect(err).NotTo(HaveOccurred())
+	c1.Close()
+
+	err = tf.ChangeNamespaceStorageSizeAndWait(res, 2)
+	Expect(err).NotTo(HaveOccurred())
+
+	// make sure that every pod is using a persistent volume claim with the new size
+	pods, err := tf.KubeClient.CoreV1().Pods(ns.Name).List(listoptions.ResourcesByClusterName(res.Name))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(int32(len(pods.Items))).To(Equal(nodeCount))
+	for _, pod := range pods.Items {
+		for _, volume := range pod.Spec.Volumes {
+			if c := volume.VolumeSource.PersistentVolumeClaim; c != nil {
+				claim, err := tf.KubeClient.CoreV1().PersistentVolumeClaims(ns.Name).Get(c.ClaimName, metav1.GetOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				claimCapacity := claim.Status.Capacity[v1.ResourceStorage]
+				Expect(strings.TrimSuffix(claimCapacity.String(), "i")).To(Equal("2G"))
+			}
+		}
+	}
+
+	// make sure that the outdated persistent volume claims have been retired
+	claims, err := tf.KubeClient.CoreV1().PersistentVolumeClaims(ns.Name).List(listoptions.ResourcesByClusterName(res.Name))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(int32(len(claims.Items))).To(Equal(nodeCount))
+
+	c2, err := framework.NewAerospikeClient(res)
+	Expect(err).NotTo(HaveOccurred())
+	err = c2.ReadSequentialIntegers(aerospikeCluster.Spec.Namespaces[0].Name, nRecords)
+	Expect(err).NotTo(HaveOccurred())
+	c2.Close()
+}
+
+func testStorageChangeWithReplicationFactorOne(tf *framework.TestFramework, ns *v1.Namespace) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	res, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.WaitForClusterNodeCount(res, res.Spec.NodeCount)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.ChangeNamespaceStorageSizeAndWait(res, 2)
+	Expect(err).To(HaveOccurred())
+	Expect(tf.ErrorCauses(err)).To(ContainElement(MatchRegexp("cannot change the storage spec for namespace .+ with a replication factor lower than 2")))
+}
+
+func testStorageSizeExpansion(tf *framework.TestFramework, ns *v1.Namespace, nodeCount int32, nRecords int) {
+	sc, err := tf.CreateExpandableStorageClass()
+	Expect(err).NotTo(HaveOccurred())
+	defer tf.DeleteStorageClass(sc)
+
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	aerospikeCluster.Spec.NodeCount = nodeCount
+	aerospikeCluster.Spec.Namespaces[0].Storage.StorageClassName = pointers.NewString(sc.Name)
+	res, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.WaitForClusterNodeCount(res, nodeCount)
+	Expect(err).NotTo(HaveOccurred())
+
+	c1, err := framework.NewAerospikeClient(res)
+	Expect(err).NotTo(HaveOccurred())
+	err = c1.WriteSequentialIntegers(aerospikeCluster.Spec.Namespaces[0].Name, nRecords)
+	Expect(err).NotTo(HaveOccurred())
+	c1.Close()
+
+	// grab the name and uid of the persistent volume claims before the change
+	claims, err := tf.KubeClient.CoreV1().PersistentVolumeClaims(ns.Name).List(listoptions.ResourcesByClusterName(res.Name))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(int32(len(claims.Items))).To(Equal(nodeCount))
+	uids := make(map[string]types.UID, len(claims.Items))
+	for _, claim := range claims.Items {
+		uids[claim.Name] = claim.UID
+	}
+
+	// the storage class allows for volume expansion, so the change must be
+	// accepted even though the replication factor is 1
+	err = tf.ChangeNamespaceStorageSizeAndWait(res, 2)
+	Expect(err).NotTo(HaveOccurred())
+
+	// make sure that every pod is still using the same persistent volume
+	// claim, and that it has been expanded
+	pods, err := tf.KubeClient.CoreV1().Pods(ns.Name).List(listoptions.ResourcesByClusterName(res.Name))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(int32(len(pods.Items))).To(Equal(nodeCount))
+	for _, pod := range pods.Items {
+		for _, volume := range pod.Spec.Volumes {
+			if c := volume.VolumeSource.PersistentVolumeClaim; c != nil {
+				claim, err := tf.KubeClient.CoreV1().PersistentVolumeClaims(ns.Name).Get(c.ClaimName, metav1.GetOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(uids).To(HaveKeyWithValue(claim.Name, claim.UID))
+				claimCapacity := claim.Status.Capacity[v1.ResourceStorage]
+				Expect(strings.TrimSuffix(claimCapacity.String(), "i")).To(Equal("2G"))
+			}
+		}
+	}
+
+	c2, err := framework.NewAerospikeClient(res)
+	Expect(err).NotTo(HaveOccurred())
+	err = c2.ReadSequentialIntegers(aerospikeCluster.Spec.Namespaces[0].Name, nRecords)
+	Expect(err).NotTo(HaveOccurred())
+	c2.Close()
+}
+
+func testStorageSizeDecrease(tf *framework.TestFramework, ns *v1.Namespace) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	aerospikeCluster.Spec.NodeCount = 2
+	aerospikeCluster.Spec.Namespaces[0] = tf.NewAerospikeNamespaceWithFileStorage("aerospike-namespace-0", 2, 1, 0, 2)
+	res, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.WaitForClusterNodeCount(res, res.Spec.NodeCount)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.ChangeNamespaceStorageSizeAndWait(res, 1)
+	Expect(err).To(HaveOccurred())
+	Expect(tf.ErrorCauses(err)).To(ContainElement(MatchRegexp("cannot decrease the storage size for namespace")))
+}
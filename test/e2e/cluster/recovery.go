@@ -0,0 +1,196 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"strings"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/api/core/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/common"
+	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
+	"github.com/travelaudience/aerospike-operator/pkg/pointers"
+	"github.com/travelaudience/aerospike-operator/pkg/utils/annotations"
+	"github.com/travelaudience/aerospike-operator/pkg/utils/listoptions"
+	"github.com/travelaudience/aerospike-operator/test/e2e/framework"
+)
+
+func testRecoveryAfterFailedUpgrade(tf *framework.TestFramework, ns *v1.Namespace, nodeCount int32, action, sourceVersion, targetVersion string) {
+	asc := startFailingUpgrade(tf, ns, nodeCount, common.UpgradeFailurePolicyHalt, sourceVersion, targetVersion)
+
+	// wait for the upgrade to fail and for the cluster to be halted
+	asc = waitForClusterCondition(tf, asc, common.ConditionUpgradeHalted)
+	Expect(asc.Annotations).To(HaveKeyWithValue(annotations.UpgradeStatusAnnotationKey, annotations.UpgradeStatusFailedAnnotationValue))
+
+	// fix the bucket used for pre-upgrade backups and request the recovery
+	asc = requestRecovery(tf, asc, action)
+
+	asc = waitForClusterCondition(tf, asc, common.ConditionRecoveryFinished)
+	Expect(asc.Spec.Recovery).To(BeNil())
+	Expect(getCondition(asc.Status.Conditions, common.ConditionRecoveryFailed)).To(BeEmpty())
+
+	// wait for the cluster to be upgraded or rolled back, depending on the
+	// requested action
+	expectedVersion := targetVersion
+	if action == common.RecoveryActionResume {
+		asc = waitForClusterCondition(tf, asc, common.ConditionUpgradeFinished)
+	} else {
+		asc = waitForClusterCondition(tf, asc, common.ConditionUpgradeRollbackFinished)
+		expectedVersion = sourceVersion
+	}
+	Expect(asc.Spec.Version).To(Equal(expectedVersion))
+	Expect(asc.Status.Version).To(Equal(expectedVersion))
+	Expect(asc.Annotations).NotTo(HaveKey(annotations.UpgradeStatusAnnotationKey))
+	Expect(asc.Annotations).NotTo(HaveKey(annotations.FailedUpgradeVersionAnnotationKey))
+}
+
+func testRecoveryAfterPartialUpgrade(tf *framework.TestFramework, ns *v1.Namespace, nodeCount int32, nRecords int, sourceVersion, targetVersion string) {
+	aerospikeCluster := newAerospikeClusterForUpgrade(tf, nodeCount, common.UpgradeFailurePolicyHalt, framework.GCSBucketName, sourceVersion)
+	aerospikeCluster.Spec.Namespaces[0].ReplicationFactor = pointers.NewInt32(2)
+	asc, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.WaitForClusterNodeCount(asc, nodeCount)
+	Expect(err).NotTo(HaveOccurred())
+
+	// write data to the first namespace of the Aerospike cluster
+	c1, err := framework.NewAerospikeClient(asc)
+	Expect(err).NotTo(HaveOccurred())
+	err = c1.WriteSequentialIntegers(asc.Spec.Namespaces[0].Name, nRecords)
+	Expect(err).NotTo(HaveOccurred())
+	c1.Close()
+
+	// request the upgrade and make the second upgraded pod fail, so that the
+	// upgrade is halted after the first pod has already been upgraded
+	asc = requestUpgrade(tf, asc, targetVersion)
+	failUpgradedPod(tf, asc, targetVersion, 2)
+	asc = waitForClusterCondition(tf, asc, common.ConditionUpgradeHalted)
+	Expect(getCondition(asc.Status.Conditions, common.ConditionAutoBackupFinished)).To(Equal(apiextensions.ConditionTrue))
+
+	// resume the upgrade
+	asc = requestRecovery(tf, asc, common.RecoveryActionResume)
+	asc = waitForClusterCondition(tf, asc, common.ConditionRecoveryFinished)
+	Expect(getCondition(asc.Status.Conditions, common.ConditionRecoveryFailed)).To(BeEmpty())
+
+	// wait for the upgrade to finish on every pod
+	asc = waitForClusterCondition(tf, asc, common.ConditionUpgradeFinished)
+	Expect(asc.Spec.Version).To(Equal(targetVersion))
+	Expect(asc.Status.Version).To(Equal(targetVersion))
+	Expect(asc.Annotations).NotTo(HaveKey(annotations.UpgradeStatusAnnotationKey))
+	Expect(asc.Annotations).NotTo(HaveKey(annotations.FailedUpgradeVersionAnnotationKey))
+
+	err = tf.WaitForClusterNodeCount(asc, nodeCount)
+	Expect(err).NotTo(HaveOccurred())
+	pods, err := tf.KubeClient.CoreV1().Pods(ns.Name).List(listoptions.ResourcesByClusterName(asc.Name))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(pods.Items).To(HaveLen(int(nodeCount)))
+	for _, pod := range pods.Items {
+		Expect(getAerospikeServerImage(&pod)).To(HaveSuffix(":" + targetVersion))
+	}
+
+	// no data must have been lost
+	c2, err := framework.NewAerospikeClient(asc)
+	Expect(err).NotTo(HaveOccurred())
+	err = c2.ReadSequentialIntegers(asc.Spec.Namespaces[0].Name, nRecords)
+	Expect(err).NotTo(HaveOccurred())
+	c2.Close()
+}
+
+func testRecoveryFailsWithUnexpectedVersion(tf *framework.TestFramework, ns *v1.Namespace, nodeCount int32, sourceVersion, targetVersion, otherVersion string) {
+	asc := startFailingUpgrade(tf, ns, nodeCount, common.UpgradeFailurePolicyHalt, sourceVersion, targetVersion)
+	asc = waitForClusterCondition(tf, asc, common.ConditionUpgradeHalted)
+
+	// make a pod run a version that is neither the source nor the target one
+	pods, err := tf.KubeClient.CoreV1().Pods(ns.Name).List(listoptions.ResourcesByClusterName(asc.Name))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(pods.Items).NotTo(BeEmpty())
+	pod := &pods.Items[0]
+	setAerospikeServerImageVersion(tf, pod, otherVersion)
+	Eventually(func() (bool, error) {
+		pod, err := tf.KubeClient.CoreV1().Pods(ns.Name).Get(pods.Items[0].Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name == "aerospike-server" {
+				return status.Ready && strings.HasSuffix(status.Image, ":"+otherVersion), nil
+			}
+		}
+		return false, nil
+	}, 5*time.Minute, 5*time.Second).Should(BeTrue())
+
+	// request the recovery, which must be refused
+	asc = requestRecovery(tf, asc, common.RecoveryActionResume)
+	asc = waitForClusterCondition(tf, asc, common.ConditionRecoveryFailed)
+	Expect(asc.Spec.Recovery).To(BeNil())
+	Expect(getCondition(asc.Status.Conditions, common.ConditionRecoveryFinished)).To(BeEmpty())
+	Expect(asc.Spec.Version).To(Equal(targetVersion))
+	Expect(asc.Status.Version).To(Equal(sourceVersion))
+	Expect(asc.Annotations).To(HaveKeyWithValue(annotations.UpgradeStatusAnnotationKey, annotations.UpgradeStatusFailedAnnotationValue))
+}
+
+func testRecoveryWithoutFailedUpgrade(tf *framework.TestFramework, ns *v1.Namespace, nodeCount int32) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	aerospikeCluster.Spec.NodeCount = nodeCount
+	asc, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.WaitForClusterNodeCount(asc, nodeCount)
+	Expect(err).NotTo(HaveOccurred())
+
+	asc, err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Get(asc.Name, metav1.GetOptions{})
+	Expect(err).NotTo(HaveOccurred())
+	asc.Spec.Recovery = &aerospikev1alpha2.AerospikeClusterRecoverySpec{
+		Action: common.RecoveryActionResume,
+	}
+	_, err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Update(asc)
+	Expect(err).To(HaveOccurred())
+	Expect(err.Error()).To(MatchRegexp(".spec.recovery can only be specified for a cluster whose version upgrade has failed"))
+
+	// the cluster must not report any recovery
+	asc, err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Get(asc.Name, metav1.GetOptions{})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(getCondition(asc.Status.Conditions, common.ConditionRecoveryFinished)).To(BeEmpty())
+	Expect(getCondition(asc.Status.Conditions, common.ConditionRecoveryFailed)).To(BeEmpty())
+}
+
+// requestRecovery fixes the bucket used for the pre-upgrade backups of the
+// specified Aerospike cluster and requests the specified recovery action. the
+// cluster is read again on every attempt, since the operator may still be
+// updating it after halting the upgrade.
+func requestRecovery(tf *framework.TestFramework, asc *aerospikev1alpha2.AerospikeCluster, action string) *aerospikev1alpha2.AerospikeCluster {
+	var res *aerospikev1alpha2.AerospikeCluster
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(asc.Namespace).Get(asc.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		current.Spec.BackupSpec.Storage.Bucket = framework.GCSBucketName
+		current.Spec.Recovery = &aerospikev1alpha2.AerospikeClusterRecoverySpec{
+			Action: action,
+		}
+		res, err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(asc.Namespace).Update(current)
+		return err
+	})
+	Expect(err).NotTo(HaveOccurred())
+	return res
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"time"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
+	"github.com/travelaudience/aerospike-operator/pkg/reconciler"
+	"github.com/travelaudience/aerospike-operator/pkg/utils/selectors"
+	"github.com/travelaudience/aerospike-operator/test/e2e/framework"
+)
+
+func testReconcileWithPendingClusters(tf *framework.TestFramework, ns *v1.Namespace, pendingClusterCount int) {
+	// create clusters whose pods can never be scheduled, and which would
+	// otherwise keep the workers of the controller busy
+	pending := make([]*aerospikev1alpha2.AerospikeCluster, 0, pendingClusterCount)
+	for i := 0; i < pendingClusterCount; i++ {
+		aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+		aerospikeCluster.Spec.NodeCount = 1
+		aerospikeCluster.Spec.PodSpec = &aerospikev1alpha2.AerospikePodSpec{
+			NodeSelector: map[string]string{
+				"aerospike.travelaudience.com/non-existing-label": "true",
+			},
+		}
+		asc, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+		Expect(err).NotTo(HaveOccurred())
+		pending = append(pending, asc)
+	}
+
+	// make sure that a healthy cluster is still created in a timely fashion
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	aerospikeCluster.Spec.NodeCount = 1
+	asc, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+	err = tf.WaitForClusterNodeCount(asc, 1)
+	Expect(err).NotTo(HaveOccurred())
+
+	// make sure that the creation of the pending pods has been recorded so
+	// that it can be resumed
+	for _, asc := range pending {
+		asc, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Get(asc.Name, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(asc.Annotations).To(HaveKey(reconciler.PodOperationAnnotationKey))
+	}
+}
+
+func testResumeRollingRestartAfterOperatorRestart(tf *framework.TestFramework, ns *v1.Namespace, nodeCount int32) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	aerospikeCluster.Spec.NodeCount = nodeCount
+	asc, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+	err = tf.WaitForClusterNodeCount(asc, nodeCount)
+	Expect(err).NotTo(HaveOccurred())
+
+	// request a rolling restart of the cluster
+	asc, err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Get(asc.Name, metav1.GetOptions{})
+	Expect(err).NotTo(HaveOccurred())
+	restartedAt := metav1.NewTime(time.Now())
+	asc.Spec.RestartedAt = &restartedAt
+	asc, err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Update(asc)
+	Expect(err).NotTo(HaveOccurred())
+
+	// restart the operator as soon as the restart of the first pod has been
+	// recorded
+	err = tf.WaitForClusterCondition(asc, func(event watch.Event) (bool, error) {
+		obj := event.Object.(*aerospikev1alpha2.AerospikeCluster)
+		_, ok := obj.Annotations[reconciler.PodOperationAnnotationKey]
+		return ok, nil
+	}, 5*time.Minute)
+	Expect(err).NotTo(HaveOccurred())
+	err = tf.RestartOperator()
+	Expect(err).NotTo(HaveOccurred())
+
+	// make sure that the rolling restart is resumed and finishes
+	err = tf.WaitForClusterCondition(asc, func(event watch.Event) (bool, error) {
+		obj := event.Object.(*aerospikev1alpha2.AerospikeCluster)
+		return obj.Status.RestartedAt != nil && obj.Status.RestartedAt.Equal(asc.Spec.RestartedAt), nil
+	}, 20*time.Minute)
+	Expect(err).NotTo(HaveOccurred())
+	err = tf.WaitForClusterNodeCount(asc, nodeCount)
+	Expect(err).NotTo(HaveOccurred())
+
+	// make sure that every pod has been re-created and that no pod operation
+	// has been left behind
+	pods, err := tf.KubeClient.CoreV1().Pods(ns.Name).List(metav1.ListOptions{LabelSelector: selectors.ResourcesByClusterName(asc.Name).String()})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(pods.Items).To(HaveLen(int(nodeCount)))
+	for _, pod := range pods.Items {
+		Expect(pod.CreationTimestamp.Before(&restartedAt)).To(BeFalse())
+	}
+	asc, err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Get(asc.Name, metav1.GetOptions{})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(asc.Annotations).NotTo(HaveKey(reconciler.PodOperationAnnotationKey))
+}
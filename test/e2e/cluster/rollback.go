@@ -0,0 +1,238 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/api/core/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/common"
+	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
+	"github.com/travelaudience/aerospike-operator/pkg/pointers"
+	"github.com/travelaudience/aerospike-operator/pkg/utils/annotations"
+	"github.com/travelaudience/aerospike-operator/pkg/utils/listoptions"
+	"github.com/travelaudience/aerospike-operator/test/e2e/framework"
+)
+
+func testRollbackOnFailedUpgrade(tf *framework.TestFramework, ns *v1.Namespace, nodeCount int32, policy, sourceVersion, targetVersion string) {
+	asc := startFailingUpgrade(tf, ns, nodeCount, policy, sourceVersion, targetVersion)
+
+	// wait for the upgrade to fail and for the cluster to be rolled back
+	asc = waitForClusterCondition(tf, asc, common.ConditionUpgradeRollbackFinished)
+	Expect(getCondition(asc.Status.Conditions, common.ConditionAutoBackupFailed)).To(Equal(apiextensions.ConditionTrue))
+	Expect(getCondition(asc.Status.Conditions, common.ConditionUpgradeFailed)).To(Equal(apiextensions.ConditionTrue))
+	Expect(getCondition(asc.Status.Conditions, common.ConditionUpgradeRollbackStarted)).To(Equal(apiextensions.ConditionTrue))
+	Expect(getCondition(asc.Status.Conditions, common.ConditionUpgradeHalted)).To(BeEmpty())
+	Expect(asc.Spec.Version).To(Equal(sourceVersion))
+	Expect(asc.Status.Version).To(Equal(sourceVersion))
+	Expect(asc.Annotations).NotTo(HaveKey(annotations.UpgradeStatusAnnotationKey))
+	Expect(asc.Annotations).NotTo(HaveKey(annotations.FailedUpgradeVersionAnnotationKey))
+
+	// there are no pre-upgrade backups to restore
+	if policy == common.UpgradeFailurePolicyRestore {
+		Expect(getCondition(asc.Status.Conditions, common.ConditionAutoRestoreFailed)).To(Equal(apiextensions.ConditionTrue))
+	}
+
+	// the cluster must keep on being managed after the rollback
+	err := tf.ScaleCluster(asc, nodeCount+1)
+	Expect(err).NotTo(HaveOccurred())
+}
+
+func testRollbackOnFailedPodUpgrade(tf *framework.TestFramework, ns *v1.Namespace, nodeCount int32, nRecords int, policy, sourceVersion, targetVersion string) {
+	aerospikeCluster := newAerospikeClusterForUpgrade(tf, nodeCount, policy, framework.GCSBucketName, sourceVersion)
+	aerospikeCluster.Spec.Namespaces[0].ReplicationFactor = pointers.NewInt32(2)
+	asc, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.WaitForClusterNodeCount(asc, nodeCount)
+	Expect(err).NotTo(HaveOccurred())
+
+	// write data to the first namespace of the Aerospike cluster
+	c1, err := framework.NewAerospikeClient(asc)
+	Expect(err).NotTo(HaveOccurred())
+	err = c1.WriteSequentialIntegers(asc.Spec.Namespaces[0].Name, nRecords)
+	Expect(err).NotTo(HaveOccurred())
+	c1.Close()
+
+	// request the upgrade and make the first upgraded pod fail
+	asc = requestUpgrade(tf, asc, targetVersion)
+	failUpgradedPod(tf, asc, targetVersion, 1)
+
+	// wait for the upgrade to fail and for the cluster to be rolled back
+	asc = waitForClusterCondition(tf, asc, common.ConditionUpgradeRollbackFinished)
+	Expect(getCondition(asc.Status.Conditions, common.ConditionAutoBackupFinished)).To(Equal(apiextensions.ConditionTrue))
+	Expect(getCondition(asc.Status.Conditions, common.ConditionUpgradeFailed)).To(Equal(apiextensions.ConditionTrue))
+	Expect(getCondition(asc.Status.Conditions, common.ConditionUpgradeRollbackFailed)).To(BeEmpty())
+	Expect(asc.Spec.Version).To(Equal(sourceVersion))
+	Expect(asc.Status.Version).To(Equal(sourceVersion))
+
+	// wait for the namespaces to be restored from the pre-upgrade backups
+	if policy == common.UpgradeFailurePolicyRestore {
+		asc = waitForClusterCondition(tf, asc, common.ConditionAutoRestoreFinished)
+		Expect(getCondition(asc.Status.Conditions, common.ConditionAutoRestoreFailed)).To(BeEmpty())
+	}
+	Expect(asc.Annotations).NotTo(HaveKey(annotations.UpgradeStatusAnnotationKey))
+	Expect(asc.Annotations).NotTo(HaveKey(annotations.FailedUpgradeVersionAnnotationKey))
+
+	// every pod must be running the source version again
+	err = tf.WaitForClusterNodeCount(asc, nodeCount)
+	Expect(err).NotTo(HaveOccurred())
+	pods, err := tf.KubeClient.CoreV1().Pods(ns.Name).List(listoptions.ResourcesByClusterName(asc.Name))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(pods.Items).To(HaveLen(int(nodeCount)))
+	for _, pod := range pods.Items {
+		Expect(getAerospikeServerImage(&pod)).To(HaveSuffix(":" + sourceVersion))
+	}
+
+	// no data must have been lost
+	c2, err := framework.NewAerospikeClient(asc)
+	Expect(err).NotTo(HaveOccurred())
+	err = c2.ReadSequentialIntegers(asc.Spec.Namespaces[0].Name, nRecords)
+	Expect(err).NotTo(HaveOccurred())
+	c2.Close()
+}
+
+// startFailingUpgrade creates an Aerospike cluster whose pre-upgrade backups
+// fail, since the bucket they are made to does not exist, and requests its
+// upgrade to the target version.
+func startFailingUpgrade(tf *framework.TestFramework, ns *v1.Namespace, nodeCount int32, policy, sourceVersion, targetVersion string) *aerospikev1alpha2.AerospikeCluster {
+	aerospikeCluster := newAerospikeClusterForUpgrade(tf, nodeCount, policy, "non-existing-bucket", sourceVersion)
+	asc, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.WaitForClusterNodeCount(asc, nodeCount)
+	Expect(err).NotTo(HaveOccurred())
+
+	return requestUpgrade(tf, asc, targetVersion)
+}
+
+// newAerospikeClusterForUpgrade returns an Aerospike cluster running the
+// source version whose pre-upgrade backups are made to the specified bucket
+// and whose failed upgrades are handled according to the specified policy.
+func newAerospikeClusterForUpgrade(tf *framework.TestFramework, nodeCount int32, policy, bucket, sourceVersion string) aerospikev1alpha2.AerospikeCluster {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	aerospikeCluster.Spec.Version = sourceVersion
+	aerospikeCluster.Spec.NodeCount = nodeCount
+	aerospikeCluster.Spec.OnUpgradeFailure = pointers.NewString(policy)
+	aerospikeCluster.Spec.BackupSpec = &aerospikev1alpha2.AerospikeClusterBackupSpec{
+		Storage: aerospikev1alpha2.BackupStorageSpec{
+			Type:            common.StorageTypeGCS,
+			Bucket:          bucket,
+			Secret:          framework.GCSSecretName,
+			SecretNamespace: &framework.GCSSecretNamespace,
+			SecretKey:       &framework.GCSSecretKey,
+		},
+	}
+	return aerospikeCluster
+}
+
+// requestUpgrade requests the upgrade of the specified Aerospike cluster to
+// the target version.
+func requestUpgrade(tf *framework.TestFramework, asc *aerospikev1alpha2.AerospikeCluster, targetVersion string) *aerospikev1alpha2.AerospikeCluster {
+	asc, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(asc.Namespace).Get(asc.Name, metav1.GetOptions{})
+	Expect(err).NotTo(HaveOccurred())
+	asc.Spec.Version = targetVersion
+	asc, err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(asc.Namespace).Update(asc)
+	Expect(err).NotTo(HaveOccurred())
+	return asc
+}
+
+// failUpgradedPod waits for the specified number of pods of the specified
+// Aerospike cluster to be re-created with the target version, and replaces the
+// aerospike-server image of the one still being upgraded with one that does
+// not exist so that the pod enters a failure state.
+func failUpgradedPod(tf *framework.TestFramework, asc *aerospikev1alpha2.AerospikeCluster, targetVersion string, upgradedPods int) {
+	var pod *v1.Pod
+	Eventually(func() (bool, error) {
+		pods, err := tf.KubeClient.CoreV1().Pods(asc.Namespace).List(listoptions.ResourcesByClusterName(asc.Name))
+		if err != nil {
+			return false, err
+		}
+		upgraded := make([]*v1.Pod, 0, len(pods.Items))
+		for i := range pods.Items {
+			if strings.HasSuffix(getAerospikeServerImage(&pods.Items[i]), ":"+targetVersion) {
+				upgraded = append(upgraded, &pods.Items[i])
+			}
+		}
+		if len(upgraded) < upgradedPods {
+			return false, nil
+		}
+		// pods are upgraded one at a time, so prefer the one that is not yet
+		// ready over the ones whose upgrade has already finished
+		pod = upgraded[0]
+		for _, p := range upgraded {
+			if !isPodReady(p) {
+				pod = p
+			}
+		}
+		return true, nil
+	}, 15*time.Minute, 5*time.Second).Should(BeTrue())
+
+	setAerospikeServerImageVersion(tf, pod, "non-existing-version")
+}
+
+// setAerospikeServerImageVersion replaces the tag of the image used by the
+// aerospike-server container of the specified pod with the specified version.
+func setAerospikeServerImageVersion(tf *framework.TestFramework, pod *v1.Pod, version string) {
+	image := getAerospikeServerImage(pod)
+	image = image[:strings.LastIndex(image, ":")+1] + version
+	patch := fmt.Sprintf(`{"spec":{"containers":[{"name":"aerospike-server","image":"%s"}]}}`, image)
+	_, err := tf.KubeClient.CoreV1().Pods(pod.Namespace).Patch(pod.Name, types.StrategicMergePatchType, []byte(patch))
+	Expect(err).NotTo(HaveOccurred())
+}
+
+// isPodReady returns whether the specified pod is ready.
+func isPodReady(pod *v1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == v1.PodReady {
+			return c.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// getAerospikeServerImage returns the image used by the aerospike-server
+// container of the specified pod.
+func getAerospikeServerImage(pod *v1.Pod) string {
+	for _, container := range pod.Spec.Containers {
+		if container.Name == "aerospike-server" {
+			return container.Image
+		}
+	}
+	return ""
+}
+
+// waitForClusterCondition waits for the specified condition to be true, and
+// returns the latest version of the Aerospike cluster.
+func waitForClusterCondition(tf *framework.TestFramework, asc *aerospikev1alpha2.AerospikeCluster, conditionType apiextensions.CustomResourceDefinitionConditionType) *aerospikev1alpha2.AerospikeCluster {
+	var err error
+	Eventually(func() (apiextensions.ConditionStatus, error) {
+		asc, err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(asc.Namespace).Get(asc.Name, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		return getCondition(asc.Status.Conditions, conditionType), nil
+	}, 15*time.Minute, 10*time.Second).Should(Equal(apiextensions.ConditionTrue))
+	return asc
+}
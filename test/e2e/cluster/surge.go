@@ -0,0 +1,58 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/travelaudience/aerospike-operator/pkg/pointers"
+	"github.com/travelaudience/aerospike-operator/test/e2e/framework"
+)
+
+func testParallelPodCreation(tf *framework.TestFramework, ns *v1.Namespace, nodeCount, maxSurge int32) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	aerospikeCluster.Spec.NodeCount = nodeCount
+	aerospikeCluster.Spec.MaxSurge = pointers.NewInt32(maxSurge)
+	asc, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.WaitForClusterNodeCount(asc, nodeCount)
+	Expect(err).NotTo(HaveOccurred())
+
+	// the first pod is created on its own, after which the next maxSurge pods
+	// must all be created before any of them becomes ready
+	var lastCreated, firstReady time.Time
+	for i := 1; i <= int(maxSurge); i++ {
+		pod, err := tf.KubeClient.CoreV1().Pods(ns.Name).Get(fmt.Sprintf("%s-%d", asc.Name, i), metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		if pod.CreationTimestamp.After(lastCreated) {
+			lastCreated = pod.CreationTimestamp.Time
+		}
+		for _, c := range pod.Status.Conditions {
+			if c.Type == v1.PodReady && c.Status == v1.ConditionTrue && (firstReady.IsZero() || c.LastTransitionTime.Time.Before(firstReady)) {
+				firstReady = c.LastTransitionTime.Time
+			}
+		}
+	}
+	Expect(firstReady.IsZero()).To(BeFalse())
+	Expect(lastCreated.Before(firstReady)).To(BeTrue())
+}
@@ -26,6 +26,8 @@ import (
 	clustersuite "github.com/travelaudience/aerospike-operator/test/e2e/cluster"
 	"github.com/travelaudience/aerospike-operator/test/e2e/framework"
 	"github.com/travelaudience/aerospike-operator/test/e2e/garbagecollector"
+	"github.com/travelaudience/aerospike-operator/test/e2e/secondaryindexes"
+	"github.com/travelaudience/aerospike-operator/test/e2e/udfs"
 )
 
 var (
@@ -40,6 +42,8 @@ var _ = BeforeSuite(func() {
 	clustersuite.RegisterTestFramework(tf)
 	backupsuite.RegisterTestFramework(tf)
 	garbagecollector.RegisterTestFramework(tf)
+	secondaryindexes.RegisterTestFramework(tf)
+	udfs.RegisterTestFramework(tf)
 })
 
 func RunE2ETests(t *testing.T) {
@@ -18,6 +18,7 @@ package framework
 
 import (
 	"fmt"
+	"regexp"
 	"time"
 
 	as "github.com/aerospike/aerospike-client-go"
@@ -25,7 +26,6 @@ import (
 	"github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/common"
 	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
 	"github.com/travelaudience/aerospike-operator/pkg/asutils"
-	"github.com/travelaudience/aerospike-operator/pkg/reconciler"
 )
 
 type AerospikeClient struct {
@@ -104,7 +104,7 @@ func (ac *AerospikeClient) GetNodeNames() []string {
 }
 
 func (ac *AerospikeClient) GetNamespaceStorageEngine(namespace string) (string, error) {
-	c, err := as.NewConnection(fmt.Sprintf("%s:%d", ac.host, reconciler.ServicePort), 10*time.Second)
+	c, err := as.NewConnection(fmt.Sprintf("%s:%d", ac.host, asutils.ServicePort), 10*time.Second)
 	if err != nil {
 		return "", err
 	}
@@ -114,6 +114,9 @@ func (ac *AerospikeClient) GetNamespaceStorageEngine(namespace string) (string,
 		return "", err
 	}
 	stats := asutils.ParseStatistics(r[infoCmd])
+	if stats["storage-engine"] == common.StorageTypeMemory {
+		return common.StorageTypeMemory, nil
+	}
 	if _, ok := stats["storage-engine.device"]; ok {
 		return common.StorageTypeDevice, nil
 	}
@@ -129,8 +132,40 @@ func (ac *AerospikeClient) GetNamespaceStorageEngine(namespace string) (string,
 	return "", fmt.Errorf("namespace has unknown storage type")
 }
 
+// storageTargetKeyRegexp matches the keys that identify each device or file
+// used by a namespace (e.g. "storage-engine.file[1]").
+var storageTargetKeyRegexp = regexp.MustCompile(`^storage-engine\.(device|file)\[\d+\]$`)
+
+func (ac *AerospikeClient) GetNamespaceStorageTargetCount(namespace string) (int, error) {
+	c, err := as.NewConnection(fmt.Sprintf("%s:%d", ac.host, asutils.ServicePort), 10*time.Second)
+	if err != nil {
+		return 0, err
+	}
+	infoCmd := fmt.Sprintf("namespace/%s", namespace)
+	r, err := as.RequestInfo(c, infoCmd)
+	if err != nil {
+		return 0, err
+	}
+	stats := asutils.ParseStatistics(r[infoCmd])
+	count := 0
+	for key := range stats {
+		if storageTargetKeyRegexp.MatchString(key) {
+			count++
+		}
+	}
+	if count == 0 {
+		if _, ok := stats["storage-engine.device"]; ok {
+			return 1, nil
+		}
+		if _, ok := stats["storage-engine.file"]; ok {
+			return 1, nil
+		}
+	}
+	return count, nil
+}
+
 func (ac *AerospikeClient) IsDataInMemoryEnabled(namespace string) (bool, error) {
-	c, err := as.NewConnection(fmt.Sprintf("%s:%d", ac.host, reconciler.ServicePort), 10*time.Second)
+	c, err := as.NewConnection(fmt.Sprintf("%s:%d", ac.host, asutils.ServicePort), 10*time.Second)
 	if err != nil {
 		return false, err
 	}
@@ -145,3 +180,22 @@ func (ac *AerospikeClient) IsDataInMemoryEnabled(namespace string) (bool, error)
 	}
 	return false, fmt.Errorf("namespace has unknown storage type")
 }
+
+func (ac *AerospikeClient) SecondaryIndexExists(namespace, name string) (bool, error) {
+	_, err := asutils.GetSecondaryIndexLoadPercentage(ac.host, asutils.ServicePort, namespace, name)
+	if err == asutils.ErrSecondaryIndexNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (ac *AerospikeClient) GetUDFHash(filename string) (string, error) {
+	udfs, err := asutils.ListUDFs(ac.host, asutils.ServicePort)
+	if err != nil {
+		return "", err
+	}
+	return udfs[filename], nil
+}
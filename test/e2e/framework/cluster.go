@@ -81,6 +81,18 @@ func (tf *TestFramework) NewAerospikeNamespaceWithFileStorage(name string, repli
 	}
 }
 
+func (tf *TestFramework) NewAerospikeNamespaceWithMemoryStorage(name string, replicationFactor int32, memorySizeGB int, defaultTTLSeconds int) aerospikev1alpha2.AerospikeNamespaceSpec {
+	return aerospikev1alpha2.AerospikeNamespaceSpec{
+		Name:              name,
+		ReplicationFactor: &replicationFactor,
+		MemorySize:        pointers.NewString(fmt.Sprintf("%dG", memorySizeGB)),
+		DefaultTTL:        pointers.NewString(fmt.Sprintf("%ds", defaultTTLSeconds)),
+		Storage: aerospikev1alpha2.StorageSpec{
+			Type: common.StorageTypeMemory,
+		},
+	}
+}
+
 func (tf *TestFramework) WaitForClusterCondition(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, fn watch.ConditionFunc, timeout time.Duration) error {
 	w, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(aerospikeCluster.Namespace).Watch(listoptions.ObjectByName(aerospikeCluster.Name))
 	if err != nil {
@@ -137,6 +149,23 @@ func (tf *TestFramework) ChangeNamespaceMemorySizeAndScaleClusterAndWait(aerospi
 	return tf.WaitForClusterNodeCount(res, nodeCount)
 }
 
+func (tf *TestFramework) ChangeNamespaceStorageSizeAndWait(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, newStorageSizeGB int) error {
+	res, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(aerospikeCluster.Namespace).Get(aerospikeCluster.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	res.Spec.Namespaces[0].Storage.Size = fmt.Sprintf("%dG", newStorageSizeGB)
+	if res, err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(res.Namespace).Update(res); err != nil {
+		return err
+	}
+	return tf.WaitForClusterCondition(res, func(event watch.Event) (bool, error) {
+		// grab the current cluster object from the event
+		obj := event.Object.(*aerospikev1alpha2.AerospikeCluster)
+		// check whether the new storage spec has been applied
+		return len(obj.Status.Namespaces) > 0 && obj.Status.Namespaces[0].Storage.Size == res.Spec.Namespaces[0].Storage.Size, nil
+	}, watchTimeout)
+}
+
 func (tf *TestFramework) NewAerospikeClusterV1alpha1(version string, nodeCount int32, namespaces []aerospikev1alpha1.AerospikeNamespaceSpec) aerospikev1alpha1.AerospikeCluster {
 	return aerospikev1alpha1.AerospikeCluster{
 		ObjectMeta: metav1.ObjectMeta{
@@ -168,3 +197,38 @@ func (tf *TestFramework) NewAerospikeNamespaceWithFileStorageV1alpha1(name strin
 		},
 	}
 }
+
+func (tf *TestFramework) SetClusterPausedAndWait(aerospikeCluster *aerospikev1alpha2.AerospikeCluster, paused bool) error {
+	res, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(aerospikeCluster.Namespace).Get(aerospikeCluster.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	res.Spec.Paused = paused
+	if res, err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(res.Namespace).Update(res); err != nil {
+		return err
+	}
+	return tf.WaitForClusterCondition(res, func(event watch.Event) (bool, error) {
+		// grab the current cluster object from the event
+		obj := event.Object.(*aerospikev1alpha2.AerospikeCluster)
+		// check whether the pause has been acknowledged
+		return obj.Status.Paused == paused, nil
+	}, watchTimeout)
+}
+
+func (tf *TestFramework) RestartClusterAndWait(aerospikeCluster *aerospikev1alpha2.AerospikeCluster) error {
+	res, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(aerospikeCluster.Namespace).Get(aerospikeCluster.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	restartedAt := metav1.NewTime(time.Now())
+	res.Spec.RestartedAt = &restartedAt
+	if res, err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(res.Namespace).Update(res); err != nil {
+		return err
+	}
+	return tf.WaitForClusterCondition(res, func(event watch.Event) (bool, error) {
+		// grab the current cluster object from the event
+		obj := event.Object.(*aerospikev1alpha2.AerospikeCluster)
+		// check whether the rolling restart has finished
+		return obj.Status.RestartedAt != nil && obj.Status.RestartedAt.Equal(res.Spec.RestartedAt), nil
+	}, watchTimeout)
+}
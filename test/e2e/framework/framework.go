@@ -19,6 +19,7 @@ package framework
 import (
 	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
 
@@ -27,6 +28,11 @@ import (
 
 const (
 	watchTimeout = 10 * time.Minute
+
+	// operatorNamespace is the namespace in which aerospike-operator runs
+	operatorNamespace = "aerospike-operator"
+	// operatorSelector selects the pods that run aerospike-operator
+	operatorSelector = "app=aerospike-operator"
 )
 
 type TestFramework struct {
@@ -52,3 +58,9 @@ func NewTestFramework(kubeconfigPath string) (*TestFramework, error) {
 		KubeClient:      kubeClient,
 	}, nil
 }
+
+// RestartOperator deletes the pods that run aerospike-operator so that they
+// are re-created by their deployment.
+func (tf *TestFramework) RestartOperator() error {
+	return tf.KubeClient.CoreV1().Pods(operatorNamespace).DeleteCollection(&metav1.DeleteOptions{}, metav1.ListOptions{LabelSelector: operatorSelector})
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/common"
+	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
+	"github.com/travelaudience/aerospike-operator/pkg/meta"
+	"github.com/travelaudience/aerospike-operator/pkg/utils/listoptions"
+)
+
+const (
+	secondaryIndexPrefix = "as-sindex-e2e-"
+)
+
+func (tf *TestFramework) NewAerospikeSecondaryIndex(cluster *aerospikev1alpha2.AerospikeCluster, namespace, bin, indexType string) aerospikev1alpha2.AerospikeSecondaryIndex {
+	return aerospikev1alpha2.AerospikeSecondaryIndex{
+		ObjectMeta: v1.ObjectMeta{
+			GenerateName: secondaryIndexPrefix,
+		},
+		Spec: aerospikev1alpha2.AerospikeSecondaryIndexSpec{
+			Target: aerospikev1alpha2.TargetNamespace{
+				Cluster:   cluster.Name,
+				Namespace: namespace,
+			},
+			Bin:  bin,
+			Type: indexType,
+		},
+	}
+}
+
+func (tf *TestFramework) WaitForSecondaryIndexCondition(obj *aerospikev1alpha2.AerospikeSecondaryIndex, fn watch.ConditionFunc, timeout time.Duration) error {
+	w, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeSecondaryIndexes(obj.Namespace).Watch(listoptions.ObjectByName(obj.Name))
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	last, err := watch.Until(timeout, w, fn)
+	if err != nil {
+		if err == watch.ErrWatchClosed {
+			if t := timeout - time.Since(start); t > 0 {
+				return tf.WaitForSecondaryIndexCondition(obj, fn, t)
+			}
+		}
+		return err
+	}
+	if last == nil {
+		return fmt.Errorf("no events received for %s", meta.Key(obj))
+	}
+	return nil
+}
+
+// WaitForSecondaryIndexBuilt waits for the secondary index to have been built
+// the specified number of times.
+func (tf *TestFramework) WaitForSecondaryIndexBuilt(obj *aerospikev1alpha2.AerospikeSecondaryIndex, times int) error {
+	return tf.WaitForSecondaryIndexCondition(obj, func(event watch.Event) (bool, error) {
+		obj := event.Object.(*aerospikev1alpha2.AerospikeSecondaryIndex)
+		count := 0
+		for _, c := range obj.Status.Conditions {
+			if c.Type == common.ConditionSecondaryIndexBuilt {
+				count++
+			}
+		}
+		return count >= times, nil
+	}, watchTimeout)
+}
+
+func (tf *TestFramework) WaitForSecondaryIndexDeleted(obj *aerospikev1alpha2.AerospikeSecondaryIndex) error {
+	return tf.WaitForSecondaryIndexCondition(obj, func(event watch.Event) (bool, error) {
+		return event.Type == watch.Deleted, nil
+	}, watchTimeout)
+}
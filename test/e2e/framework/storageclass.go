@@ -0,0 +1,63 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"fmt"
+
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/travelaudience/aerospike-operator/pkg/pointers"
+)
+
+const (
+	storageClassPrefix = "as-e2e-"
+
+	defaultStorageClassAnnotation     = "storageclass.kubernetes.io/is-default-class"
+	betaDefaultStorageClassAnnotation = "storageclass.beta.kubernetes.io/is-default-class"
+)
+
+// CreateExpandableStorageClass creates a copy of the default storage class
+// that allows for persistent volumes to be expanded.
+func (tf *TestFramework) CreateExpandableStorageClass() (*storagev1.StorageClass, error) {
+	scs, err := tf.KubeClient.StorageV1().StorageClasses().List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, sc := range scs.Items {
+		if sc.Annotations[defaultStorageClassAnnotation] != "true" && sc.Annotations[betaDefaultStorageClassAnnotation] != "true" {
+			continue
+		}
+		return tf.KubeClient.StorageV1().StorageClasses().Create(&storagev1.StorageClass{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: storageClassPrefix,
+			},
+			Provisioner:          sc.Provisioner,
+			Parameters:           sc.Parameters,
+			ReclaimPolicy:        sc.ReclaimPolicy,
+			MountOptions:         sc.MountOptions,
+			VolumeBindingMode:    sc.VolumeBindingMode,
+			AllowVolumeExpansion: pointers.NewBool(true),
+		})
+	}
+	return nil, fmt.Errorf("no default storage class found")
+}
+
+func (tf *TestFramework) DeleteStorageClass(sc *storagev1.StorageClass) error {
+	return tf.KubeClient.StorageV1().StorageClasses().Delete(sc.Name, metav1.NewDeleteOptions(0))
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/common"
+	aerospikev1alpha2 "github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/v1alpha2"
+	"github.com/travelaudience/aerospike-operator/pkg/meta"
+	"github.com/travelaudience/aerospike-operator/pkg/utils/listoptions"
+)
+
+const (
+	udfPrefix = "as-udf-e2e-"
+)
+
+func (tf *TestFramework) NewAerospikeUDFWithInlineSource(cluster *aerospikev1alpha2.AerospikeCluster, source string) aerospikev1alpha2.AerospikeUDF {
+	return aerospikev1alpha2.AerospikeUDF{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: udfPrefix,
+		},
+		Spec: aerospikev1alpha2.AerospikeUDFSpec{
+			Target: aerospikev1alpha2.TargetCluster{
+				Cluster: cluster.Name,
+			},
+			Source: aerospikev1alpha2.UDFSource{
+				Inline: source,
+			},
+		},
+	}
+}
+
+func (tf *TestFramework) NewAerospikeUDFWithConfigMapSource(cluster *aerospikev1alpha2.AerospikeCluster, configMap *v1.ConfigMap, key string) aerospikev1alpha2.AerospikeUDF {
+	return aerospikev1alpha2.AerospikeUDF{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: udfPrefix,
+		},
+		Spec: aerospikev1alpha2.AerospikeUDFSpec{
+			Target: aerospikev1alpha2.TargetCluster{
+				Cluster: cluster.Name,
+			},
+			Source: aerospikev1alpha2.UDFSource{
+				ConfigMapKeyRef: &v1.ConfigMapKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{
+						Name: configMap.Name,
+					},
+					Key: key,
+				},
+			},
+		},
+	}
+}
+
+func (tf *TestFramework) WaitForUDFCondition(obj *aerospikev1alpha2.AerospikeUDF, fn watch.ConditionFunc, timeout time.Duration) error {
+	w, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeUDFs(obj.Namespace).Watch(listoptions.ObjectByName(obj.Name))
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	last, err := watch.Until(timeout, w, fn)
+	if err != nil {
+		if err == watch.ErrWatchClosed {
+			if t := timeout - time.Since(start); t > 0 {
+				return tf.WaitForUDFCondition(obj, fn, t)
+			}
+		}
+		return err
+	}
+	if last == nil {
+		return fmt.Errorf("no events received for %s", meta.Key(obj))
+	}
+	return nil
+}
+
+// WaitForUDFRegistered waits for the udf module to have been registered the
+// specified number of times.
+func (tf *TestFramework) WaitForUDFRegistered(obj *aerospikev1alpha2.AerospikeUDF, times int) error {
+	return tf.WaitForUDFCondition(obj, func(event watch.Event) (bool, error) {
+		obj := event.Object.(*aerospikev1alpha2.AerospikeUDF)
+		count := 0
+		for _, c := range obj.Status.Conditions {
+			if c.Type == common.ConditionUDFRegistered {
+				count++
+			}
+		}
+		return count >= times, nil
+	}, watchTimeout)
+}
+
+func (tf *TestFramework) WaitForUDFDeleted(obj *aerospikev1alpha2.AerospikeUDF) error {
+	return tf.WaitForUDFCondition(obj, func(event watch.Event) (bool, error) {
+		return event.Type == watch.Deleted, nil
+	}, watchTimeout)
+}
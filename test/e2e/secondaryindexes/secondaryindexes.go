@@ -0,0 +1,144 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secondaryindexes
+
+import (
+	. "github.com/onsi/gomega"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/travelaudience/aerospike-operator/pkg/apis/aerospike/common"
+	"github.com/travelaudience/aerospike-operator/test/e2e/framework"
+)
+
+func testSecondaryIndexCreateAndDelete(tf *framework.TestFramework, ns *v1.Namespace, nRecords int) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	asc, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.WaitForClusterNodeCount(asc, aerospikeCluster.Spec.NodeCount)
+	Expect(err).NotTo(HaveOccurred())
+
+	c, err := framework.NewAerospikeClient(asc)
+	Expect(err).NotTo(HaveOccurred())
+	defer c.Close()
+	err = c.WriteSequentialIntegers(asc.Spec.Namespaces[0].Name, nRecords)
+	Expect(err).NotTo(HaveOccurred())
+
+	asIndex := tf.NewAerospikeSecondaryIndex(asc, asc.Spec.Namespaces[0].Name, "idx", common.SecondaryIndexTypeNumeric)
+	index, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeSecondaryIndexes(ns.Name).Create(&asIndex)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.WaitForSecondaryIndexBuilt(index, 1)
+	Expect(err).NotTo(HaveOccurred())
+
+	index, err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeSecondaryIndexes(ns.Name).Get(index.Name, metav1.GetOptions{})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(index.Status.BuildProgress).To(BeEquivalentTo(100))
+	exists, err := c.SecondaryIndexExists(asc.Spec.Namespaces[0].Name, index.Name)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(exists).To(BeTrue())
+
+	err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeSecondaryIndexes(ns.Name).Delete(index.Name, &metav1.DeleteOptions{})
+	Expect(err).NotTo(HaveOccurred())
+	err = tf.WaitForSecondaryIndexDeleted(index)
+	Expect(err).NotTo(HaveOccurred())
+
+	exists, err = c.SecondaryIndexExists(asc.Spec.Namespaces[0].Name, index.Name)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(exists).To(BeFalse())
+}
+
+func testSecondaryIndexRecreatedWithCluster(tf *framework.TestFramework, ns *v1.Namespace) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	asc, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.WaitForClusterNodeCount(asc, aerospikeCluster.Spec.NodeCount)
+	Expect(err).NotTo(HaveOccurred())
+
+	asIndex := tf.NewAerospikeSecondaryIndex(asc, asc.Spec.Namespaces[0].Name, "idx", common.SecondaryIndexTypeString)
+	index, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeSecondaryIndexes(ns.Name).Create(&asIndex)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.WaitForSecondaryIndexBuilt(index, 1)
+	Expect(err).NotTo(HaveOccurred())
+
+	// delete the cluster and re-create it with the same name
+	err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Delete(asc.Name, &metav1.DeleteOptions{})
+	Expect(err).NotTo(HaveOccurred())
+	Eventually(func() bool {
+		_, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Get(asc.Name, metav1.GetOptions{})
+		return err != nil
+	}, "1m").Should(BeTrue())
+	aerospikeCluster.Name = asc.Name
+	aerospikeCluster.GenerateName = ""
+	asc, err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.WaitForClusterNodeCount(asc, aerospikeCluster.Spec.NodeCount)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.WaitForSecondaryIndexBuilt(index, 2)
+	Expect(err).NotTo(HaveOccurred())
+
+	c, err := framework.NewAerospikeClient(asc)
+	Expect(err).NotTo(HaveOccurred())
+	defer c.Close()
+	exists, err := c.SecondaryIndexExists(asc.Spec.Namespaces[0].Name, index.Name)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(exists).To(BeTrue())
+}
+
+func testSecondaryIndexWithNonExistingNamespace(tf *framework.TestFramework, ns *v1.Namespace) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	asc, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	asIndex := tf.NewAerospikeSecondaryIndex(asc, "non-existing-namespace", "idx", common.SecondaryIndexTypeNumeric)
+	_, err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeSecondaryIndexes(ns.Name).Create(&asIndex)
+	Expect(err).To(HaveOccurred())
+	Expect(tf.ErrorCauses(err)).To(ContainElement(MatchRegexp("does not contain a namespace named")))
+}
+
+func testSecondaryIndexOnIndexedBin(tf *framework.TestFramework, ns *v1.Namespace) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	asc, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	asIndex := tf.NewAerospikeSecondaryIndex(asc, asc.Spec.Namespaces[0].Name, "idx", common.SecondaryIndexTypeNumeric)
+	_, err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeSecondaryIndexes(ns.Name).Create(&asIndex)
+	Expect(err).NotTo(HaveOccurred())
+	_, err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeSecondaryIndexes(ns.Name).Create(&asIndex)
+	Expect(err).To(HaveOccurred())
+	Expect(tf.ErrorCauses(err)).To(ContainElement(MatchRegexp("bin idx is already indexed")))
+}
+
+func testSecondaryIndexSpecChange(tf *framework.TestFramework, ns *v1.Namespace) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	asc, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	asIndex := tf.NewAerospikeSecondaryIndex(asc, asc.Spec.Namespaces[0].Name, "idx", common.SecondaryIndexTypeNumeric)
+	index, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeSecondaryIndexes(ns.Name).Create(&asIndex)
+	Expect(err).NotTo(HaveOccurred())
+
+	index.Spec.Bin = "other"
+	_, err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeSecondaryIndexes(ns.Name).Update(index)
+	Expect(err).To(HaveOccurred())
+	Expect(tf.ErrorCauses(err)).To(ContainElement(MatchRegexp("cannot be changed after creation")))
+}
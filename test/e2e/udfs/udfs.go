@@ -0,0 +1,136 @@
+/*
+Copyright 2018 The aerospike-operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package udfs
+
+import (
+	. "github.com/onsi/gomega"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/travelaudience/aerospike-operator/pkg/asutils"
+	"github.com/travelaudience/aerospike-operator/pkg/udf"
+	"github.com/travelaudience/aerospike-operator/test/e2e/framework"
+)
+
+const (
+	udfSource      = "function hello(rec)\n  return \"hello\"\nend\n"
+	otherUDFSource = "function hello(rec)\n  return \"hello again\"\nend\n"
+	udfSourceKey   = "hello.lua"
+)
+
+func testUDFRegisterAndRemove(tf *framework.TestFramework, ns *v1.Namespace) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	asc, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.WaitForClusterNodeCount(asc, aerospikeCluster.Spec.NodeCount)
+	Expect(err).NotTo(HaveOccurred())
+
+	asUDF := tf.NewAerospikeUDFWithInlineSource(asc, udfSource)
+	obj, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeUDFs(ns.Name).Create(&asUDF)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.WaitForUDFRegistered(obj, 1)
+	Expect(err).NotTo(HaveOccurred())
+
+	obj, err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeUDFs(ns.Name).Get(obj.Name, metav1.GetOptions{})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(obj.Status.Hash).To(Equal(asutils.UDFHash(udfSource)))
+
+	c, err := framework.NewAerospikeClient(asc)
+	Expect(err).NotTo(HaveOccurred())
+	defer c.Close()
+	hash, err := c.GetUDFHash(udf.ModuleName(obj))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(hash).To(Equal(obj.Status.Hash))
+
+	err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeUDFs(ns.Name).Delete(obj.Name, &metav1.DeleteOptions{})
+	Expect(err).NotTo(HaveOccurred())
+	err = tf.WaitForUDFDeleted(obj)
+	Expect(err).NotTo(HaveOccurred())
+
+	hash, err = c.GetUDFHash(udf.ModuleName(obj))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(hash).To(BeEmpty())
+}
+
+func testUDFFromConfigMap(tf *framework.TestFramework, ns *v1.Namespace) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	asc, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.WaitForClusterNodeCount(asc, aerospikeCluster.Spec.NodeCount)
+	Expect(err).NotTo(HaveOccurred())
+
+	cm, err := tf.KubeClient.CoreV1().ConfigMaps(ns.Name).Create(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "as-udf-e2e-",
+		},
+		Data: map[string]string{
+			udfSourceKey: udfSource,
+		},
+	})
+	Expect(err).NotTo(HaveOccurred())
+
+	asUDF := tf.NewAerospikeUDFWithConfigMapSource(asc, cm, udfSourceKey)
+	obj, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeUDFs(ns.Name).Create(&asUDF)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.WaitForUDFRegistered(obj, 1)
+	Expect(err).NotTo(HaveOccurred())
+
+	// change the lua code and wait for the udf module to be re-registered
+	cm.Data[udfSourceKey] = otherUDFSource
+	_, err = tf.KubeClient.CoreV1().ConfigMaps(ns.Name).Update(cm)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = tf.WaitForUDFRegistered(obj, 2)
+	Expect(err).NotTo(HaveOccurred())
+
+	c, err := framework.NewAerospikeClient(asc)
+	Expect(err).NotTo(HaveOccurred())
+	defer c.Close()
+	hash, err := c.GetUDFHash(udf.ModuleName(obj))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(hash).To(Equal(asutils.UDFHash(otherUDFSource)))
+}
+
+func testUDFWithoutSource(tf *framework.TestFramework, ns *v1.Namespace) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	asc, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	asUDF := tf.NewAerospikeUDFWithInlineSource(asc, "")
+	_, err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeUDFs(ns.Name).Create(&asUDF)
+	Expect(err).To(HaveOccurred())
+	Expect(tf.ErrorCauses(err)).To(ContainElement(MatchRegexp("exactly one of inline or configMapKeyRef must be specified")))
+}
+
+func testUDFTargetChange(tf *framework.TestFramework, ns *v1.Namespace) {
+	aerospikeCluster := tf.NewAerospikeClusterWithDefaults()
+	asc, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeClusters(ns.Name).Create(&aerospikeCluster)
+	Expect(err).NotTo(HaveOccurred())
+
+	asUDF := tf.NewAerospikeUDFWithInlineSource(asc, udfSource)
+	obj, err := tf.AerospikeClient.AerospikeV1alpha2().AerospikeUDFs(ns.Name).Create(&asUDF)
+	Expect(err).NotTo(HaveOccurred())
+
+	obj.Spec.Target.Cluster = "other"
+	_, err = tf.AerospikeClient.AerospikeV1alpha2().AerospikeUDFs(ns.Name).Update(obj)
+	Expect(err).To(HaveOccurred())
+	Expect(tf.ErrorCauses(err)).To(ContainElement(MatchRegexp("cannot be changed after creation")))
+}